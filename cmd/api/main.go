@@ -2,6 +2,8 @@ package main
 
 import (
 	"log"
+	"log/slog"
+	"net/http"
 	"time"
 
 	_ "goUniAdmin/docs"
@@ -9,6 +11,19 @@ import (
 	"goUniAdmin/internal/db"
 	"goUniAdmin/internal/modules"
 	"goUniAdmin/internal/modules/admin"
+	"goUniAdmin/internal/modules/audit"
+	"goUniAdmin/internal/modules/emailtemplate"
+	"goUniAdmin/internal/modules/files"
+	"goUniAdmin/internal/modules/mastermanagement"
+	"goUniAdmin/internal/modules/roles"
+	"goUniAdmin/internal/modules/settings"
+	"goUniAdmin/internal/modules/staticpagemanagement"
+	"goUniAdmin/internal/modules/translations"
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/logger"
+	"goUniAdmin/internal/services/middleware"
+	"goUniAdmin/internal/services/response"
+	"goUniAdmin/internal/services/revocation"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -35,6 +50,7 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	logger.Init(cfg)
 
 	dbConn, err := db.NewDB(cfg)
 	if err != nil {
@@ -44,16 +60,31 @@ func main() {
 	config.ConfigInstance = cfg
 	db.DBInstance = dbConn
 
-	admin.RegisterAdminModule(cfg, dbConn)
+	if _, err := revocation.NewStore(dbConn); err != nil {
+		log.Fatal("Failed to initialize token revocation store:", err)
+	}
 
-	router := gin.Default()
+	// Audit first so its callbacks are in place before other modules write
+	audit.RegisterAuditModule(cfg, dbConn)
+	admin.RegisterAdminModule(cfg, dbConn)
+	roles.RegisterRolesModule(cfg, dbConn)
+	emailtemplate.RegisterEmailTemplateModule(cfg, dbConn)
+	settings.RegisterSettingsModule(cfg, dbConn)
+	staticpagemanagement.RegisterStaticPageModule(cfg, dbConn)
+	mastermanagement.RegisterMasterModule(cfg, dbConn)
+	files.RegisterFilesModule(cfg, dbConn)
+	translations.RegisterTranslationsModule(cfg, dbConn)
+
+	gin.SetMode(cfg.GinMode)
+	router := gin.New()
+	router.Use(middleware.RequestID(), middleware.RequestLogger(), middleware.Recovery())
 
 	// CORS configuration
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept", middleware.RequestIDHeader},
+		ExposeHeaders:    []string{"Content-Length", middleware.RequestIDHeader},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
@@ -82,13 +113,9 @@ func main() {
 
 	// 404 handler
 	router.NoRoute(func(c *gin.Context) {
-		c.JSON(404, gin.H{
-			"success": false,
-			"error":   "Endpoint not found",
-			"path":    c.Request.URL.Path,
-		})
+		response.Error(c, apperror.New(http.StatusNotFound, "ROUTE_NOT_FOUND", "endpoint not found").WithDetails(gin.H{"path": c.Request.URL.Path}))
 	})
 
-	log.Printf("Starting server on %s", cfg.Port)
+	slog.Info("Starting server", "port", cfg.Port)
 	log.Fatal(router.Run(cfg.Port))
 }
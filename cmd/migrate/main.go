@@ -0,0 +1,116 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/services/logger"
+	"goUniAdmin/migrations"
+)
+
+const usage = `Usage: migrate [-dir migrations] <command>
+
+Commands:
+  up             Apply all pending migrations
+  down [N]       Revert the last N applied migrations (default 1)
+  status         List migrations and whether they are applied
+  create <name>  Create a new empty migration file
+`
+
+func main() {
+	dir := flag.String("dir", "migrations", "Directory containing the migration files (used by create)")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "up", "down", "status", "create":
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// create only writes a file and does not need a database connection
+	if args[0] == "create" {
+		if len(args) != 2 {
+			log.Fatal("Usage: migrate create <name>")
+		}
+		path, err := migrations.Create(*dir, args[1])
+		if err != nil {
+			log.Fatal("Failed to create migration:", err)
+		}
+		log.Printf("Created %s", path)
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	logger.Init(cfg)
+
+	dbConn, err := db.NewDB(cfg)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	migrator := migrations.NewMigrator(dbConn.DB)
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, m := range applied {
+			log.Printf("Applied %03d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(applied) == 0 {
+			log.Println("No pending migrations")
+		}
+
+	case "down":
+		n := 1
+		if len(args) > 1 {
+			n, err = strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				log.Fatal("N must be a positive number")
+			}
+		}
+		reverted, err := migrator.Down(n)
+		for _, m := range reverted {
+			log.Printf("Reverted %03d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(reverted) == 0 {
+			log.Println("No applied migrations to revert")
+		}
+
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.AppliedAt != nil {
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if s.Missing {
+				state += " (missing from code)"
+			}
+			fmt.Printf("%03d  %-30s %s\n", s.Version, s.Name, state)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/services/logger"
+	"goUniAdmin/internal/services/seed"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	logger.Init(cfg)
+
+	// Flags default to the SEED_* environment variables, which may also come from .env.
+	// Prefer SEED_ADMIN_PASSWORD over -password so the password stays out of the process list.
+	email := flag.String("email", os.Getenv("SEED_ADMIN_EMAIL"), "Super admin email (SEED_ADMIN_EMAIL)")
+	password := flag.String("password", os.Getenv("SEED_ADMIN_PASSWORD"), "Super admin password (SEED_ADMIN_PASSWORD)")
+	firstName := flag.String("first-name", getEnv("SEED_ADMIN_FIRST_NAME", "Super"), "Super admin first name (SEED_ADMIN_FIRST_NAME)")
+	lastName := flag.String("last-name", getEnv("SEED_ADMIN_LAST_NAME", "Admin"), "Super admin last name (SEED_ADMIN_LAST_NAME)")
+	fixtures := flag.String("fixtures", os.Getenv("SEED_FIXTURES"), "Optional JSON or YAML fixture file (SEED_FIXTURES)")
+	flag.Parse()
+
+	dbConn, err := db.NewDB(cfg)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	seeder := seed.NewSeeder(dbConn, cfg)
+
+	if err := seeder.SeedRoles(); err != nil {
+		log.Fatal("Failed to seed roles:", err)
+	}
+
+	if err := seeder.SeedSettings(); err != nil {
+		log.Fatal("Failed to seed settings:", err)
+	}
+
+	if err := seeder.SeedMasters(); err != nil {
+		log.Fatal("Failed to seed master data:", err)
+	}
+
+	if *email != "" {
+		err := seeder.SeedSuperAdmin(seed.AdminFixture{
+			FirstName: *firstName,
+			LastName:  *lastName,
+			EmailID:   *email,
+			Password:  *password,
+		})
+		if err != nil {
+			log.Fatal("Failed to seed super admin:", err)
+		}
+	} else {
+		log.Println("No super admin email given, skipping super admin (set SEED_ADMIN_EMAIL or -email)")
+	}
+
+	if *fixtures != "" {
+		data, err := seed.LoadFixtures(*fixtures)
+		if err != nil {
+			log.Fatal("Failed to load fixtures:", err)
+		}
+		if err := seeder.SeedFixtures(data); err != nil {
+			log.Fatal("Failed to seed fixtures:", err)
+		}
+	}
+
+	log.Println("Seeding completed")
+}
+
+// getEnv retrieves an environment variable or returns a default value
+func getEnv(key, defaultValue string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return defaultValue
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -20,12 +21,40 @@ type Config struct {
 	DBName               string
 	DBSSLMode            string
 	DATABASE_URL         string
+	EmailDriver          string // smtp, log or memory
 	EmailHost            string
 	EmailPort            string
 	EmailUsername        string
 	EmailPassword        string
 	EmailFrom            string
+	FrontendURL          string        // Base URL of the admin panel, used in email links
+	PasswordResetExpiry  time.Duration // Lifetime of password reset tokens
+	EmailVerifyExpiry    time.Duration // Lifetime of email verification tokens
+	InvitationExpiry     time.Duration // Lifetime of admin invitation links
 	JWTSecret            string
+	JWTExpiry            time.Duration // Lifetime of access tokens
+	RefreshTokenExpiry   time.Duration // Lifetime of refresh tokens
+	LoginMaxAttempts     int           // Failed logins per account before it is locked
+	LoginIPMaxAttempts   int           // Failed logins per client IP before it is locked
+	LoginAttemptWindow   time.Duration // Failures older than this are forgotten
+	LoginLockoutDuration time.Duration // How long an account or IP stays locked
+	LoginDelayBase       time.Duration // Delay after the first failure, doubled for each further failure
+	SettingsCacheTTL     time.Duration // How long settings are cached; bounds staleness across instances
+	MasterCacheTTL       time.Duration // How long master lookups are cached; bounds staleness across instances
+	TranslationsCacheTTL time.Duration // How long translation overrides are cached; bounds staleness across instances
+	StorageDriver        string        // local, s3 or memory
+	StorageLocalRoot     string        // Directory used by the local driver
+	StoragePublicURL     string        // Base URL of this API, used in signed URLs of the local and memory drivers
+	StorageSigningSecret string        // Signs local file URLs; defaults to JWTSecret
+	StorageS3Endpoint    string        // e.g. s3.amazonaws.com or localhost:9000 for MinIO
+	StorageS3Region      string
+	StorageS3Bucket      string
+	StorageS3AccessKey   string
+	StorageS3SecretKey   string
+	StorageS3UseSSL      bool
+	StorageS3PathStyle   bool          // Address the bucket in the path, as MinIO expects
+	UploadMaxSize        int64         // Largest accepted upload in bytes, unless the uploads.max_size_mb setting is set
+	FileURLExpiry        time.Duration // Lifetime of signed file URLs
 	PasswordSalt         string
 	LogLevel             string
 	AllowedOrigins       string
@@ -52,12 +81,40 @@ func Load() (*Config, error) {
 		AppName:              getEnv("APP_NAME", "goUniAdmin"),
 		DBSSLMode:            getEnv("DB_SSLMODE", "disable"),
 		DATABASE_URL:         getEnv("DATABASE_URL", ""),
+		EmailDriver:          getEnv("EMAIL_DRIVER", "smtp"),
 		EmailHost:            getEnv("EMAIL_HOST", "smtp.example.com"),
 		EmailPort:            getEnv("EMAIL_PORT", "587"),
 		EmailUsername:        getEnv("EMAIL_USERNAME", "noreply@example.com"),
 		EmailPassword:        getEnv("EMAIL_PASSWORD", "emailsecret"),
 		EmailFrom:            getEnv("EMAIL_FROM", "noreply@example.com"),
+		FrontendURL:          getEnv("FRONTEND_URL", "http://localhost:3000"),
+		PasswordResetExpiry:  getEnvAsDuration("PASSWORD_RESET_EXPIRY", 1*time.Hour),
+		EmailVerifyExpiry:    getEnvAsDuration("EMAIL_VERIFY_EXPIRY", 24*time.Hour),
+		InvitationExpiry:     getEnvAsDuration("INVITATION_EXPIRY", 72*time.Hour),
 		JWTSecret:            getEnv("JWT_SECRET", "your-very-secret-key-here"),
+		JWTExpiry:            getEnvAsDuration("JWT_EXPIRY", 1*time.Hour),
+		RefreshTokenExpiry:   getEnvAsDuration("REFRESH_TOKEN_EXPIRY", 7*24*time.Hour),
+		LoginMaxAttempts:     getEnvAsInt("LOGIN_MAX_ATTEMPTS", 5),
+		LoginIPMaxAttempts:   getEnvAsInt("LOGIN_IP_MAX_ATTEMPTS", 20),
+		LoginAttemptWindow:   getEnvAsDuration("LOGIN_ATTEMPT_WINDOW", 15*time.Minute),
+		LoginLockoutDuration: getEnvAsDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+		LoginDelayBase:       getEnvAsDuration("LOGIN_DELAY_BASE", 1*time.Second),
+		SettingsCacheTTL:     getEnvAsDuration("SETTINGS_CACHE_TTL", 1*time.Minute),
+		MasterCacheTTL:       getEnvAsDuration("MASTER_CACHE_TTL", 5*time.Minute),
+		TranslationsCacheTTL: getEnvAsDuration("TRANSLATIONS_CACHE_TTL", 1*time.Minute),
+		StorageDriver:        getEnv("STORAGE_DRIVER", "local"),
+		StorageLocalRoot:     getEnv("STORAGE_LOCAL_ROOT", "./storage"),
+		StoragePublicURL:     getEnv("STORAGE_PUBLIC_URL", "http://localhost:8080"),
+		StorageSigningSecret: getEnv("STORAGE_SIGNING_SECRET", ""),
+		StorageS3Endpoint:    getEnv("STORAGE_S3_ENDPOINT", "s3.amazonaws.com"),
+		StorageS3Region:      getEnv("STORAGE_S3_REGION", "us-east-1"),
+		StorageS3Bucket:      getEnv("STORAGE_S3_BUCKET", ""),
+		StorageS3AccessKey:   getEnv("STORAGE_S3_ACCESS_KEY", ""),
+		StorageS3SecretKey:   getEnv("STORAGE_S3_SECRET_KEY", ""),
+		StorageS3UseSSL:      getEnvAsBool("STORAGE_S3_USE_SSL", true),
+		StorageS3PathStyle:   getEnvAsBool("STORAGE_S3_PATH_STYLE", false),
+		UploadMaxSize:        int64(getEnvAsInt("UPLOAD_MAX_SIZE_MB", 10)) << 20,
+		FileURLExpiry:        getEnvAsDuration("FILE_URL_EXPIRY", 15*time.Minute),
 		PasswordSalt:         getEnv("PASSWORD_SALT", "some-random-salt"),
 		LogLevel:             getEnv("LOG_LEVEL", "debug"),
 		AllowedOrigins:       getEnv("ALLOWED_ORIGINS", "http://localhost:3000"),
@@ -91,6 +148,28 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+// getEnvAsInt retrieves an environment variable as an integer or returns a default value
+func getEnvAsInt(key string, defaultValue int) int {
+	if value, exists := os.LookupEnv(key); exists {
+		i, err := strconv.Atoi(value)
+		if err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsDuration retrieves an environment variable as a duration (e.g. "15m", "168h") or returns a default value
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		d, err := time.ParseDuration(value)
+		if err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
 // GetSwaggerHost returns the Swagger host (for compatibility with previous method)
 func (c *Config) GetSwaggerHost() string {
 	return c.SwaggerHost
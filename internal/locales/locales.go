@@ -0,0 +1,10 @@
+// Package locales embeds the message catalogs: one flat JSON object of message ID to text per
+// language, named by language tag, e.g. en.json
+package locales
+
+import "embed"
+
+// FS holds the catalogs
+//
+//go:embed *.json
+var FS embed.FS
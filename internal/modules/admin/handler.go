@@ -1,9 +1,19 @@
 package admin
 
 import (
-	"fmt"
+	"errors"
+	"log/slog"
+	"math"
 	"net/http"
 	"strconv"
+	"time"
+
+	"goUniAdmin/internal/modules/audit"
+	"goUniAdmin/internal/services/apperror"
+	localization "goUniAdmin/internal/services/common"
+	"goUniAdmin/internal/services/middleware"
+	"goUniAdmin/internal/services/query"
+	"goUniAdmin/internal/services/response"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -28,45 +38,78 @@ type AdminCreateRequest struct {
 	Mobile    string `json:"mobile,omitempty"`
 	EmailID   string `json:"emailId"`
 	Password  string `json:"password"`
+	// Optional; checked against the country, currency and timezone master data
+	CountryCode string `json:"countryCode,omitempty"`
+	Currency    string `json:"currency,omitempty"`
+	TimeZone    string `json:"timeZone,omitempty"`
+}
+
+// LoginRequest defines the request body for admin login
+type LoginRequest struct {
+	EmailID  string `json:"emailId"`
+	Password string `json:"password"`
 }
 
 // CreateAdmin godoc
 // @Summary Create a new admin
-// @Description Creates a new admin with hashed password
+// @Description Creates a new admin with hashed password. The authenticated admin is recorded as AddedBy.
 // @Tags admins
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param admin body AdminCreateRequest true "Admin data"
-// @Success 201 {object} Admin
-// @Failure 400 {object} map[string]string "error: Invalid request body or validation error"
-// @Failure 500 {object} map[string]string "error: Failed to hash password"
+// @Success 201 {object} response.Envelope{data=Admin}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body or validation error"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 500 {object} response.ErrorEnvelope "Failed to hash password"
 // @Router /admins [post]
 func (h *AdminHandler) CreateAdmin(c *gin.Context) {
-	var admin Admin
-	if err := c.ShouldBindJSON(&admin); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid request body"})
+	// Bind the request type rather than Admin, whose Password field is excluded from JSON
+	var req AdminCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+	admin := Admin{
+		FirstName:   req.FirstName,
+		LastName:    req.LastName,
+		UserName:    req.UserName,
+		Mobile:      req.Mobile,
+		EmailID:     req.EmailID,
+		Password:    req.Password,
+		CountryCode: req.CountryCode,
+		Currency:    req.Currency,
+		TimeZone:    req.TimeZone,
+	}
+	if addedBy, err := uuid.Parse(c.GetString("adminID")); err == nil {
+		admin.AddedBy = addedBy
+	}
+	// Check every field here, since the service only sees the hashed password
+	if err := ValidateAdmin(admin); err != nil {
+		c.Error(apperror.Validation(err))
 		return
 	}
 
 	// Hash the password using bcrypt before creating the admin
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(admin.Password), bcrypt.DefaultCost)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": "Failed to hash password"})
+		c.Error(apperror.Internal(err))
 		return
 	}
 	// Replace the plain password with the hashed version
 	admin.Password = string(hashedPassword)
 
 	// Create the admin with the hashed password
-	created, err := h.service.Create(admin)
+	created, err := h.service.WithContext(audit.Context(c)).Create(admin, localization.Language(c))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// Optionally, remove the password from the response for security
 	created.Password = ""
-	c.JSON(http.StatusCreated, created)
+	response.Success(c, http.StatusCreated, "admin_created", created)
 }
 
 // GetAdmin godoc
@@ -76,106 +119,134 @@ func (h *AdminHandler) CreateAdmin(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Admin ID"
 // @Param Authorization header string true "Bearer token"
-// @Success 200 {object} Admin
-// @Failure 400 {object} map[string]string "error: Invalid ID"
-// @Failure 401 {object} map[string]string "error: Unauthorized"
-// @Failure 404 {object} map[string]string "error: Admin not found"
+// @Success 200 {object} response.Envelope{data=Admin}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Admin not found"
 // @Router /admins/{id} [get]
 func (h *AdminHandler) GetAdmin(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid ID"})
+		c.Error(apperror.InvalidRequest("invalid ID"))
 		return
 	}
 
 	admin, err := h.service.Read(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"success": false, "error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// Remove password from response
 	admin.Password = ""
-	c.JSON(http.StatusOK, gin.H{"success": true, "data": admin, "message": "Get details successfully."})
+	withPhotoURLs(&admin)
+	response.Success(c, http.StatusOK, "details_fetched", admin)
 }
 
 // UpdateAdmin godoc
 // @Summary Update an admin
-// @Description Updates an existing admin by ID
+// @Description Updates the fields set in the body. Changing the email requires it to be verified again; deactivating revokes the admin's sessions. The caller must hold every permission of the admin.
 // @Tags admins
 // @Accept json
 // @Produce json
 // @Param id path string true "Admin ID"
-// @Param admin body Admin true "Updated admin data"
+// @Param admin body AdminUpdate true "Fields to change"
 // @Param Authorization header string true "Bearer token"
-// @Success 200 {object} Admin
-// @Failure 400 {object} map[string]string "error: Invalid ID or request body"
-// @Failure 401 {object} map[string]string "error: Unauthorized"
-// @Failure 404 {object} map[string]string "error: Admin not found"
+// @Success 200 {object} response.Envelope{data=Admin}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID or request body"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden, or the admin holds permissions the caller lacks"
+// @Failure 404 {object} response.ErrorEnvelope "Admin not found"
+// @Failure 409 {object} response.ErrorEnvelope "Email already exists"
 // @Router /admins/{id} [put]
 func (h *AdminHandler) UpdateAdmin(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid ID"})
+		c.Error(apperror.InvalidRequest("invalid ID"))
 		return
 	}
 
-	var admin Admin
-	if err := c.ShouldBindJSON(&admin); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid request body"})
+	var update AdminUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
 		return
 	}
 
-	updated, err := h.service.Update(id, admin)
+	service, err := h.callerService(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+		c.Error(err)
+		return
+	}
+	updated, err := service.Update(id, update, localization.Language(c))
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
 	// Remove password from response
 	updated.Password = ""
-	c.JSON(http.StatusOK, gin.H{"success": false, "message": "Updated successfully", "data": updated})
+	response.Success(c, http.StatusOK, "updated_successfully", updated)
 }
 
 // DeleteAdmin godoc
 // @Summary Delete an admin
-// @Description Soft deletes an admin by ID
+// @Description Soft deletes an admin by ID. The caller must hold every permission of the admin.
 // @Tags admins
 // @Produce json
 // @Param id path string true "Admin ID"
 // @Param Authorization header string true "Bearer token"
 // @Success 204
-// @Failure 400 {object} map[string]string "error: Invalid ID"
-// @Failure 401 {object} map[string]string "error: Unauthorized"
-// @Failure 404 {object} map[string]string "error: Admin not found"
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden, or the admin holds permissions the caller lacks"
+// @Failure 404 {object} response.ErrorEnvelope "Admin not found"
 // @Router /admins/{id} [delete]
 func (h *AdminHandler) DeleteAdmin(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid ID"})
+		c.Error(apperror.InvalidRequest("invalid ID"))
 		return
 	}
 
-	if err := h.service.Delete(id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"success": false, "error": err.Error()})
+	service, err := h.callerService(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := service.Delete(id); err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusNoContent, gin.H{"success": true, "message": "Admin deleted successfully."})
+	response.Success(c, http.StatusNoContent, "admin_deleted", nil)
 }
 
 // ListAdmins godoc
 // @Summary List all admins
-// @Description Retrieves a list of all non-deleted admins
+// @Description Retrieves a paginated list of non-deleted admins with optional search, filters and sorting. Pages are selected by page number by default; pass cursor (empty for the first page) to page by an opaque keyset cursor instead, which stays fast on large tables and returns next_cursor and prev_cursor. Keyset pages can only be sorted by createdAt.
 // @Tags admins
 // @Produce json
+// @Security BearerAuth
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Page size" default(10)
-// @Success 200 {array} Admin
-// @Failure 500 {object} map[string]string "error: Internal server error"
+// @Param search query string false "Matches first name, last name, email, username or mobile; every word must match"
+// @Param status query bool false "Filter by status"
+// @Param gender query string false "Filter by gender, comma-separated for several"
+// @Param countryCode query string false "Filter by country code, comma-separated for several"
+// @Param createdFrom query string false "Created at or after this date (YYYY-MM-DD) or RFC 3339 timestamp"
+// @Param createdTo query string false "Created at or before this date (YYYY-MM-DD) or RFC 3339 timestamp"
+// @Param sort query string false "Comma-separated sort keys, prefix with - for descending: firstName, lastName, emailId, status, createdAt, updatedAt" default(-createdAt)
+// @Param cursor query string false "Keyset cursor from next_cursor or prev_cursor; empty for the first page"
+// @Param include_total query bool false "Include total_count; defaults to true for page numbers and false for cursors"
+// @Success 200 {object} response.Envelope{data=response.Page{list=[]Admin}}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid filter or sort parameter"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 500 {object} response.ErrorEnvelope "Internal server error"
 // @Router /admins [get]
 func (h *AdminHandler) ListAdmins(c *gin.Context) {
 	// Parse query parameters for pagination
@@ -189,77 +260,370 @@ func (h *AdminHandler) ListAdmins(c *gin.Context) {
 		page_size = 10 // Ensure page_size is at least 1 if invalid or non-positive
 	}
 
+	params, err := query.Parse(c.Request.URL.Query(), AdminListSpec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	cursorPage, useCursor, err := query.ParseCursorPage(c.Request.URL.Query(), params, page_size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Counting defeats the point of keyset pages, so it is opt-in there
+	withTotal, err := query.IncludeTotal(c.Request.URL.Query(), !useCursor)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if useCursor {
+		admins, cursors, count, err := h.service.ListByCursor(params, cursorPage, withTotal)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		for i := range admins {
+			admins[i].Password = ""
+			withPhotoURLs(&admins[i])
+		}
+		data := response.Page{List: admins, PageSize: page_size, NextCursor: cursors.Next, PrevCursor: cursors.Prev}
+		if withTotal {
+			data.TotalCount = &count
+		}
+		response.Success(c, http.StatusOK, "details_fetched", data)
+		return
+	}
+
 	// Calculate the offset and limit for pagination
 	offset := (page - 1) * page_size
 	limit := page_size
 
-	admins, count, err := h.service.List(limit, offset)
+	admins, count, err := h.service.List(params, limit, offset, withTotal)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// Remove passwords from all admins in the list
 	for i := range admins {
 		admins[i].Password = ""
+		withPhotoURLs(&admins[i])
 	}
-	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Get details successfully.", "data": gin.H{"list": admins, "page": page, "page_size": page_size, "total_count": count}})
+	data := response.Page{List: admins, Page: page, PageSize: page_size}
+	if withTotal {
+		data.TotalCount = &count
+	}
+	response.Success(c, http.StatusOK, "details_fetched", data)
 }
 
 // AdminLogin godoc
 // @Summary Admin login
-// @Description Authenticates an admin and returns a JWT token
+// @Description Authenticates an admin and returns a JWT access token with a refresh token. When two-factor authentication is enabled, a challenge token is returned instead and must be exchanged at /admins/2fa/verify. Repeated failures are delayed and eventually lock the account and client IP.
 // @Tags admins
 // @Accept json
 // @Produce json
-// @Param login body object{emailId=string,password=string} true "Login credentials (only emailId and password)"
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]string "error: Invalid request body"
-// @Failure 401 {object} map[string]string "error: Invalid email or password"
-// @Failure 500 {object} map[string]string "error: Failed to generate token"
+// @Param login body LoginRequest true "Login credentials"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body"
+// @Failure 401 {object} response.ErrorEnvelope "Invalid email or password"
+// @Failure 403 {object} response.ErrorEnvelope "Account is inactive, or code EMAIL_NOT_VERIFIED"
+// @Failure 429 {object} response.ErrorEnvelope "Too many login attempts; see the Retry-After header"
+// @Failure 500 {object} response.ErrorEnvelope "Failed to generate token"
 // @Router /admins/login [post]
 func (h *AdminHandler) AdminLogin(c *gin.Context) {
-	var admin Admin
+	var admin LoginRequest
 	if err := c.ShouldBindJSON(&admin); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "Invalid request body"})
+		c.Error(apperror.InvalidRequest("invalid request body"))
 		return
 	}
 
-	dbAdmin, err := h.service.ReadByEmail(admin.EmailID)
+	// Throttling is keyed by the submitted email, so unknown emails behave like real ones. The
+	// attempt is counted before the password is compared and released if it succeeds.
+	retryAfter, err := h.service.ReserveLoginAttempt(admin.EmailID, c.ClientIP())
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "Invalid email or password"})
+		c.Error(err)
+		return
+	}
+	if retryAfter > 0 {
+		abortRetryAfter(c, ErrTooManyLoginAttempts, retryAfter)
 		return
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(dbAdmin.Password), []byte(admin.Password))
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "Invalid email or password"})
+	dbAdmin, err := h.service.ReadByEmail(admin.EmailID)
+	if err != nil && !errors.Is(err, ErrAdminNotFound) {
+		c.Error(err)
+		return
+	}
+
+	var found *Admin
+	if err == nil {
+		found = &dbAdmin
+	}
+	if !CheckPassword(found, admin.Password) {
+		c.Error(ErrInvalidCredentials)
+		return
+	}
+	if err := h.service.LoginSucceeded(admin.EmailID, c.ClientIP()); err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to clear login failures", "error", err)
+	}
+
+	if !dbAdmin.Status {
+		c.Error(ErrAccountInactive)
+		return
+	}
+
+	if !dbAdmin.EmailVerificationStatus {
+		c.Error(ErrEmailNotVerified)
 		return
 	}
 
 	// Remove password from response
 	dbAdmin.Password = ""
-	// Generate JWT token
-	adminId := dbAdmin.ID
-	fmt.Println("Generating JWT token ", adminId)
 
-	token, err := h.service.GenerateToken(adminId)
+	// With 2FA enabled the password only earns a challenge, exchanged at /2fa/verify
+	if dbAdmin.TwoFactorEnabled {
+		challenge, err := h.service.GenerateChallengeToken(dbAdmin.ID)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		response.Success(c, http.StatusOK, "two_factor_required", gin.H{
+			"twoFactorRequired": true,
+			"challengeToken":    challenge.ChallengeToken,
+			"expiresIn":         challenge.ExpiresIn,
+		})
+		return
+	}
+
+	// Generate JWT token
+	tokens, err := h.service.IssueTokenPair(dbAdmin.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": "Failed to generate token"})
+		c.Error(err)
 		return
 	}
-	fmt.Println("line 160")
+	slog.InfoContext(c.Request.Context(), "Admin logged in", "admin_id", dbAdmin.ID)
 
-	// Return the admin data along with the generated token
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Logged in successfully",
-		"data": gin.H{
-			"admin": dbAdmin,
-			"token": token,
-		},
+	// Return the admin data along with the generated tokens
+	response.Success(c, http.StatusOK, "logged_in_successfully", gin.H{
+		"admin":        dbAdmin,
+		"token":        tokens.AccessToken,
+		"refreshToken": tokens.RefreshToken,
+		"expiresIn":    tokens.ExpiresIn,
 	})
+}
 
+// RefreshTokenRequest defines the request body for rotating a refresh token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// RefreshToken godoc
+// @Summary Refresh access token
+// @Description Exchanges a refresh token for a new access/refresh token pair. The presented refresh token is revoked; reusing it revokes every token issued from the same login.
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Param body body RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body"
+// @Failure 401 {object} response.ErrorEnvelope "Invalid or expired refresh token"
+// @Failure 500 {object} response.ErrorEnvelope "Failed to refresh token"
+// @Router /admins/token/refresh [post]
+func (h *AdminHandler) RefreshToken(c *gin.Context) {
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.RefreshToken == "" {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	tokens, err := h.service.RefreshTokens(req.RefreshToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "token_refreshed", tokens)
+}
+
+// ForgotPasswordRequest defines the request body for requesting a password reset
+type ForgotPasswordRequest struct {
+	EmailID string `json:"emailId"`
+}
+
+// ResetPasswordRequest defines the request body for setting a new password with a reset token
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Emails a single-use, time-limited password reset link. The response is the same whether or not the email exists.
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Param body body ForgotPasswordRequest true "Admin email"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body"
+// @Failure 500 {object} response.ErrorEnvelope "Internal server error"
+// @Router /admins/forgot-password [post]
+func (h *AdminHandler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.EmailID == "" {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	retryAfter, err := h.service.ReserveEmailRequest("reset", req.EmailID, c.ClientIP())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Over the limit nothing is sent, but the answer stays the same so it does not reveal the account
+	if retryAfter == 0 {
+		if err := h.service.WithContext(audit.Context(c)).RequestPasswordReset(req.EmailID, localization.Language(c)); err != nil {
+			c.Error(err)
+			return
+		}
+	}
+
+	response.Success(c, http.StatusOK, "password_reset_link_sent", nil)
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Sets a new password using a token from the password reset email. All existing sessions are revoked.
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Param body body ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body, token or password"
+// @Failure 500 {object} response.ErrorEnvelope "Failed to reset password"
+// @Router /admins/reset-password [post]
+func (h *AdminHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Token == "" {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	if err := h.service.WithContext(audit.Context(c)).ResetPassword(req.Token, req.Password); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "password_reset", nil)
+}
+
+// ResendVerificationRequest defines the request body for resending the verification email
+type ResendVerificationRequest struct {
+	EmailID string `json:"emailId"`
+}
+
+// VerifyEmail godoc
+// @Summary Verify email address
+// @Description Confirms an admin's email address using the token from the verification email
+// @Tags admins
+// @Produce json
+// @Param token query string true "Verification token"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid or expired verification token"
+// @Failure 500 {object} response.ErrorEnvelope "Failed to verify email"
+// @Router /admins/verify-email [get]
+func (h *AdminHandler) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.Error(ErrInvalidVerificationToken)
+		return
+	}
+
+	if err := h.service.WithContext(audit.Context(c)).VerifyEmail(token); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "email_verified", nil)
+}
+
+// ResendVerification godoc
+// @Summary Resend verification email
+// @Description Sends a new email verification link. The response is the same whether or not the email exists.
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Param body body ResendVerificationRequest true "Admin email"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body"
+// @Failure 500 {object} response.ErrorEnvelope "Internal server error"
+// @Router /admins/verify-email/resend [post]
+func (h *AdminHandler) ResendVerification(c *gin.Context) {
+	var req ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.EmailID == "" {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	retryAfter, err := h.service.ReserveEmailRequest("verify", req.EmailID, c.ClientIP())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Over the limit nothing is sent, but the answer stays the same so it does not reveal the account
+	if retryAfter == 0 {
+		if err := h.service.WithContext(audit.Context(c)).ResendVerification(req.EmailID, localization.Language(c)); err != nil {
+			c.Error(err)
+			return
+		}
+	}
+
+	response.Success(c, http.StatusOK, "verification_link_sent", nil)
+}
+
+// LogoutRequest defines the optional request body for logging out
+type LogoutRequest struct {
+	RefreshToken string `json:"refreshToken,omitempty"`
+}
+
+// Logout godoc
+// @Summary Admin logout
+// @Description Revokes the current access token and, when provided, the refresh token issued with it
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body LogoutRequest false "Refresh token to revoke"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 500 {object} response.ErrorEnvelope "Failed to logout"
+// @Router /admins/logout [post]
+func (h *AdminHandler) Logout(c *gin.Context) {
+	var req LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Error(apperror.InvalidRequest("invalid request body"))
+			return
+		}
+	}
+
+	adminID, err := uuid.Parse(c.GetString("adminID"))
+	if err != nil {
+		c.Error(apperror.Unauthorized("invalid admin ID in token"))
+		return
+	}
+
+	if err := h.service.WithContext(audit.Context(c)).Logout(adminID, c.GetString("tokenID"), c.GetTime("tokenExpiresAt"), req.RefreshToken); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "logged_out", nil)
 }
 
 // GetProfile godoc
@@ -268,29 +632,516 @@ func (h *AdminHandler) AdminLogin(c *gin.Context) {
 // @Tags admins
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} Admin
-// @Failure 401 {object} map[string]string "error: Unauthorized"
-// @Failure 404 {object} map[string]string "error: Admin not found"
+// @Success 200 {object} response.Envelope{data=Admin}
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 404 {object} response.ErrorEnvelope "Admin not found"
 // @Router /admins/profile [get]
 func (h *AdminHandler) GetProfile(c *gin.Context) {
 	adminIDStr, exists := c.Get("adminID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "Admin ID not found in context"})
+		c.Error(middleware.ErrAuthRequired)
 		return
 	}
 
 	adminID, err := uuid.Parse(adminIDStr.(string))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": "Invalid admin ID in token"})
+		c.Error(apperror.Unauthorized("invalid admin ID in token"))
 		return
 	}
 
 	admin, err := h.service.Read(adminID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"success": false, "error": err.Error()})
+		c.Error(err)
+		return
+	}
+
+	admin.Password = ""
+	withPhotoURLs(&admin)
+	response.Success(c, http.StatusOK, "details_fetched", admin)
+}
+
+// ChangePasswordRequest defines the request body for changing the authenticated admin's password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+// UpdateProfile godoc
+// @Summary Update admin profile
+// @Description Updates personal fields of the authenticated admin: name, mobile, photo, social IDs, time zone, date format, theme and table column settings. Omitted fields are left unchanged.
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param profile body ProfileUpdate true "Profile fields to change"
+// @Success 200 {object} response.Envelope{data=Admin}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body or validation error"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Router /admins/profile [put]
+func (h *AdminHandler) UpdateProfile(c *gin.Context) {
+	adminID, err := uuid.Parse(c.GetString("adminID"))
+	if err != nil {
+		c.Error(apperror.Unauthorized("invalid admin ID in token"))
+		return
+	}
+
+	var req ProfileUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	admin, err := h.service.WithContext(audit.Context(c)).UpdateProfile(adminID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	withPhotoURLs(&admin)
+	response.Success(c, http.StatusOK, "profile_updated", admin)
+}
+
+// ChangePassword godoc
+// @Summary Change password
+// @Description Changes the password of the authenticated admin after checking the current one. All sessions, including this one, are signed out.
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body, current password or new password"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Router /admins/profile/change-password [post]
+func (h *AdminHandler) ChangePassword(c *gin.Context) {
+	adminID, err := uuid.Parse(c.GetString("adminID"))
+	if err != nil {
+		c.Error(apperror.Unauthorized("invalid admin ID in token"))
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	if err := h.service.WithContext(audit.Context(c)).ChangePassword(adminID, req.CurrentPassword, req.NewPassword); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "password_changed", nil)
+}
+
+// abortRetryAfter reports a throttled request with the seconds to wait in the Retry-After
+// header and the error details
+func abortRetryAfter(c *gin.Context, err *apperror.Error, wait time.Duration) {
+	seconds := int64(math.Ceil(wait.Seconds()))
+	c.Header("Retry-After", strconv.FormatInt(seconds, 10))
+	c.Error(err.WithDetails(gin.H{"retryAfter": seconds}))
+}
+
+// TwoFactorCodeRequest defines a request carrying a TOTP code
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// TwoFactorDisableRequest defines the request body for disabling two-factor authentication
+type TwoFactorDisableRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"` // TOTP code or recovery code
+}
+
+// TwoFactorVerifyRequest defines the request body for completing a two-factor login
+type TwoFactorVerifyRequest struct {
+	ChallengeToken string `json:"challengeToken"`
+	Code           string `json:"code"` // TOTP code or recovery code
+}
+
+// SetupTwoFactor godoc
+// @Summary Set up two-factor authentication
+// @Description Generates a TOTP secret and otpauth URI for an authenticator app. 2FA is enabled once confirmed.
+// @Tags admins
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Envelope{data=TwoFactorSetup}
+// @Failure 400 {object} response.ErrorEnvelope "Two-factor authentication is already enabled"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Router /admins/2fa/setup [post]
+func (h *AdminHandler) SetupTwoFactor(c *gin.Context) {
+	adminID, err := uuid.Parse(c.GetString("adminID"))
+	if err != nil {
+		c.Error(apperror.Unauthorized("invalid admin ID in token"))
+		return
+	}
+
+	setup, err := h.service.WithContext(audit.Context(c)).SetupTwoFactor(adminID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "two_factor_setup_started", setup)
+}
+
+// ConfirmTwoFactor godoc
+// @Summary Confirm two-factor authentication
+// @Description Enables 2FA after checking a code from the authenticator app and returns one-time recovery codes
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body TwoFactorCodeRequest true "TOTP code"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body or code"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 429 {object} response.ErrorEnvelope "Too many attempts; see the Retry-After header"
+// @Router /admins/2fa/confirm [post]
+func (h *AdminHandler) ConfirmTwoFactor(c *gin.Context) {
+	adminID, err := uuid.Parse(c.GetString("adminID"))
+	if err != nil {
+		c.Error(apperror.Unauthorized("invalid admin ID in token"))
+		return
+	}
+
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	codes, retryAfter, err := h.service.WithContext(audit.Context(c)).ConfirmTwoFactor(adminID, req.Code)
+	if retryAfter > 0 {
+		abortRetryAfter(c, ErrTooManyLoginAttempts, retryAfter)
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "two_factor_enabled", gin.H{"recoveryCodes": codes})
+}
+
+// DisableTwoFactor godoc
+// @Summary Disable two-factor authentication
+// @Description Disables 2FA after checking the password and a TOTP or recovery code
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body TwoFactorDisableRequest true "Password and code"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body, password or code"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 429 {object} response.ErrorEnvelope "Too many attempts; see the Retry-After header"
+// @Router /admins/2fa/disable [post]
+func (h *AdminHandler) DisableTwoFactor(c *gin.Context) {
+	adminID, err := uuid.Parse(c.GetString("adminID"))
+	if err != nil {
+		c.Error(apperror.Unauthorized("invalid admin ID in token"))
+		return
+	}
+
+	var req TwoFactorDisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	retryAfter, err := h.service.WithContext(audit.Context(c)).DisableTwoFactor(adminID, req.Password, req.Code)
+	if retryAfter > 0 {
+		abortRetryAfter(c, ErrTooManyLoginAttempts, retryAfter)
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "two_factor_disabled", nil)
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerate recovery codes
+// @Description Replaces all recovery codes after checking a TOTP code
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body TwoFactorCodeRequest true "TOTP code"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body or code"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 429 {object} response.ErrorEnvelope "Too many attempts; see the Retry-After header"
+// @Router /admins/2fa/recovery-codes [post]
+func (h *AdminHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	adminID, err := uuid.Parse(c.GetString("adminID"))
+	if err != nil {
+		c.Error(apperror.Unauthorized("invalid admin ID in token"))
+		return
+	}
+
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
 		return
 	}
 
+	codes, retryAfter, err := h.service.WithContext(audit.Context(c)).RegenerateRecoveryCodes(adminID, req.Code)
+	if retryAfter > 0 {
+		abortRetryAfter(c, ErrTooManyLoginAttempts, retryAfter)
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "recovery_codes_regenerated", gin.H{"recoveryCodes": codes})
+}
+
+// VerifyTwoFactor godoc
+// @Summary Complete two-factor login
+// @Description Exchanges the challenge token from login and a TOTP or recovery code for an access/refresh token pair
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Param body body TwoFactorVerifyRequest true "Challenge token and code"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body"
+// @Failure 401 {object} response.ErrorEnvelope "Invalid challenge token or code"
+// @Failure 429 {object} response.ErrorEnvelope "Too many attempts; see the Retry-After header"
+// @Failure 500 {object} response.ErrorEnvelope "Failed to generate token"
+// @Router /admins/2fa/verify [post]
+func (h *AdminHandler) VerifyTwoFactor(c *gin.Context) {
+	var req TwoFactorVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.ChallengeToken == "" {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	// Codes share the client IP limit with password logins; the admin's own limit is applied by the service
+	retryAfter, err := h.service.ReserveIPAttempt(c.ClientIP())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if retryAfter > 0 {
+		abortRetryAfter(c, ErrTooManyLoginAttempts, retryAfter)
+		return
+	}
+
+	admin, tokens, retryAfter, err := h.service.WithContext(audit.Context(c)).VerifyTwoFactorLogin(req.ChallengeToken, req.Code)
+	if retryAfter > 0 {
+		abortRetryAfter(c, ErrTooManyLoginAttempts, retryAfter)
+		return
+	}
+	if err != nil {
+		// 2FA turned off since the challenge was issued invalidates the challenge
+		if errors.Is(err, ErrTwoFactorNotEnabled) {
+			err = ErrInvalidChallengeToken
+		}
+		c.Error(err)
+		return
+	}
+	if err := h.service.ReleaseIPAttempt(c.ClientIP()); err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to release login attempt", "error", err)
+	}
+
 	admin.Password = ""
-	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Get details Successfully.", "data": admin})
+	response.Success(c, http.StatusOK, "logged_in_successfully", gin.H{
+		"admin":        admin,
+		"token":        tokens.AccessToken,
+		"refreshToken": tokens.RefreshToken,
+		"expiresIn":    tokens.ExpiresIn,
+	})
+}
+
+// UnlockAdmin godoc
+// @Summary Unlock an admin account
+// @Description Clears the failed login count and any lockout on an admin account. The caller must hold every permission of the admin.
+// @Tags admins
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Admin ID"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden, or the admin holds permissions the caller lacks"
+// @Failure 404 {object} response.ErrorEnvelope "Admin not found"
+// @Router /admins/{id}/unlock [post]
+func (h *AdminHandler) UnlockAdmin(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	service, err := h.callerService(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := service.UnlockAdmin(id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "admin_unlocked", nil)
+}
+
+// InviteAdminRequest defines the request body for inviting an admin
+type InviteAdminRequest struct {
+	EmailID   string      `json:"emailId"`
+	FirstName string      `json:"firstName"`
+	LastName  string      `json:"lastName"`
+	RoleIDs   []uuid.UUID `json:"roleIds,omitempty"` // Granted on acceptance; requires roles:assign
+}
+
+// AcceptInvitationRequest defines the request body for accepting an invitation
+type AcceptInvitationRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// InviteAdmin godoc
+// @Summary Invite an admin
+// @Description Emails a signed, expiring invitation link. The invitee sets their own password when accepting. Inviting an email with a pending invitation replaces it. Optional roleIds are granted on acceptance and require roles:assign plus every permission of the roles.
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body InviteAdminRequest true "Invitee"
+// @Success 201 {object} response.Envelope{data=AdminInvitation}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body or validation error"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden, or roles the inviter may not assign"
+// @Failure 404 {object} response.ErrorEnvelope "Role not found"
+// @Failure 409 {object} response.ErrorEnvelope "Email already exists"
+// @Failure 500 {object} response.ErrorEnvelope "Failed to send invitation email"
+// @Router /admins/invitations [post]
+func (h *AdminHandler) InviteAdmin(c *gin.Context) {
+	inviterID, err := uuid.Parse(c.GetString("adminID"))
+	if err != nil {
+		c.Error(apperror.Unauthorized("invalid admin ID in token"))
+		return
+	}
+
+	var req InviteAdminRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	invitation, err := h.service.WithContext(audit.Context(c)).InviteAdmin(inviterID, req.EmailID, req.FirstName, req.LastName, req.RoleIDs, localization.Language(c))
+	if err != nil {
+		if invitation.ID != uuid.Nil {
+			err = ErrInvitationEmailFailed.Wrap(err)
+		}
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "invitation_sent", invitation)
+}
+
+// ListInvitations godoc
+// @Summary List pending invitations
+// @Description Retrieves a paginated list of pending, unexpired admin invitations
+// @Tags admins
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} response.Envelope{data=response.Page{list=[]AdminInvitation}}
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 500 {object} response.ErrorEnvelope "Internal server error"
+// @Router /admins/invitations [get]
+func (h *AdminHandler) ListInvitations(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	page_size, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || page_size < 1 {
+		page_size = 10
+	}
+
+	invitations, count, err := h.service.ListInvitations(page_size, (page-1)*page_size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", response.NewPage(invitations, page, page_size, count))
+}
+
+// RevokeInvitation godoc
+// @Summary Revoke an invitation
+// @Description Cancels a pending invitation so its link can no longer be used
+// @Tags admins
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Invitation not found"
+// @Router /admins/invitations/{id} [delete]
+func (h *AdminHandler) RevokeInvitation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	if err := h.service.WithContext(audit.Context(c)).RevokeInvitation(id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "invitation_revoked", nil)
+}
+
+// AcceptInvitation godoc
+// @Summary Accept an invitation
+// @Description Creates the invited admin with the chosen password using the token from the invitation link. The email address is treated as verified and the roles named in the invitation are granted.
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Param body body AcceptInvitationRequest true "Invitation token and new password"
+// @Success 201 {object} response.Envelope{data=Admin}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body, token or password"
+// @Failure 409 {object} response.ErrorEnvelope "Email already exists"
+// @Router /admins/invitations/accept [post]
+func (h *AdminHandler) AcceptInvitation(c *gin.Context) {
+	var req AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Token == "" {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	admin, err := h.service.WithContext(audit.Context(c)).AcceptInvitation(req.Token, req.Password)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	admin.Password = ""
+	response.Success(c, http.StatusCreated, "invitation_accepted", admin)
+}
+
+// callerService returns the service acting on behalf of the authenticated admin, with writes
+// attributed to the request in the audit log
+func (h *AdminHandler) callerService(c *gin.Context) (*AdminService, error) {
+	callerID, err := uuid.Parse(c.GetString("adminID"))
+	if err != nil {
+		return nil, apperror.Unauthorized("invalid admin ID in token")
+	}
+	return h.service.WithContext(audit.Context(c)).WithCaller(callerID), nil
 }
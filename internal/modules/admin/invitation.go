@@ -0,0 +1,238 @@
+package admin
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"goUniAdmin/internal/modules/roles"
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/middleware"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidInvitation is returned when an invitation token is invalid, expired, revoked or already used
+	ErrInvalidInvitation = apperror.New(http.StatusBadRequest, "INVALID_INVITATION", "invalid or expired invitation")
+	// ErrInvitationNotFound is returned when revoking an unknown or no longer pending invitation
+	ErrInvitationNotFound = apperror.New(http.StatusNotFound, "INVITATION_NOT_FOUND", "invitation not found")
+	// ErrEmailAlreadyExists is returned when inviting or accepting for an email that already has an admin
+	ErrEmailAlreadyExists = apperror.New(http.StatusConflict, "EMAIL_TAKEN", "email already exists")
+	// ErrInvitationEmailFailed is returned when an invitation was stored but its email was not sent
+	ErrInvitationEmailFailed = apperror.New(http.StatusInternalServerError, "INVITATION_EMAIL_FAILED", "invitation was created but the email could not be sent")
+)
+
+// InviteAdmin records an invitation and emails a signed, expiring link to the invitee in the
+// given locale. A pending invitation for the same email is replaced, so inviting again resends.
+// The roles are granted on acceptance; promising them requires the inviter to be allowed to
+// assign them now.
+func (s *AdminService) InviteAdmin(inviterID uuid.UUID, emailID, firstName, lastName string, roleIDs []uuid.UUID, locale string) (AdminInvitation, error) {
+	emailID = strings.TrimSpace(emailID)
+	if err := ValidateInvitation(emailID, firstName, lastName); err != nil {
+		return AdminInvitation{}, apperror.Validation(err)
+	}
+	if len(roleIDs) > 0 {
+		roleService := roles.NewRoleService(s.db, s.cfg).WithCaller(inviterID)
+		allowed, err := roleService.HasPermission(inviterID, "roles:assign")
+		if err != nil {
+			return AdminInvitation{}, err
+		}
+		if !allowed {
+			return AdminInvitation{}, middleware.ErrPermissionDenied
+		}
+		if err := roleService.CheckAssignable(roleIDs); err != nil {
+			return AdminInvitation{}, err
+		}
+	}
+
+	inviter, err := s.Read(inviterID)
+	if err != nil {
+		return AdminInvitation{}, err
+	}
+	if err := checkEmailAvailable(s.db.DB, emailID, uuid.Nil); err != nil {
+		return AdminInvitation{}, err
+	}
+
+	invitation := AdminInvitation{
+		EmailID:   emailID,
+		FirstName: firstName,
+		LastName:  lastName,
+		InvitedBy: inviterID,
+		RoleIDs:   roleIDs,
+		ExpiresAt: time.Now().Add(s.cfg.InvitationExpiry),
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&AdminInvitation{}).
+			Where("email_id = ? AND accepted_at IS NULL AND revoked_at IS NULL", emailID).
+			Update("revoked_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Create(&invitation).Error
+	})
+	if err != nil {
+		return AdminInvitation{}, err
+	}
+
+	token, err := s.generateInvitationToken(invitation)
+	if err != nil {
+		return AdminInvitation{}, err
+	}
+	link := fmt.Sprintf("%s/accept-invitation?token=%s", strings.TrimRight(s.cfg.FrontendURL, "/"), url.QueryEscape(token))
+	err = s.mailer.SendTemplate(invitation.EmailID, "admin_invitation", locale, map[string]interface{}{
+		"FirstName":   invitation.FirstName,
+		"InviterName": strings.TrimSpace(inviter.FirstName + " " + inviter.LastName),
+		"Link":        link,
+		"ExpiresIn":   s.cfg.InvitationExpiry.String(),
+	})
+	if err != nil {
+		// The invitation stays pending; inviting the same email again resends it
+		slog.ErrorContext(s.db.Statement.Context, "Failed to send invitation email", "invitation_id", invitation.ID, "error", err)
+		return invitation, err
+	}
+	return invitation, nil
+}
+
+// ListInvitations returns the pending, unexpired invitations, newest first
+func (s *AdminService) ListInvitations(limit, offset int) ([]AdminInvitation, int64, error) {
+	query := s.db.Model(&AdminInvitation{}).
+		Where("accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", time.Now())
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var invitations []AdminInvitation
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&invitations).Error; err != nil {
+		return nil, 0, err
+	}
+	return invitations, totalCount, nil
+}
+
+// RevokeInvitation cancels a pending invitation so its link can no longer be used
+func (s *AdminService) RevokeInvitation(id uuid.UUID) error {
+	result := s.db.Model(&AdminInvitation{}).
+		Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// AcceptInvitation creates the invited admin with the chosen password and grants the roles of
+// the invitation that still exist. The email address is considered verified because the invitee
+// received the link, and AddedBy is the inviter.
+func (s *AdminService) AcceptInvitation(token, password string) (Admin, error) {
+	if err := ValidatePassword(password); err != nil {
+		return Admin{}, apperror.Validation(err)
+	}
+
+	invitationID, err := s.parseInvitationToken(token)
+	if err != nil {
+		return Admin{}, ErrInvalidInvitation
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return Admin{}, fmt.Errorf("failed to hash password: %v", err)
+	}
+
+	var admin Admin
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// Conditional update so a link can only be used once, even by concurrent requests
+		result := tx.Model(&AdminInvitation{}).
+			Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", invitationID, time.Now()).
+			Update("accepted_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvalidInvitation
+		}
+
+		var invitation AdminInvitation
+		if err := tx.Where("id = ?", invitationID).First(&invitation).Error; err != nil {
+			return err
+		}
+
+		if err := checkEmailAvailable(tx, invitation.EmailID, uuid.Nil); err != nil {
+			return err
+		}
+
+		admin = Admin{
+			FirstName:               invitation.FirstName,
+			LastName:                invitation.LastName,
+			EmailID:                 invitation.EmailID,
+			Password:                string(hashedPassword),
+			EmailVerificationStatus: true,
+			AddedBy:                 invitation.InvitedBy,
+			Status:                  true,
+		}
+		if err := tx.Create(&admin).Error; err != nil {
+			return err
+		}
+
+		if len(invitation.RoleIDs) == 0 {
+			return nil
+		}
+		var roleIDs []uuid.UUID
+		if err := tx.Model(&roles.Role{}).Where("id IN ?", invitation.RoleIDs).Pluck("id", &roleIDs).Error; err != nil {
+			return err
+		}
+		for _, roleID := range roleIDs {
+			if err := tx.Create(&roles.AdminRole{AdminID: admin.ID, RoleID: roleID}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Admin{}, err
+	}
+	return admin, nil
+}
+
+// generateInvitationToken signs a token naming the invitation, valid until it expires
+func (s *AdminService) generateInvitationToken(invitation AdminInvitation) (string, error) {
+	claims := jwt.MapClaims{
+		"id":  invitation.ID.String(),
+		"typ": middleware.TokenTypeInvitation,
+		"exp": invitation.ExpiresAt.Unix(),
+		"iat": time.Now().Unix(),
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.cfg.JWTSecret))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %v", err)
+	}
+	return signed, nil
+}
+
+// parseInvitationToken verifies an invitation token and returns the invitation ID
+func (s *AdminService) parseInvitationToken(token string) (uuid.UUID, error) {
+	parsed, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(s.cfg.JWTSecret), nil
+	})
+	if err != nil || !parsed.Valid {
+		return uuid.Nil, ErrInvalidInvitation
+	}
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok || claims["typ"] != middleware.TokenTypeInvitation {
+		return uuid.Nil, ErrInvalidInvitation
+	}
+	id, _ := claims["id"].(string)
+	return uuid.Parse(id)
+}
@@ -0,0 +1,84 @@
+package admin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInviteAdminRejectsTakenEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		deleted bool
+	}{
+		{"active admin", false},
+		{"deleted admin", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(t)
+			inviter := newTestAdmin(t, service, "ann@example.com")
+			existing := newTestAdmin(t, service, "bo@example.com")
+			if tt.deleted {
+				if err := service.Delete(existing.ID); err != nil {
+					t.Fatalf("Delete() error = %v", err)
+				}
+			}
+
+			_, err := service.InviteAdmin(inviter.ID, "bo@example.com", "Bo", "Kim", nil, "en")
+			if !errors.Is(err, ErrEmailAlreadyExists) {
+				t.Errorf("InviteAdmin() error = %v, want ErrEmailAlreadyExists", err)
+			}
+		})
+	}
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	service, sender := newTestService(t)
+	inviter := newTestAdmin(t, service, "ann@example.com")
+
+	invitation, err := service.InviteAdmin(inviter.ID, "bo@example.com", "Bo", "Kim", nil, "en")
+	if err != nil {
+		t.Fatalf("InviteAdmin() error = %v", err)
+	}
+	if messages := sender.Messages(); len(messages) != 1 || messages[0].To[0] != "bo@example.com" {
+		t.Fatalf("emails = %+v, want one to bo@example.com", messages)
+	}
+	token, err := service.generateInvitationToken(invitation)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	admin, err := service.AcceptInvitation(token, "N3w-Passw0rd!")
+	if err != nil {
+		t.Fatalf("AcceptInvitation() error = %v", err)
+	}
+	if admin.EmailID != "bo@example.com" || admin.AddedBy != inviter.ID || !admin.EmailVerificationStatus {
+		t.Errorf("AcceptInvitation() = %+v, want a verified admin added by the inviter", admin)
+	}
+	if _, err := service.AcceptInvitation(token, "N3w-Passw0rd!"); !errors.Is(err, ErrInvalidInvitation) {
+		t.Errorf("AcceptInvitation() of a used invitation error = %v, want ErrInvalidInvitation", err)
+	}
+}
+
+func TestAcceptInvitationRejectsEmailOfDeletedAdmin(t *testing.T) {
+	service, _ := newTestService(t)
+	inviter := newTestAdmin(t, service, "ann@example.com")
+	invitation, err := service.InviteAdmin(inviter.ID, "bo@example.com", "Bo", "Kim", nil, "en")
+	if err != nil {
+		t.Fatalf("InviteAdmin() error = %v", err)
+	}
+	token, err := service.generateInvitationToken(invitation)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The address is taken after the invitation was sent, by an admin deleted since
+	taken := newTestAdmin(t, service, "bo@example.com")
+	if err := service.Delete(taken.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if _, err := service.AcceptInvitation(token, "N3w-Passw0rd!"); !errors.Is(err, ErrEmailAlreadyExists) {
+		t.Errorf("AcceptInvitation() error = %v, want ErrEmailAlreadyExists", err)
+	}
+}
@@ -0,0 +1,211 @@
+package admin
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"goUniAdmin/internal/services/apperror"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrInvalidCredentials is returned for an unknown email or a wrong password
+	ErrInvalidCredentials = apperror.New(http.StatusUnauthorized, "INVALID_CREDENTIALS", "invalid email or password")
+	// ErrAccountInactive is returned when a deactivated admin logs in
+	ErrAccountInactive = apperror.New(http.StatusForbidden, "ACCOUNT_INACTIVE", "account is inactive")
+	// ErrEmailNotVerified is returned when an admin logs in before verifying their email
+	ErrEmailNotVerified = apperror.New(http.StatusForbidden, "EMAIL_NOT_VERIFIED", "email address has not been verified")
+	// ErrTooManyLoginAttempts is returned while the account or client IP is locked out
+	ErrTooManyLoginAttempts = apperror.New(http.StatusTooManyRequests, "TOO_MANY_LOGIN_ATTEMPTS", "too many login attempts, please try again later")
+)
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+// accountAttemptKey returns the login attempt key for an email address
+func accountAttemptKey(emailID string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(emailID))
+}
+
+// ipAttemptKey returns the login attempt key for a client IP
+func ipAttemptKey(ip string) string {
+	return "ip:" + ip
+}
+
+// twoFactorAttemptKey returns the attempt key for second factor codes entered by an admin
+func twoFactorAttemptKey(adminID uuid.UUID) string {
+	return "2fa:" + adminID.String()
+}
+
+// emailRequestKey returns the attempt key for emails of a kind, e.g. "reset", requested for an
+// address from a client IP
+func emailRequestKey(kind, emailID, ip string) string {
+	return kind + ":" + ip + ":" + strings.ToLower(strings.TrimSpace(emailID))
+}
+
+// ReserveLoginAttempt counts a login attempt against the email and the client IP before the
+// password is compared, so a burst of parallel guesses cannot all pass the check before any
+// failure is recorded. It returns how long the caller must wait when either is locked out or
+// delayed, in which case nothing is counted. A successful login releases the reservation with
+// LoginSucceeded.
+func (s *AdminService) ReserveLoginAttempt(emailID, ip string) (time.Duration, error) {
+	wait, err := s.reserveAttempt(accountAttemptKey(emailID), s.cfg.LoginMaxAttempts)
+	if err != nil || wait > 0 {
+		return wait, err
+	}
+	wait, err = s.reserveAttempt(ipAttemptKey(ip), s.cfg.LoginIPMaxAttempts)
+	if err != nil || wait > 0 {
+		if releaseErr := s.releaseAttempt(accountAttemptKey(emailID)); releaseErr != nil && err == nil {
+			err = releaseErr
+		}
+	}
+	return wait, err
+}
+
+// LoginSucceeded forgets failed logins for an email and releases the attempt reserved against
+// the client IP. The rest of the IP counter is left to expire so one valid account cannot be
+// used to reset it.
+func (s *AdminService) LoginSucceeded(emailID, ip string) error {
+	if err := s.ClearLoginFailures(emailID); err != nil {
+		return err
+	}
+	return s.releaseAttempt(ipAttemptKey(ip))
+}
+
+// ReserveIPAttempt counts an attempt against the client IP alone, for steps such as the 2FA
+// login that follow a password check. A success releases it with ReleaseIPAttempt.
+func (s *AdminService) ReserveIPAttempt(ip string) (time.Duration, error) {
+	return s.reserveAttempt(ipAttemptKey(ip), s.cfg.LoginIPMaxAttempts)
+}
+
+// ReleaseIPAttempt releases an attempt reserved against the client IP that succeeded
+func (s *AdminService) ReleaseIPAttempt(ip string) error {
+	return s.releaseAttempt(ipAttemptKey(ip))
+}
+
+// ReserveEmailRequest counts a request for an email of a kind, e.g. "reset", to an address
+// from a client IP, under the same delays and limits as logins. It returns how long the caller
+// must wait when that IP has requested too many for the address, in which case nothing is
+// counted. The limit is per IP so others cannot use it to block the owner's own requests.
+// Unknown addresses are counted too.
+func (s *AdminService) ReserveEmailRequest(kind, emailID, ip string) (time.Duration, error) {
+	return s.reserveAttempt(emailRequestKey(kind, emailID, ip), s.cfg.LoginMaxAttempts)
+}
+
+// attemptWait returns the remaining lockout or progressive delay for a single key
+func (s *AdminService) attemptWait(attempt LoginAttempt, now time.Time) time.Duration {
+	if attempt.LockedUntil != nil && now.Before(*attempt.LockedUntil) {
+		return attempt.LockedUntil.Sub(now)
+	}
+	if attempt.Failures == 0 || s.cfg.LoginDelayBase <= 0 || now.Sub(attempt.LastFailedAt) > s.cfg.LoginAttemptWindow {
+		return 0
+	}
+
+	// Double the delay for every failure after the first, capped at the lockout duration
+	delay := s.cfg.LoginDelayBase
+	for i := 1; i < attempt.Failures && delay < s.cfg.LoginLockoutDuration; i++ {
+		delay *= 2
+	}
+	if s.cfg.LoginLockoutDuration > 0 && delay > s.cfg.LoginLockoutDuration {
+		delay = s.cfg.LoginLockoutDuration
+	}
+	if wait := attempt.LastFailedAt.Add(delay).Sub(now); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// reserveAttempt counts an attempt for a key unless it is locked out or delayed, in which case
+// it returns the wait. The check and the increment happen under a row lock, so concurrent
+// attempts are counted one after another. Attempts outside the window and expired lockouts
+// start the count again; reaching maxAttempts locks the key.
+func (s *AdminService) reserveAttempt(key string, maxAttempts int) (time.Duration, error) {
+	var wait time.Duration
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&LoginAttempt{Key: key}).Error; err != nil {
+			return err
+		}
+
+		var attempt LoginAttempt
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("key = ?", key).First(&attempt).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if wait = s.attemptWait(attempt, now); wait > 0 {
+			return nil
+		}
+		lockExpired := attempt.LockedUntil != nil && !now.Before(*attempt.LockedUntil)
+		if lockExpired || now.Sub(attempt.LastFailedAt) > s.cfg.LoginAttemptWindow {
+			attempt.Failures = 0
+			attempt.LockedUntil = nil
+		}
+
+		attempt.Failures++
+		attempt.LastFailedAt = now
+		if maxAttempts > 0 && attempt.Failures >= maxAttempts {
+			lockedUntil := now.Add(s.cfg.LoginLockoutDuration)
+			attempt.LockedUntil = &lockedUntil
+		}
+
+		return tx.Model(&LoginAttempt{}).Where("key = ?", key).Updates(map[string]interface{}{
+			"failures":       attempt.Failures,
+			"last_failed_at": attempt.LastFailedAt,
+			"locked_until":   attempt.LockedUntil,
+		}).Error
+	})
+	return wait, err
+}
+
+// attemptLocked reports whether a key is currently locked out
+func (s *AdminService) attemptLocked(key string) (bool, error) {
+	var count int64
+	err := s.db.Model(&LoginAttempt{}).Where("key = ? AND locked_until > ?", key, time.Now()).Count(&count).Error
+	return count > 0, err
+}
+
+// releaseAttempt takes back an attempt reserved for a key that turned out to succeed
+func (s *AdminService) releaseAttempt(key string) error {
+	return s.db.Model(&LoginAttempt{}).
+		Where("key = ? AND failures > 0", key).
+		Update("failures", gorm.Expr("failures - 1")).Error
+}
+
+// ClearLoginFailures forgets failed logins for an email
+func (s *AdminService) ClearLoginFailures(emailID string) error {
+	return s.db.Where("key = ?", accountAttemptKey(emailID)).Delete(&LoginAttempt{}).Error
+}
+
+// UnlockAdmin clears the lockouts and failure counts for an admin's password and 2FA codes.
+// The caller must hold every permission of the admin.
+func (s *AdminService) UnlockAdmin(id uuid.UUID) error {
+	admin, err := s.Read(id)
+	if err != nil {
+		return err
+	}
+	if err := s.checkManageable(id); err != nil {
+		return err
+	}
+	return s.db.Where("key IN ?", []string{accountAttemptKey(admin.EmailID), twoFactorAttemptKey(id)}).Delete(&LoginAttempt{}).Error
+}
+
+// CheckPassword compares a password against an admin's hash. When the admin does not
+// exist a dummy hash is compared instead, so both cases take the same time.
+func CheckPassword(admin *Admin, password string) bool {
+	if admin == nil {
+		dummyHashOnce.Do(func() {
+			dummyHash, _ = bcrypt.GenerateFromPassword([]byte(uuid.NewString()), bcrypt.DefaultCost)
+		})
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(admin.Password), []byte(password)) == nil
+}
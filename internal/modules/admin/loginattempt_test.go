@@ -0,0 +1,148 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestReserveLoginAttemptLocksAccount(t *testing.T) {
+	service, _ := newTestService(t)
+	admin := newTestAdmin(t, service, "ann@example.com")
+
+	for i := 1; i <= service.cfg.LoginMaxAttempts; i++ {
+		wait, err := service.ReserveLoginAttempt("Ann@Example.com ", "10.0.0.1")
+		if err != nil || wait != 0 {
+			t.Fatalf("attempt %d: ReserveLoginAttempt() = %v, %v, want no wait", i, wait, err)
+		}
+	}
+	wait, err := service.ReserveLoginAttempt("ann@example.com", "10.0.0.2")
+	if err != nil || wait <= 0 || wait > service.cfg.LoginLockoutDuration {
+		t.Fatalf("ReserveLoginAttempt() after the limit = %v, %v, want the lockout", wait, err)
+	}
+
+	if err := service.UnlockAdmin(admin.ID); err != nil {
+		t.Fatalf("UnlockAdmin() error = %v", err)
+	}
+	if wait, err := service.ReserveLoginAttempt("ann@example.com", "10.0.0.2"); err != nil || wait != 0 {
+		t.Errorf("ReserveLoginAttempt() after UnlockAdmin() = %v, %v, want no wait", wait, err)
+	}
+}
+
+func TestLoginSucceededResetsAccount(t *testing.T) {
+	service, _ := newTestService(t)
+
+	for round := 0; round < 3; round++ {
+		for i := 1; i < service.cfg.LoginMaxAttempts; i++ {
+			if wait, err := service.ReserveLoginAttempt("ann@example.com", "10.0.0.1"); err != nil || wait != 0 {
+				t.Fatalf("round %d: ReserveLoginAttempt() = %v, %v, want no wait", round, wait, err)
+			}
+		}
+		if err := service.LoginSucceeded("ann@example.com", "10.0.0.1"); err != nil {
+			t.Fatalf("LoginSucceeded() error = %v", err)
+		}
+	}
+}
+
+func TestReserveLoginAttemptLocksIP(t *testing.T) {
+	service, _ := newTestService(t)
+
+	for i := 1; i <= service.cfg.LoginIPMaxAttempts; i++ {
+		if wait, err := service.ReserveLoginAttempt(fmt.Sprintf("user%d@example.com", i), "10.0.0.1"); err != nil || wait != 0 {
+			t.Fatalf("attempt %d: ReserveLoginAttempt() = %v, %v, want no wait", i, wait, err)
+		}
+	}
+	if wait, err := service.ReserveLoginAttempt("next@example.com", "10.0.0.1"); err != nil || wait <= 0 {
+		t.Fatalf("ReserveLoginAttempt() from a locked IP = %v, %v, want a wait", wait, err)
+	}
+
+	// The attempt refused for the IP is not counted against the account
+	var attempt LoginAttempt
+	service.db.Where("key = ?", accountAttemptKey("next@example.com")).First(&attempt)
+	if attempt.Failures != 0 {
+		t.Errorf("account failures = %d, want 0", attempt.Failures)
+	}
+	if wait, err := service.ReserveLoginAttempt("next@example.com", "10.0.0.2"); err != nil || wait != 0 {
+		t.Errorf("ReserveLoginAttempt() from another IP = %v, %v, want no wait", wait, err)
+	}
+}
+
+func TestReserveEmailRequest(t *testing.T) {
+	service, _ := newTestService(t)
+
+	for i := 1; i <= service.cfg.LoginMaxAttempts; i++ {
+		if wait, err := service.ReserveEmailRequest("reset", "ann@example.com", "10.0.0.1"); err != nil || wait != 0 {
+			t.Fatalf("request %d: ReserveEmailRequest() = %v, %v, want no wait", i, wait, err)
+		}
+	}
+	if wait, err := service.ReserveEmailRequest("reset", "ANN@example.com", "10.0.0.1"); err != nil || wait <= 0 {
+		t.Errorf("ReserveEmailRequest() after the limit = %v, %v, want a wait", wait, err)
+	}
+	if wait, err := service.ReserveEmailRequest("reset", "ann@example.com", "10.0.0.2"); err != nil || wait != 0 {
+		t.Errorf("ReserveEmailRequest() from another IP = %v, %v, want no wait", wait, err)
+	}
+	if wait, err := service.ReserveEmailRequest("verify", "ann@example.com", "10.0.0.1"); err != nil || wait != 0 {
+		t.Errorf("ReserveEmailRequest() of another kind = %v, %v, want no wait", wait, err)
+	}
+}
+
+func TestForgotPasswordOverLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service, sender := newTestService(t)
+	newTestAdmin(t, service, "ann@example.com")
+	router := gin.New()
+	router.POST("/forgot-password", NewAdminHandler(service).ForgotPassword)
+
+	for i := 1; i <= service.cfg.LoginMaxAttempts+2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/forgot-password", strings.NewReader(`{"emailId":"ann@example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200", i, w.Code)
+		}
+	}
+	// Give the background sends time to finish before counting them
+	waitForMessages(t, sender, service.cfg.LoginMaxAttempts)
+	time.Sleep(50 * time.Millisecond)
+	if got := len(sender.Messages()); got != service.cfg.LoginMaxAttempts {
+		t.Errorf("sent %d emails, want %d", got, service.cfg.LoginMaxAttempts)
+	}
+}
+
+func TestAttemptWait(t *testing.T) {
+	service, _ := newTestService(t)
+	service.cfg.LoginDelayBase = time.Second
+	service.cfg.LoginLockoutDuration = 10 * time.Second
+	now := time.Now()
+	lockedUntil := now.Add(5 * time.Minute)
+	expiredLock := now.Add(-time.Second)
+
+	tests := []struct {
+		name    string
+		attempt LoginAttempt
+		want    time.Duration
+	}{
+		{"no failures", LoginAttempt{}, 0},
+		{"first failure", LoginAttempt{Failures: 1, LastFailedAt: now}, time.Second},
+		{"delay doubles", LoginAttempt{Failures: 3, LastFailedAt: now}, 4 * time.Second},
+		{"delay capped at the lockout", LoginAttempt{Failures: 8, LastFailedAt: now}, 10 * time.Second},
+		{"delay partly over", LoginAttempt{Failures: 2, LastFailedAt: now.Add(-time.Second)}, time.Second},
+		{"delay over", LoginAttempt{Failures: 2, LastFailedAt: now.Add(-3 * time.Second)}, 0},
+		{"outside the window", LoginAttempt{Failures: 5, LastFailedAt: now.Add(-time.Hour)}, 0},
+		{"locked", LoginAttempt{Failures: 5, LastFailedAt: now.Add(-time.Hour), LockedUntil: &lockedUntil}, 5 * time.Minute},
+		{"lock expired", LoginAttempt{Failures: 5, LastFailedAt: now.Add(-time.Hour), LockedUntil: &expiredLock}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := service.attemptWait(tt.attempt, now); got != tt.want {
+				t.Errorf("attemptWait() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
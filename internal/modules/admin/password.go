@@ -0,0 +1,97 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"goUniAdmin/internal/services/apperror"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidResetToken is returned when a password reset token is unknown, used or expired
+var ErrInvalidResetToken = apperror.New(http.StatusBadRequest, "INVALID_RESET_TOKEN", "invalid or expired password reset token")
+
+// RequestPasswordReset issues a single-use reset token and emails a reset link to the admin
+// in their language, or else the given locale. Unknown or inactive emails are silently ignored so callers cannot probe for accounts;
+// for the same reason the email is sent in the background and a failed delivery is only logged.
+func (s *AdminService) RequestPasswordReset(emailID, locale string) error {
+	admin, err := s.ReadByEmail(emailID)
+	if err != nil {
+		if errors.Is(err, ErrAdminNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !admin.Status {
+		return nil
+	}
+
+	rawToken, err := randomToken(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate reset token: %v", err)
+	}
+
+	// Only the hash is stored, so a database leak does not expose usable reset links
+	err = s.db.Model(&Admin{}).Where("id = ?", admin.ID).Updates(map[string]interface{}{
+		"forgot_token":               hashToken(rawToken),
+		"forgot_token_creation_time": time.Now(),
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", strings.TrimRight(s.cfg.FrontendURL, "/"), url.QueryEscape(rawToken))
+	s.sendInBackground("password reset email", admin.ID, func() error {
+		return s.mailer.SendTemplate(admin.EmailID, "password_reset", emailLocale(admin, locale), map[string]interface{}{
+			"FirstName": admin.FirstName,
+			"Link":      link,
+			"ExpiresIn": s.cfg.PasswordResetExpiry.String(),
+		})
+	})
+	return nil
+}
+
+// ResetPassword sets a new password for the admin owning the reset token. The token is
+// consumed and every existing session of the admin is revoked.
+func (s *AdminService) ResetPassword(token, newPassword string) error {
+	if err := ValidatePassword(newPassword); err != nil {
+		return apperror.Validation(err)
+	}
+
+	tokenHash := hashToken(token)
+	var admin Admin
+	if err := s.db.Where("forgot_token = ? AND is_deleted = ?", tokenHash, false).First(&admin).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+	if time.Since(admin.ForgotTokenCreationTime) > s.cfg.PasswordResetExpiry {
+		return ErrInvalidResetToken
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %v", err)
+	}
+
+	// Matching on the token hash makes the token single-use even under concurrent requests
+	result := s.db.Model(&Admin{}).Where("id = ? AND forgot_token = ?", admin.ID, tokenHash).Updates(map[string]interface{}{
+		"password":     string(hashedPassword),
+		"forgot_token": "",
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidResetToken
+	}
+
+	return s.RevokeAdminTokens(admin.ID)
+}
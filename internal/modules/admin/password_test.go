@@ -0,0 +1,113 @@
+package admin
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"goUniAdmin/internal/services/email"
+)
+
+// resetToken returns the token of the reset link in an email
+func resetToken(t *testing.T, msg email.Message) string {
+	t.Helper()
+	for _, line := range strings.Split(msg.TextBody, "\n") {
+		if link, err := url.Parse(strings.TrimSpace(line)); err == nil && link.Query().Get("token") != "" {
+			return link.Query().Get("token")
+		}
+	}
+	t.Fatalf("no reset link in %q", msg.TextBody)
+	return ""
+}
+
+func TestPasswordReset(t *testing.T) {
+	service, sender := newTestService(t)
+	admin := newTestAdmin(t, service, "ann@example.com")
+	session, _ := service.IssueTokenPair(admin.ID)
+
+	if err := service.RequestPasswordReset("nobody@example.com", "en"); err != nil {
+		t.Fatalf("RequestPasswordReset() of an unknown email error = %v", err)
+	}
+	if err := service.RequestPasswordReset("ann@example.com", "en"); err != nil {
+		t.Fatalf("RequestPasswordReset() error = %v", err)
+	}
+	messages := waitForMessages(t, sender, 1)
+	if len(messages) != 1 || messages[0].To[0] != "ann@example.com" {
+		t.Fatalf("emails = %+v, want one to ann@example.com", messages)
+	}
+	token := resetToken(t, messages[0])
+
+	var stored Admin
+	service.db.First(&stored, "id = ?", admin.ID)
+	if stored.ForgotToken == token || stored.ForgotToken != hashToken(token) {
+		t.Errorf("stored token = %q, want the hash of the emailed token", stored.ForgotToken)
+	}
+
+	if err := service.ResetPassword(token, "short"); err == nil || errors.Is(err, ErrInvalidResetToken) {
+		t.Fatalf("ResetPassword() with a weak password error = %v, want a validation error", err)
+	}
+	if err := service.ResetPassword(token, "N3w-Passw0rd!"); err != nil {
+		t.Fatalf("ResetPassword() error = %v", err)
+	}
+	service.db.First(&stored, "id = ?", admin.ID)
+	if CheckPassword(&stored, testPassword) || !CheckPassword(&stored, "N3w-Passw0rd!") {
+		t.Error("ResetPassword() did not replace the password")
+	}
+	if _, err := service.RefreshTokens(session.RefreshToken); err == nil {
+		t.Error("session survived the password reset")
+	}
+	if err := service.ResetPassword(token, "An0ther-Passw0rd!"); !errors.Is(err, ErrInvalidResetToken) {
+		t.Errorf("ResetPassword() with a used token error = %v, want ErrInvalidResetToken", err)
+	}
+}
+
+func TestPasswordResetRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(s *AdminService, a Admin)
+	}{
+		{"expired token", func(s *AdminService, a Admin) {
+			s.db.Model(&Admin{}).Where("id = ?", a.ID).Update("forgot_token_creation_time", time.Now().Add(-2*time.Hour))
+		}},
+		{"deleted admin", func(s *AdminService, a Admin) {
+			s.db.Model(&Admin{}).Where("id = ?", a.ID).Update("is_deleted", true)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, sender := newTestService(t)
+			admin := newTestAdmin(t, service, "ann@example.com")
+			if err := service.RequestPasswordReset(admin.EmailID, "en"); err != nil {
+				t.Fatalf("RequestPasswordReset() error = %v", err)
+			}
+			token := resetToken(t, waitForMessages(t, sender, 1)[0])
+			tt.prepare(service, admin)
+
+			if err := service.ResetPassword(token, "N3w-Passw0rd!"); !errors.Is(err, ErrInvalidResetToken) {
+				t.Errorf("ResetPassword() error = %v, want ErrInvalidResetToken", err)
+			}
+		})
+	}
+}
+
+func TestAccountEmailsUseAdminLanguage(t *testing.T) {
+	service, sender := newTestService(t)
+
+	_, err := service.Create(Admin{FirstName: "Bo", LastName: "Kim", EmailID: "bo@example.com", Password: "N3w-Passw0rd!", Language: "de"}, "es")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	admin := newTestAdmin(t, service, "ann@example.com")
+	service.db.Model(&Admin{}).Where("id = ?", admin.ID).Update("language", "de")
+	if err := service.RequestPasswordReset(admin.EmailID, "es"); err != nil {
+		t.Fatalf("RequestPasswordReset() error = %v", err)
+	}
+
+	for _, msg := range waitForMessages(t, sender, 2) {
+		if !strings.HasPrefix(msg.TextBody, "Hallo ") {
+			t.Errorf("email to %s = %q, want German", msg.To[0], msg.Subject)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package admin
+
+import (
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+// PhotoResolver turns the stored photo of an admin into URLs a client can load
+type PhotoResolver interface {
+	PhotoURLs(adminID uuid.UUID, photo string) (url string, thumbnailURL string, err error)
+}
+
+// PhotoResolverInstance resolves admin photos in responses, provided by the files module.
+// Without it the photo is returned as stored.
+var PhotoResolverInstance PhotoResolver
+
+// withPhotoURLs fills in the photo URLs of an admin. Failures are logged and leave them empty,
+// since a missing avatar should not fail the request.
+func withPhotoURLs(admin *Admin) {
+	if PhotoResolverInstance == nil || admin.Photo == "" {
+		return
+	}
+	url, thumbnailURL, err := PhotoResolverInstance.PhotoURLs(admin.ID, admin.Photo)
+	if err != nil {
+		slog.Error("Failed to resolve admin photo", "admin_id", admin.ID, "error", err)
+		return
+	}
+	admin.PhotoURL = url
+	admin.PhotoThumbnailURL = thumbnailURL
+}
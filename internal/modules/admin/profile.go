@@ -0,0 +1,165 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/middleware"
+	"goUniAdmin/internal/services/validators"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrIncorrectPassword is returned when the current password given to change it is wrong
+	ErrIncorrectPassword = apperror.New(http.StatusBadRequest, "INCORRECT_PASSWORD", "current password is incorrect")
+	// ErrPasswordUnchanged is returned when the new password equals the current one
+	ErrPasswordUnchanged = apperror.New(http.StatusBadRequest, "PASSWORD_UNCHANGED", "new password must differ from the current password")
+)
+
+// maxTableColumnSettingsSize bounds the stored table column settings
+const maxTableColumnSettingsSize = 64 << 10
+
+// ProfileUpdate holds the personal fields an admin may change on their own profile. Omitted
+// fields are left unchanged; an empty string clears a field.
+type ProfileUpdate struct {
+	FirstName           *string         `json:"firstName,omitempty" validate:"omitnil,notblank"`
+	LastName            *string         `json:"lastName,omitempty" validate:"omitnil,notblank"`
+	Mobile              *string         `json:"mobile,omitempty"`
+	Photo               *string         `json:"photo,omitempty"`
+	FbId                *string         `json:"fbId,omitempty"`
+	TwitterId           *string         `json:"twitterId,omitempty"`
+	InstagramId         *string         `json:"instagramId,omitempty"`
+	GithubId            *string         `json:"githubId,omitempty"`
+	Codepen             *string         `json:"codepen,omitempty"`
+	Slack               *string         `json:"slack,omitempty"`
+	TimeZone            *string         `json:"timeZone,omitempty"`
+	DateFormat          *string         `json:"dateFormat,omitempty"`
+	Language            *string         `json:"language,omitempty" enums:"en,de,es"`
+	IsThemeDark         *bool           `json:"isThemeDark,omitempty"`
+	TableColumnSettings json.RawMessage `json:"tableColumnSettings,omitempty" swaggertype:"object"`
+}
+
+// columns returns the column updates for the fields that were set
+func (p ProfileUpdate) columns() map[string]interface{} {
+	columns := map[string]interface{}{}
+	fields := map[string]*string{
+		"first_name":   p.FirstName,
+		"last_name":    p.LastName,
+		"mobile":       p.Mobile,
+		"photo":        p.Photo,
+		"fb_id":        p.FbId,
+		"twitter_id":   p.TwitterId,
+		"instagram_id": p.InstagramId,
+		"github_id":    p.GithubId,
+		"codepen":      p.Codepen,
+		"slack":        p.Slack,
+		"time_zone":    p.TimeZone,
+		"date_format":  p.DateFormat,
+		"language":     p.Language,
+	}
+	for column, value := range fields {
+		if value != nil {
+			columns[column] = *value
+		}
+	}
+	if p.IsThemeDark != nil {
+		columns["is_theme_dark"] = *p.IsThemeDark
+	}
+	if p.TableColumnSettings != nil {
+		columns["table_column_settings"] = p.TableColumnSettings
+	}
+	return columns
+}
+
+// ValidateProfileUpdate validates the fields set in a profile update
+func ValidateProfileUpdate(update ProfileUpdate) error {
+	errs := validators.Struct(update)
+	if update.Mobile != nil {
+		errs = append(errs, validators.Var("mobile", *update.Mobile, "omitempty,phone")...)
+	}
+	if update.TimeZone != nil {
+		errs = append(errs, validators.Var("timeZone", *update.TimeZone, "omitempty,timezone")...)
+	}
+	if update.Language != nil {
+		errs = append(errs, validators.Var("language", *update.Language, "omitempty,oneof=en de es")...)
+	}
+	if update.TableColumnSettings != nil {
+		if len(update.TableColumnSettings) > maxTableColumnSettingsSize {
+			errs.Add("tableColumnSettings", "max_bytes", strconv.Itoa(maxTableColumnSettingsSize))
+		} else if !json.Valid(update.TableColumnSettings) {
+			errs.Add("tableColumnSettings", "json", "")
+		}
+	}
+	return errs.Err()
+}
+
+// UpdateProfile applies an admin's changes to their own profile
+func (s *AdminService) UpdateProfile(adminID uuid.UUID, update ProfileUpdate) (Admin, error) {
+	if err := ValidateProfileUpdate(update); err != nil {
+		return Admin{}, apperror.Validation(err)
+	}
+	if update.TimeZone != nil {
+		if err := ValidateMasterFields(Admin{TimeZone: *update.TimeZone}); err != nil {
+			return Admin{}, err
+		}
+	}
+	existing, err := s.Read(adminID)
+	if err != nil {
+		return Admin{}, err
+	}
+
+	if columns := update.columns(); len(columns) > 0 {
+		if err := s.db.Model(&existing).Updates(columns).Error; err != nil {
+			return Admin{}, err
+		}
+	}
+	return s.Read(adminID)
+}
+
+// AdminAccount returns whether an admin may still use their tokens and the language they chose
+// for API messages. Unknown admins are reported as inactive.
+func (s *AdminService) AdminAccount(adminID uuid.UUID) (middleware.AdminAccount, error) {
+	var admin Admin
+	err := s.db.Select("status", "is_deleted", "language").Where("id = ?", adminID).Take(&admin).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return middleware.AdminAccount{}, nil
+	}
+	if err != nil {
+		return middleware.AdminAccount{}, err
+	}
+	return middleware.AdminAccount{Active: admin.Status && !admin.IsDeleted, Language: admin.Language}, nil
+}
+
+// ChangePassword replaces an admin's password after checking the current one. Every session
+// of the admin, including the current one, is revoked, so they must log in again.
+func (s *AdminService) ChangePassword(adminID uuid.UUID, currentPassword, newPassword string) error {
+	admin, err := s.Read(adminID)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(admin.Password), []byte(currentPassword)); err != nil {
+		return ErrIncorrectPassword
+	}
+	if err := ValidatePassword(newPassword); err != nil {
+		return apperror.Validation(err)
+	}
+	if newPassword == currentPassword {
+		return ErrPasswordUnchanged
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %v", err)
+	}
+	if err := s.db.Model(&admin).Update("password", string(hashedPassword)).Error; err != nil {
+		return err
+	}
+	return s.RevokeAdminTokens(adminID)
+}
@@ -0,0 +1,138 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"goUniAdmin/internal/services/apperror"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestUpdateProfile(t *testing.T) {
+	oversized := json.RawMessage(`{"columns":"` + strings.Repeat("x", maxTableColumnSettingsSize) + `"}`)
+
+	tests := []struct {
+		name    string
+		update  ProfileUpdate
+		wantErr bool
+		check   func(t *testing.T, got Admin)
+	}{
+		{name: "empty first name", update: ProfileUpdate{FirstName: stringPtr("")}, wantErr: true},
+		{name: "blank last name", update: ProfileUpdate{LastName: stringPtr("  ")}, wantErr: true},
+		{name: "unsupported language", update: ProfileUpdate{Language: stringPtr("fr")}, wantErr: true},
+		{name: "oversized table column settings", update: ProfileUpdate{TableColumnSettings: oversized}, wantErr: true},
+		{name: "invalid table column settings", update: ProfileUpdate{TableColumnSettings: json.RawMessage(`{"columns":`)}, wantErr: true},
+		{
+			name:   "omitted fields are unchanged",
+			update: ProfileUpdate{LastName: stringPtr("Park")},
+			check: func(t *testing.T, got Admin) {
+				if got.LastName != "Park" || got.FirstName != "Ann" || got.Mobile != "+14155550100" || got.Language != "es" {
+					t.Errorf("UpdateProfile() = %s %s, %q, %q, want only the last name changed", got.FirstName, got.LastName, got.Mobile, got.Language)
+				}
+			},
+		},
+		{
+			name:   "empty string clears an optional field",
+			update: ProfileUpdate{Mobile: stringPtr(""), TableColumnSettings: json.RawMessage(`{"admins":["email"]}`)},
+			check: func(t *testing.T, got Admin) {
+				if got.Mobile != "" || got.FirstName != "Ann" {
+					t.Errorf("UpdateProfile() mobile = %q, first name = %q, want mobile cleared", got.Mobile, got.FirstName)
+				}
+				if string(got.TableColumnSettings) != `{"admins":["email"]}` {
+					t.Errorf("UpdateProfile() table column settings = %s", got.TableColumnSettings)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(t)
+			admin := newTestAdmin(t, service, "ann@example.com")
+			service.db.Model(&admin).Updates(map[string]interface{}{"mobile": "+14155550100", "language": "es"})
+
+			got, err := service.UpdateProfile(admin.ID, tt.update)
+			if tt.wantErr {
+				var appErr *apperror.Error
+				if !errors.As(err, &appErr) || appErr.Status != http.StatusBadRequest {
+					t.Fatalf("UpdateProfile() error = %v, want a 400 error", err)
+				}
+				stored, _ := service.Read(admin.ID)
+				if stored.FirstName != "Ann" || stored.LastName != "Lee" || stored.TableColumnSettings != nil {
+					t.Errorf("rejected update changed the admin: %+v", stored)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateProfile() error = %v", err)
+			}
+			tt.check(t, got)
+		})
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	const newPassword = "N3w-Passw0rd!"
+
+	tests := []struct {
+		name     string
+		current  string
+		password string
+		wantErr  error
+	}{
+		{"wrong current password", "wrong-Passw0rd!", newPassword, ErrIncorrectPassword},
+		{"same password", testPassword, testPassword, ErrPasswordUnchanged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(t)
+			admin := newTestAdmin(t, service, "ann@example.com")
+
+			if err := service.ChangePassword(admin.ID, tt.current, tt.password); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ChangePassword() error = %v, want %v", err, tt.wantErr)
+			}
+			stored, _ := service.Read(admin.ID)
+			if bcrypt.CompareHashAndPassword([]byte(stored.Password), []byte(testPassword)) != nil {
+				t.Error("rejected change replaced the password")
+			}
+		})
+	}
+
+	t.Run("weak new password", func(t *testing.T) {
+		service, _ := newTestService(t)
+		admin := newTestAdmin(t, service, "ann@example.com")
+
+		var appErr *apperror.Error
+		err := service.ChangePassword(admin.ID, testPassword, "short")
+		if !errors.As(err, &appErr) || appErr.Code != apperror.CodeValidationFailed {
+			t.Errorf("ChangePassword() error = %v, want a validation error", err)
+		}
+	})
+
+	t.Run("success revokes sessions", func(t *testing.T) {
+		service, _ := newTestService(t)
+		admin := newTestAdmin(t, service, "ann@example.com")
+		pair, err := service.IssueTokenPair(admin.ID)
+		if err != nil {
+			t.Fatalf("IssueTokenPair() error = %v", err)
+		}
+
+		if err := service.ChangePassword(admin.ID, testPassword, newPassword); err != nil {
+			t.Fatalf("ChangePassword() error = %v", err)
+		}
+		stored, _ := service.Read(admin.ID)
+		if bcrypt.CompareHashAndPassword([]byte(stored.Password), []byte(newPassword)) != nil {
+			t.Error("password was not replaced")
+		}
+		if _, err := service.RefreshTokens(pair.RefreshToken); err == nil {
+			t.Error("RefreshTokens() after a password change succeeded, want the session revoked")
+		}
+	})
+}
@@ -5,7 +5,7 @@ import (
 	"goUniAdmin/internal/db"
 	"goUniAdmin/internal/modules"
 	"goUniAdmin/internal/services/middleware"
-	"log"
+	"log/slog"
 
 	"github.com/gin-gonic/gin"
 )
@@ -18,22 +18,41 @@ type adminModule struct {
 // RegisterRoutes sets up the admin routes
 func (m *adminModule) RegisterRoutes(group *gin.RouterGroup, cfg *config.Config, db *db.DB) {
 	adminGroup := group.Group("/admins")
-	adminGroup.POST("", m.handler.CreateAdmin)
-	adminGroup.GET("", m.handler.ListAdmins)
 	adminGroup.POST("/login", m.handler.AdminLogin)
+	adminGroup.POST("/token/refresh", m.handler.RefreshToken)
+	adminGroup.POST("/forgot-password", m.handler.ForgotPassword)
+	adminGroup.POST("/reset-password", m.handler.ResetPassword)
+	adminGroup.GET("/verify-email", m.handler.VerifyEmail)
+	adminGroup.POST("/verify-email/resend", m.handler.ResendVerification)
+	adminGroup.POST("/2fa/verify", m.handler.VerifyTwoFactor)
+	adminGroup.POST("/invitations/accept", m.handler.AcceptInvitation)
 
 	// Protected routes with JWT authentication
 	adminGroup.Use(middleware.AuthMiddleware(cfg))
-	adminGroup.GET("/:id", m.handler.GetAdmin)
-	adminGroup.PUT("/:id", m.handler.UpdateAdmin)
-	adminGroup.DELETE("/:id", m.handler.DeleteAdmin)
+	adminGroup.POST("", middleware.RequirePermission("admins:create"), m.handler.CreateAdmin)
+	adminGroup.GET("", middleware.RequirePermission("admins:read"), m.handler.ListAdmins)
+	adminGroup.POST("/invitations", middleware.RequirePermission("admins:create"), m.handler.InviteAdmin)
+	adminGroup.GET("/invitations", middleware.RequirePermission("admins:read"), m.handler.ListInvitations)
+	adminGroup.DELETE("/invitations/:id", middleware.RequirePermission("admins:create"), m.handler.RevokeInvitation)
+	adminGroup.GET("/:id", middleware.RequirePermission("admins:read"), m.handler.GetAdmin)
+	adminGroup.PUT("/:id", middleware.RequirePermission("admins:update"), m.handler.UpdateAdmin)
+	adminGroup.DELETE("/:id", middleware.RequirePermission("admins:delete"), m.handler.DeleteAdmin)
+	adminGroup.POST("/:id/unlock", middleware.RequirePermission("admins:unlock"), m.handler.UnlockAdmin)
 	adminGroup.GET("/profile", m.handler.GetProfile)
+	adminGroup.PUT("/profile", m.handler.UpdateProfile)
+	adminGroup.POST("/profile/change-password", m.handler.ChangePassword)
+	adminGroup.POST("/logout", m.handler.Logout)
+	adminGroup.POST("/2fa/setup", m.handler.SetupTwoFactor)
+	adminGroup.POST("/2fa/confirm", m.handler.ConfirmTwoFactor)
+	adminGroup.POST("/2fa/disable", m.handler.DisableTwoFactor)
+	adminGroup.POST("/2fa/recovery-codes", m.handler.RegenerateRecoveryCodes)
 }
 
 // RegisterAdminModule registers the admin module with the given dependencies
 func RegisterAdminModule(cfg *config.Config, db *db.DB) {
 	service := NewAdminService(db, cfg)
 	handler := NewAdminHandler(service)
+	middleware.AdminAccountsInstance = service
 	modules.RegisterModule(&adminModule{handler: handler})
-	log.Println("Admin module registered")
+	slog.Info("Module registered", "module", "admin")
 }
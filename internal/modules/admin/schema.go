@@ -11,19 +11,21 @@ import (
 // Admin represents an admin user
 type Admin struct {
 	ID                            uuid.UUID       `gorm:"type:uuid;primaryKey" json:"_id"`
-	FirstName                     string          `gorm:"not null" json:"firstName"`
-	LastName                      string          `gorm:"not null" json:"lastName"`
+	FirstName                     string          `gorm:"not null" json:"firstName" validate:"required"`
+	LastName                      string          `gorm:"not null" json:"lastName" validate:"required"`
 	UserName                      string          `json:"userName,omitempty"`
-	Mobile                        string          `json:"mobile,omitempty"`
-	EmailID                       string          `gorm:"not null;unique" json:"emailId"` // Ensure unique email
-	Password                      string          `gorm:"not null" json:"-"`              // Exclude password from JSON
-	Photo                         string          `json:"photo,omitempty"`
-	EmailVerificationStatus       bool            `gorm:"default:true" json:"emailVerificationStatus"`
-	VerificationToken             string          `json:"verificationToken,omitempty"`
+	Mobile                        string          `json:"mobile,omitempty" validate:"omitempty,phone"`
+	EmailID                       string          `gorm:"not null;unique" json:"emailId" validate:"required,email"` // Ensure unique email
+	Password                      string          `gorm:"not null" json:"-"`                                        // Exclude password from JSON
+	Photo                         string          `json:"photo,omitempty"`                                          // Uploaded avatar file ID or an external URL
+	PhotoURL                      string          `gorm:"-" json:"photoUrl,omitempty"`                              // Signed URL of the photo
+	PhotoThumbnailURL             string          `gorm:"-" json:"photoThumbnailUrl,omitempty"`
+	EmailVerificationStatus       bool            `gorm:"default:false" json:"emailVerificationStatus"`
+	VerificationToken             string          `json:"-"` // SHA-256 of the email verification token
 	VerificationTokenCreationTime time.Time       `json:"verificationTokenCreationTime,omitempty"`
-	DateOfBirth                   time.Time       `json:"dateOfBirth,omitempty"`
-	Gender                        string          `json:"gender,omitempty"`
-	Website                       string          `json:"website,omitempty"`
+	DateOfBirth                   time.Time       `json:"dateOfBirth,omitempty" validate:"omitempty,past"`
+	Gender                        string          `json:"gender,omitempty" validate:"omitempty,oneof=male female other"`
+	Website                       string          `json:"website,omitempty" validate:"omitempty,website"`
 	Address                       string          `json:"address,omitempty"`
 	FbId                          string          `json:"fbId,omitempty"`
 	TwitterId                     string          `json:"twitterId,omitempty"`
@@ -32,23 +34,91 @@ type Admin struct {
 	Codepen                       string          `json:"codepen,omitempty"`
 	Slack                         string          `json:"slack,omitempty"`
 	SendOTPToken                  string          `json:"sendOTPToken,omitempty"`
-	ForgotToken                   string          `json:"forgotToken,omitempty"`
+	ForgotToken                   string          `json:"-"` // SHA-256 of the password reset token
 	ForgotTokenCreationTime       time.Time       `json:"forgotTokenCreationTime,omitempty"`
 	DeviceToken                   string          `json:"deviceToken,omitempty"`
 	Device                        string          `json:"device,omitempty"`
 	IsThemeDark                   bool            `json:"isThemeDark,omitempty"`
 	AddedBy                       uuid.UUID       `gorm:"type:uuid" json:"addedBy,omitempty"` // Use UUID type for consistency
-	CountryCode                   string          `json:"countryCode,omitempty"`
-	TimeZone                      string          `json:"timeZone,omitempty"`
+	CountryCode                   string          `json:"countryCode,omitempty" validate:"omitempty,country"`
+	TimeZone                      string          `json:"timeZone,omitempty" validate:"omitempty,timezone"`
 	DateFormat                    string          `json:"dateFormat,omitempty"`
+	Language                      string          `json:"language,omitempty" validate:"omitempty,oneof=en de es"` // Language of API messages, outranks Accept-Language
 	Currency                      string          `json:"currency,omitempty"`
 	TableColumnSettings           json.RawMessage `json:"tableColumnSettings,omitempty"`
+	TwoFactorEnabled              bool            `gorm:"default:false" json:"twoFactorEnabled"`
+	TwoFactorSecret               string          `json:"-"` // Base32 TOTP secret, pending until confirmed
+	TwoFactorLastStep             int64           `json:"-"` // Last accepted TOTP time step, prevents code replay
 	Status                        bool            `gorm:"default:true" json:"status"`
 	IsDeleted                     bool            `gorm:"default:false" json:"isDeleted"`
 	CreatedAt                     time.Time       `gorm:"autoCreateTime" json:"createdAt,omitempty"`
 	UpdatedAt                     time.Time       `gorm:"autoUpdateTime" json:"updatedAt,omitempty"`
 }
 
+// AdminUpdate holds the fields another admin may change through PUT /admins/{id}. Omitted
+// fields are left unchanged; an empty string clears an optional field. Deletion, passwords,
+// verification and two-factor state are changed only through their own endpoints.
+type AdminUpdate struct {
+	FirstName   *string    `json:"firstName,omitempty" validate:"omitnil,notblank"`
+	LastName    *string    `json:"lastName,omitempty" validate:"omitnil,notblank"`
+	UserName    *string    `json:"userName,omitempty"`
+	Mobile      *string    `json:"mobile,omitempty"`
+	EmailID     *string    `json:"emailId,omitempty" validate:"omitnil,email"` // A new address must be verified again
+	DateOfBirth *time.Time `json:"dateOfBirth,omitempty" validate:"omitnil,past"`
+	Gender      *string    `json:"gender,omitempty" enums:"male,female,other"`
+	Website     *string    `json:"website,omitempty"`
+	Address     *string    `json:"address,omitempty"`
+	CountryCode *string    `json:"countryCode,omitempty"`
+	TimeZone    *string    `json:"timeZone,omitempty"`
+	DateFormat  *string    `json:"dateFormat,omitempty"`
+	Currency    *string    `json:"currency,omitempty"`
+	Status      *bool      `json:"status,omitempty"` // Deactivating revokes the admin's sessions
+}
+
+// columns returns the column updates for the fields that were set, other than the email
+// and status, which need their own handling
+func (u AdminUpdate) columns() map[string]interface{} {
+	columns := map[string]interface{}{}
+	fields := map[string]*string{
+		"first_name":   u.FirstName,
+		"last_name":    u.LastName,
+		"user_name":    u.UserName,
+		"mobile":       u.Mobile,
+		"gender":       u.Gender,
+		"website":      u.Website,
+		"address":      u.Address,
+		"country_code": u.CountryCode,
+		"time_zone":    u.TimeZone,
+		"date_format":  u.DateFormat,
+		"currency":     u.Currency,
+	}
+	for column, value := range fields {
+		if value != nil {
+			columns[column] = *value
+		}
+	}
+	if u.DateOfBirth != nil {
+		columns["date_of_birth"] = *u.DateOfBirth
+	}
+	return columns
+}
+
+// masterFields returns the country, currency and time zone that were set, for checking
+// against the master data lists
+func (u AdminUpdate) masterFields() Admin {
+	var admin Admin
+	if u.CountryCode != nil {
+		admin.CountryCode = *u.CountryCode
+	}
+	if u.Currency != nil {
+		admin.Currency = *u.Currency
+	}
+	if u.TimeZone != nil {
+		admin.TimeZone = *u.TimeZone
+	}
+	return admin
+}
+
 // AdminRelation represents the self-referential relationship for Admin
 type AdminRelation struct {
 	ID      uuid.UUID `gorm:"type:uuid;primaryKey" json:"_id"`
@@ -63,3 +133,74 @@ func (a *Admin) BeforeCreate(tx *gorm.DB) (err error) {
 	}
 	return
 }
+
+// RefreshToken represents a persisted, hashed refresh token. Tokens issued from the
+// same login share a FamilyID so that reuse of a rotated token can revoke the whole chain.
+type RefreshToken struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey" json:"_id"`
+	AdminID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"adminId"`
+	FamilyID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"familyId"`
+	TokenHash  string     `gorm:"not null;uniqueIndex" json:"-"` // SHA-256 of the raw token, never the token itself
+	ExpiresAt  time.Time  `gorm:"not null" json:"expiresAt"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	ReplacedBy *uuid.UUID `gorm:"type:uuid" json:"replacedBy,omitempty"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"createdAt,omitempty"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (t *RefreshToken) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return
+}
+
+// RecoveryCode is a hashed one-time code that can replace a TOTP code when the authenticator is lost
+type RecoveryCode struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey" json:"_id"`
+	AdminID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"adminId"`
+	CodeHash  string     `gorm:"not null" json:"-"`
+	UsedAt    *time.Time `json:"usedAt,omitempty"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"createdAt,omitempty"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (r *RecoveryCode) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
+
+// LoginAttempt tracks recent failed logins for one account or client IP.
+// Key is "account:<email>" or "ip:<address>", so unknown emails are tracked like real ones.
+type LoginAttempt struct {
+	Key          string     `gorm:"primaryKey" json:"key"`
+	Failures     int        `gorm:"not null;default:0" json:"failures"`
+	LastFailedAt time.Time  `json:"lastFailedAt"`
+	LockedUntil  *time.Time `json:"lockedUntil,omitempty"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime" json:"updatedAt,omitempty"`
+}
+
+// AdminInvitation is a pending invitation for someone to become an admin. The link sent by
+// email carries a signed token naming the invitation; the row makes it single-use and revocable.
+type AdminInvitation struct {
+	ID         uuid.UUID   `gorm:"type:uuid;primaryKey" json:"_id"`
+	EmailID    string      `gorm:"not null;index" json:"emailId"`
+	FirstName  string      `gorm:"not null" json:"firstName"`
+	LastName   string      `gorm:"not null" json:"lastName"`
+	InvitedBy  uuid.UUID   `gorm:"type:uuid;not null" json:"invitedBy"`
+	RoleIDs    []uuid.UUID `gorm:"type:jsonb;serializer:json" json:"roleIds"` // Granted when the invitation is accepted
+	ExpiresAt  time.Time   `gorm:"not null" json:"expiresAt"`
+	AcceptedAt *time.Time  `json:"acceptedAt,omitempty"`
+	RevokedAt  *time.Time  `json:"revokedAt,omitempty"`
+	CreatedAt  time.Time   `gorm:"autoCreateTime" json:"createdAt,omitempty"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (i *AdminInvitation) BeforeCreate(tx *gorm.DB) (err error) {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return
+}
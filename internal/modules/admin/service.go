@@ -1,12 +1,20 @@
 package admin
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
 
 	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/modules/roles"
+	"goUniAdmin/internal/services/apperror"
 
 	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/services/email"
+	"goUniAdmin/internal/services/middleware"
+	"goUniAdmin/internal/services/query"
 
 	"time"
 
@@ -15,111 +23,277 @@ import (
 	"gorm.io/gorm"
 )
 
+// ErrAdminNotFound is returned when no active admin matches the lookup
+var ErrAdminNotFound = apperror.New(http.StatusNotFound, "ADMIN_NOT_FOUND", "admin not found")
+
 // AdminService manages admin CRUD operations with GORM
 type AdminService struct {
-	db  *db.DB
-	cfg *config.Config // Pointer to config
+	db     *db.DB
+	cfg    *config.Config // Pointer to config
+	mailer *email.Mailer  // Delivers account emails such as password resets
+	caller uuid.UUID      // Admin acting through the API; uuid.Nil for internal callers such as the seeder
 }
 
 // NewAdminService initializes the service with a GORM database connection and config
 func NewAdminService(db *db.DB, cfg *config.Config) *AdminService {
 	return &AdminService{
-		db:  db,
-		cfg: cfg,
+		db:     db,
+		cfg:    cfg,
+		mailer: email.NewMailerFromConfig(cfg),
 	}
 }
 
-func (s *AdminService) Create(admin Admin) (Admin, error) {
+// WithContext returns a copy of the service whose queries carry ctx, e.g. audit.Context(c) so
+// the audit log attributes the writes to the request
+func (s *AdminService) WithContext(ctx context.Context) *AdminService {
+	clone := *s
+	clone.db = &db.DB{DB: s.db.WithContext(ctx)}
+	return &clone
+}
+
+// WithCaller returns a copy of the service acting on behalf of an admin. Updating, deleting
+// and unlocking another admin then requires the caller to hold every permission of that admin.
+func (s *AdminService) WithCaller(adminID uuid.UUID) *AdminService {
+	clone := *s
+	clone.caller = adminID
+	return &clone
+}
+
+// checkManageable returns roles.ErrPermissionEscalation unless the caller holds every
+// permission of the admin
+func (s *AdminService) checkManageable(id uuid.UUID) error {
+	return roles.NewRoleService(s.db, s.cfg).WithCaller(s.caller).CheckManageable(id)
+}
+
+// SetSender replaces the email sender, e.g. with an in-memory sender in tests
+func (s *AdminService) SetSender(sender email.Sender) {
+	s.mailer.SetSender(sender)
+}
+
+// Create adds an admin and emails them a verification link in their language, else in locale,
+// the language of the request
+func (s *AdminService) Create(admin Admin, locale string) (Admin, error) {
 	if err := ValidateAdmin(admin); err != nil {
+		return Admin{}, apperror.Validation(err)
+	}
+	if err := ValidateMasterFields(admin); err != nil {
 		return Admin{}, err
 	}
 
-	var existingAdmin Admin
-	if err := s.db.Where("email_id = ? AND	is_deleted=?", admin.EmailID, false).First(&existingAdmin).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return Admin{}, err // Database error
+	if err := checkEmailAvailable(s.db.DB, admin.EmailID, uuid.Nil); err != nil {
+		return Admin{}, err
 	}
 
-	if existingAdmin.ID != uuid.Nil {
-		return Admin{}, errors.New("Email already exists")
+	// New admins must confirm their email address before they can log in
+	rawToken, err := randomToken(32)
+	if err != nil {
+		return Admin{}, fmt.Errorf("failed to generate verification token: %v", err)
 	}
+	admin.EmailVerificationStatus = false
+	admin.VerificationToken = hashToken(rawToken)
+	admin.VerificationTokenCreationTime = time.Now()
 
 	if err := s.db.Create(&admin).Error; err != nil {
 		return Admin{}, err
 	}
+
+	// The account exists at this point; a failed email can be retried through the resend endpoint
+	if err := s.sendVerificationEmail(admin, rawToken, locale); err != nil {
+		slog.ErrorContext(s.db.Statement.Context, "Failed to send verification email", "admin_id", admin.ID, "error", err)
+	}
 	return admin, nil
 }
 
+// checkEmailAvailable returns ErrEmailAlreadyExists when an admin other than excludeID uses the
+// email. Deleted admins keep their address, which the unique index still covers, so they count.
+func checkEmailAvailable(tx *gorm.DB, emailID string, excludeID uuid.UUID) error {
+	var count int64
+	if err := tx.Model(&Admin{}).Where("email_id = ? AND id <> ?", emailID, excludeID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrEmailAlreadyExists
+	}
+	return nil
+}
+
 // Read retrieves an admin by ID
 func (s *AdminService) Read(id uuid.UUID) (Admin, error) {
 	var admin Admin
 	result := s.db.Where("id = ? AND is_deleted = ?", id, false).First(&admin)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return Admin{}, errors.New("Admin not found")
+			return Admin{}, ErrAdminNotFound
 		}
 		return Admin{}, result.Error
 	}
 	return admin, nil
 }
 
-// Update modifies an existing admin
-func (s *AdminService) Update(id uuid.UUID, updated Admin) (Admin, error) {
-	if err := ValidateAdmin(updated); err != nil {
+// Update applies the fields set in update to an admin. A new email address must be unused
+// and is verified again, with the email in the admin's language, else in locale; a status change
+// goes through SetStatus. The caller must hold every permission of the admin.
+func (s *AdminService) Update(id uuid.UUID, update AdminUpdate, locale string) (Admin, error) {
+	if err := ValidateAdminUpdate(update); err != nil {
+		return Admin{}, apperror.Validation(err)
+	}
+	if err := ValidateMasterFields(update.masterFields()); err != nil {
 		return Admin{}, err
 	}
 
-	var existing Admin
-	result := s.db.Where("id = ? AND is_deleted = ?", id, false).First(&existing)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return Admin{}, errors.New("admin not found")
+	existing, err := s.Read(id)
+	if err != nil {
+		return Admin{}, err
+	}
+	if err := s.checkManageable(id); err != nil {
+		return Admin{}, err
+	}
+
+	columns := update.columns()
+	var rawToken string
+	if update.EmailID != nil && *update.EmailID != existing.EmailID {
+		if err := checkEmailAvailable(s.db.DB, *update.EmailID, id); err != nil {
+			return Admin{}, err
+		}
+		if rawToken, err = randomToken(32); err != nil {
+			return Admin{}, fmt.Errorf("failed to generate verification token: %v", err)
+		}
+		columns["email_id"] = *update.EmailID
+		columns["email_verification_status"] = false
+		columns["verification_token"] = hashToken(rawToken)
+		columns["verification_token_creation_time"] = time.Now()
+	}
+
+	if len(columns) > 0 {
+		if err := s.db.Model(&existing).Updates(columns).Error; err != nil {
+			return Admin{}, err
+		}
+	}
+	if update.Status != nil && *update.Status != existing.Status {
+		if err := s.setStatus(id, *update.Status); err != nil {
+			return Admin{}, err
 		}
-		return Admin{}, result.Error
 	}
 
-	updated.ID = id // Ensure ID isn't changed
-	if err := s.db.Model(&existing).Updates(updated).Error; err != nil {
+	updated, err := s.Read(id)
+	if err != nil {
 		return Admin{}, err
 	}
+	if rawToken != "" {
+		// The change is saved; a failed email can be retried through the resend endpoint
+		if err := s.sendVerificationEmail(updated, rawToken, locale); err != nil {
+			slog.ErrorContext(s.db.Statement.Context, "Failed to send verification email", "admin_id", id, "error", err)
+		}
+	}
 	return updated, nil
 }
 
-// Delete performs a soft delete by setting IsDeleted to true
+// Delete performs a soft delete by setting IsDeleted to true. The caller must hold every
+// permission of the admin.
 func (s *AdminService) Delete(id uuid.UUID) error {
+	if err := s.checkManageable(id); err != nil {
+		return err
+	}
 	result := s.db.Model(&Admin{}).Where("id = ? AND is_deleted = ?", id, false).Update("is_deleted", true)
 	if result.Error != nil {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return errors.New("admin not found")
+		return ErrAdminNotFound
+	}
+	return s.RevokeAdminTokens(id)
+}
+
+// SetStatus activates or deactivates an admin. Deactivation revokes all of the admin's tokens.
+// The caller must hold every permission of the admin.
+func (s *AdminService) SetStatus(id uuid.UUID, status bool) error {
+	if err := s.checkManageable(id); err != nil {
+		return err
+	}
+	return s.setStatus(id, status)
+}
+
+// setStatus activates or deactivates an admin without checking the caller
+func (s *AdminService) setStatus(id uuid.UUID, status bool) error {
+	result := s.db.Model(&Admin{}).Where("id = ? AND is_deleted = ?", id, false).Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAdminNotFound
+	}
+	if !status {
+		return s.RevokeAdminTokens(id)
 	}
 	return nil
 }
 
-// List returns all non-deleted admins
-func (s *AdminService) List(limit, offset int) ([]Admin, int64, error) {
-	var admins []Admin
-	// Query with filter, limit, offset, and ordering by created_at in descending order
+// AdminListSpec declares the search, filter and sort parameters accepted by the admin list
+var AdminListSpec = query.Spec{
+	SearchColumns: []string{"first_name", "last_name", "email_id", "user_name", "mobile"},
+	Filters: map[string]query.Filter{
+		"status":      {Column: "status", Type: query.FilterBool},
+		"gender":      {Column: "gender", Type: query.FilterIn},
+		"countryCode": {Column: "country_code", Type: query.FilterIn},
+		"createdFrom": {Column: "created_at", Type: query.FilterFrom},
+		"createdTo":   {Column: "created_at", Type: query.FilterTo},
+	},
+	Sorts: map[string]string{
+		"firstName": "first_name",
+		"lastName":  "last_name",
+		"emailId":   "email_id",
+		"status":    "status",
+		"createdAt": "created_at",
+		"updatedAt": "updated_at",
+	},
+	DefaultSort: "-createdAt",
+	TieBreaker:  "id",
+}
 
-	result := s.db.Where("is_deleted = ?", false).
-		Order("created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&admins)
+// List retrieves non-deleted admins matching the search and filters, in the requested order
+func (s *AdminService) List(params query.Params, limit, offset int, withTotal bool) ([]Admin, int64, error) {
+	base := s.listQuery(params)
 
 	// Get the total count of records matching the filter (without limit/offset)
 	var totalCount int64
-	err := s.db.Model(&Admin{}).Where("is_deleted = ?", false).Count(&totalCount).Error
+	if withTotal {
+		if err := base.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+			return nil, 0, err
+		}
+	}
 
-	// If there was an error fetching the total count, return the error
-	if err != nil {
+	var admins []Admin
+	if err := params.Order(base.Session(&gorm.Session{})).Limit(limit).Offset(offset).Find(&admins).Error; err != nil {
 		return nil, 0, err
 	}
+	return admins, totalCount, nil
+}
 
-	if result.Error != nil {
-		return nil, 0, result.Error
+// ListByCursor returns one keyset page of non-deleted admins matching params, with the cursors
+// of the neighbouring pages. The count is only run when withTotal is set.
+func (s *AdminService) ListByCursor(params query.Params, page query.CursorPage, withTotal bool) ([]Admin, query.Cursors, int64, error) {
+	base := s.listQuery(params)
+
+	var totalCount int64
+	if withTotal {
+		if err := base.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+			return nil, query.Cursors{}, 0, err
+		}
 	}
-	return admins, totalCount, nil
+
+	var admins []Admin
+	if err := page.Apply(base.Session(&gorm.Session{})).Find(&admins).Error; err != nil {
+		return nil, query.Cursors{}, 0, err
+	}
+	admins, cursors := query.CursorResult(page, admins, func(a Admin) (time.Time, string) {
+		return a.CreatedAt, a.ID.String()
+	})
+	return admins, cursors, totalCount, nil
+}
+
+// listQuery is the base query shared by List and ListByCursor
+func (s *AdminService) listQuery(params query.Params) *gorm.DB {
+	return params.Where(s.db.Model(&Admin{}).Where("is_deleted = ?", false))
 }
 
 // Read amin by email
@@ -128,7 +302,7 @@ func (s *AdminService) ReadByEmail(email string) (Admin, error) {
 	result := s.db.Where("email_id =? AND is_deleted =?", email, false).First(&admin)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return Admin{}, errors.New("admin not found")
+			return Admin{}, ErrAdminNotFound
 		}
 		return Admin{}, result.Error
 	}
@@ -142,17 +316,17 @@ func (s *AdminService) GenerateToken(id uuid.UUID) (string, error) {
 		return "", fmt.Errorf("service or JWT secret is not initialized")
 	}
 
-	// Log the secret length and ID for debugging
-	fmt.Printf("Generating token for ID: %s, JWTSecret length: %d\n", id.String(), len(s.cfg.JWTSecret))
-
-	// Set the expiration time (e.g., 1 hour from now)
-	expirationTime := time.Now().Add(1 * time.Hour)
+	// Set the expiration time from the configured access token lifetime
+	now := time.Now()
+	expirationTime := now.Add(s.cfg.JWTExpiry)
 
 	// Create the claims with standard fields
 	claims := jwt.MapClaims{
-		"id":  id.String(),           // Convert UUID to string for safety
-		"exp": expirationTime.Unix(), // Set expiration time as a Unix timestamp
-		"iat": time.Now().Unix(),     // Set issued at time as a Unix timestamp
+		"id":  id.String(),                   // Convert UUID to string for safety
+		"jti": uuid.NewString(),              // Unique token ID so the token can be revoked on logout
+		"typ": middleware.TokenTypeAccess,    // Distinguishes access tokens from 2FA challenge tokens
+		"exp": expirationTime.Unix(),         // Set expiration time as a Unix timestamp
+		"iat": middleware.IssuedAtClaim(now), // Issued at with microsecond precision, see revocation.Store.RevokeAdmin
 	}
 
 	// Generate the token with the claims
@@ -0,0 +1,238 @@
+package admin
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/modules/roles"
+	"goUniAdmin/internal/services/email"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testPassword is the password of admins created by newTestAdmin
+const testPassword = "Passw0rd!"
+
+// newTestService returns an admin service on a fresh SQLite database whose emails go to the
+// returned memory sender
+func newTestService(t *testing.T) (*AdminService, *email.MemorySender) {
+	t.Helper()
+	conn, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "admin.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := conn.AutoMigrate(&Admin{}, &RefreshToken{}, &LoginAttempt{}, &AdminInvitation{}, &RecoveryCode{}, &roles.Role{}, &roles.RolePermission{}, &roles.AdminRole{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	cfg := &config.Config{
+		AppName:              "goUniAdmin",
+		EmailDriver:          "memory",
+		FrontendURL:          "http://admin.test",
+		PasswordResetExpiry:  time.Hour,
+		InvitationExpiry:     time.Hour,
+		JWTSecret:            "test-secret",
+		JWTExpiry:            time.Hour,
+		RefreshTokenExpiry:   24 * time.Hour,
+		LoginMaxAttempts:     3,
+		LoginIPMaxAttempts:   10,
+		LoginAttemptWindow:   15 * time.Minute,
+		LoginLockoutDuration: 15 * time.Minute,
+	}
+	service := NewAdminService(&db.DB{DB: conn}, cfg)
+	sender := email.NewMemorySender()
+	service.SetSender(sender)
+	return service, sender
+}
+
+// newTestAdmin stores an active, verified admin with testPassword
+func newTestAdmin(t *testing.T, service *AdminService, emailID string) Admin {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin := Admin{
+		FirstName:               "Ann",
+		LastName:                "Lee",
+		EmailID:                 emailID,
+		Password:                string(hash),
+		EmailVerificationStatus: true,
+		Status:                  true,
+	}
+	if err := service.db.Create(&admin).Error; err != nil {
+		t.Fatalf("create admin: %v", err)
+	}
+	return admin
+}
+
+// waitForMessages waits until the sender holds n messages, since account emails are sent in
+// the background
+func waitForMessages(t *testing.T, sender *email.MemorySender, n int) []email.Message {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		messages := sender.Messages()
+		if len(messages) >= n {
+			return messages
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d emails, want %d", len(messages), n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestCreateRejectsTakenEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(s *AdminService, a Admin)
+	}{
+		{"active admin", func(*AdminService, Admin) {}},
+		{"deleted admin", func(s *AdminService, a Admin) {
+			if err := s.Delete(a.ID); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(t)
+			tt.prepare(service, newTestAdmin(t, service, "ann@example.com"))
+
+			_, err := service.Create(Admin{FirstName: "Bo", LastName: "Kim", EmailID: "ann@example.com", Password: "N3w-Passw0rd!"}, "en")
+			if !errors.Is(err, ErrEmailAlreadyExists) {
+				t.Errorf("Create() error = %v, want ErrEmailAlreadyExists", err)
+			}
+		})
+	}
+}
+
+func TestUpdateRejectsEmailOfDeletedAdmin(t *testing.T) {
+	service, _ := newTestService(t)
+	deleted := newTestAdmin(t, service, "ann@example.com")
+	if err := service.Delete(deleted.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	admin := newTestAdmin(t, service, "bo@example.com")
+
+	emailID := "ann@example.com"
+	if _, err := service.Update(admin.ID, AdminUpdate{EmailID: &emailID}, "en"); !errors.Is(err, ErrEmailAlreadyExists) {
+		t.Errorf("Update() error = %v, want ErrEmailAlreadyExists", err)
+	}
+}
+
+func TestVerificationEmailLanguage(t *testing.T) {
+	tests := []struct {
+		name          string
+		adminLanguage string
+		send          func(s *AdminService, language string) error
+		wantGreeting  string
+	}{
+		{"create in the request language", "", createAdmin, "Hola "},
+		{"create in the admin's language", "de", createAdmin, "Hallo "},
+		{"email change in the request language", "", changeEmail, "Hola "},
+		{"email change in the admin's language", "de", changeEmail, "Hallo "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, sender := newTestService(t)
+			if err := tt.send(service, tt.adminLanguage); err != nil {
+				t.Fatal(err)
+			}
+			messages := sender.Messages()
+			if len(messages) != 1 || !strings.HasPrefix(messages[0].TextBody, tt.wantGreeting) {
+				t.Errorf("verification emails = %+v, want one starting %q", messages, tt.wantGreeting)
+			}
+		})
+	}
+}
+
+// createAdmin creates an admin with the language in a Spanish request
+func createAdmin(s *AdminService, language string) error {
+	_, err := s.Create(Admin{FirstName: "Bo", LastName: "Kim", EmailID: "bo@example.com", Password: "N3w-Passw0rd!", Language: language}, "es")
+	return err
+}
+
+// changeEmail changes the email address of an admin with the language in a Spanish request
+func changeEmail(s *AdminService, language string) error {
+	admin := Admin{FirstName: "Bo", LastName: "Kim", EmailID: "bo@example.com", Password: "x", Language: language, Status: true}
+	if err := s.db.Create(&admin).Error; err != nil {
+		return err
+	}
+	_, err := s.Update(admin.ID, AdminUpdate{EmailID: stringPtr("bo.kim@example.com")}, "es")
+	return err
+}
+
+func TestManagingAdminRequiresTheirPermissions(t *testing.T) {
+	// Each case acts on the target through a service whose caller holds only admin permissions
+	tests := []struct {
+		name string
+		act  func(s *AdminService, id uuid.UUID) error
+	}{
+		{"update email", func(s *AdminService, id uuid.UUID) error {
+			_, err := s.Update(id, AdminUpdate{EmailID: stringPtr("mallory@example.com")}, "en")
+			return err
+		}},
+		{"update name", func(s *AdminService, id uuid.UUID) error {
+			_, err := s.Update(id, AdminUpdate{FirstName: stringPtr("Mallory")}, "en")
+			return err
+		}},
+		{"deactivate", func(s *AdminService, id uuid.UUID) error {
+			return s.SetStatus(id, false)
+		}},
+		{"delete", func(s *AdminService, id uuid.UUID) error {
+			return s.Delete(id)
+		}},
+		{"unlock", func(s *AdminService, id uuid.UUID) error {
+			return s.UnlockAdmin(id)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(t)
+			roleService := roles.NewRoleService(service.db, service.cfg)
+			grant := func(admin Admin, name string, permissions ...string) {
+				role, err := roleService.ReadByName(name)
+				if errors.Is(err, roles.ErrRoleNotFound) {
+					role, err = roleService.Create(roles.Role{Name: name, Permissions: permissions})
+				}
+				if err != nil {
+					t.Fatalf("role %s: %v", name, err)
+				}
+				if err := roleService.GrantRole(admin.ID, role.ID); err != nil {
+					t.Fatalf("GrantRole() error = %v", err)
+				}
+			}
+
+			caller := newTestAdmin(t, service, "caller@example.com")
+			grant(caller, "admin_manager", "admins:update", "admins:delete", "admins:unlock")
+			super := newTestAdmin(t, service, "super@example.com")
+			grant(super, "owner", "*")
+			peer := newTestAdmin(t, service, "peer@example.com")
+			grant(peer, "admin_manager")
+
+			acting := service.WithCaller(caller.ID)
+			if err := tt.act(acting, super.ID); !errors.Is(err, roles.ErrPermissionEscalation) {
+				t.Fatalf("acting on a stronger admin error = %v, want ErrPermissionEscalation", err)
+			}
+			stored, err := service.Read(super.ID)
+			if err != nil || stored.EmailID != "super@example.com" || stored.FirstName != "Ann" || !stored.Status {
+				t.Errorf("stronger admin changed: %+v, %v", stored, err)
+			}
+
+			if err := tt.act(acting, peer.ID); err != nil {
+				t.Errorf("acting on an admin with the same permissions error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package admin
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/revocation"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrInvalidRefreshToken is returned when a refresh token is unknown, expired or belongs to a disabled admin
+	ErrInvalidRefreshToken = apperror.New(http.StatusUnauthorized, "INVALID_REFRESH_TOKEN", "invalid or expired refresh token")
+	// ErrRefreshTokenReused is returned when an already rotated refresh token is presented again
+	ErrRefreshTokenReused = apperror.New(http.StatusUnauthorized, "REFRESH_TOKEN_REUSED", "refresh token has already been used")
+)
+
+// TokenPair holds an access token together with its refresh token
+type TokenPair struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int64  `json:"expiresIn"` // Access token lifetime in seconds
+}
+
+// IssueTokenPair creates an access token and starts a new refresh token family for the admin
+func (s *AdminService) IssueTokenPair(adminID uuid.UUID) (TokenPair, error) {
+	refresh, rawToken, err := s.newRefreshToken(adminID, uuid.New())
+	if err != nil {
+		return TokenPair{}, err
+	}
+	if err := s.db.Create(&refresh).Error; err != nil {
+		return TokenPair{}, err
+	}
+
+	accessToken, err := s.GenerateToken(adminID)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: rawToken,
+		ExpiresIn:    int64(s.cfg.JWTExpiry.Seconds()),
+	}, nil
+}
+
+// RefreshTokens rotates a refresh token: the presented token is revoked and a new pair is
+// issued in the same family. Presenting a token that was already rotated revokes the family.
+func (s *AdminService) RefreshTokens(rawToken string) (TokenPair, error) {
+	var pair TokenPair
+	var reusedFamily uuid.UUID
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var current RefreshToken
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", hashToken(rawToken)).
+			First(&current).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidRefreshToken
+			}
+			return err
+		}
+
+		if current.RevokedAt != nil {
+			reusedFamily = current.FamilyID
+			return ErrRefreshTokenReused
+		}
+		if time.Now().After(current.ExpiresAt) {
+			return ErrInvalidRefreshToken
+		}
+
+		// The admin may have been deleted or deactivated since the token was issued
+		var count int64
+		if err := tx.Model(&Admin{}).Where("id = ? AND is_deleted = ? AND status = ?", current.AdminID, false, true).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrInvalidRefreshToken
+		}
+
+		next, nextRaw, err := s.newRefreshToken(current.AdminID, current.FamilyID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(&next).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&current).Updates(map[string]interface{}{"revoked_at": time.Now(), "replaced_by": next.ID}).Error; err != nil {
+			return err
+		}
+
+		accessToken, err := s.GenerateToken(current.AdminID)
+		if err != nil {
+			return err
+		}
+		pair = TokenPair{
+			AccessToken:  accessToken,
+			RefreshToken: nextRaw,
+			ExpiresIn:    int64(s.cfg.JWTExpiry.Seconds()),
+		}
+		return nil
+	})
+
+	// Revoke outside the transaction, which has been rolled back by the reuse error
+	if errors.Is(err, ErrRefreshTokenReused) {
+		slog.WarnContext(s.db.Statement.Context, "Refresh token reuse detected, revoking token family", "family", reusedFamily)
+		if revokeErr := s.RevokeTokenFamily(reusedFamily); revokeErr != nil {
+			slog.ErrorContext(s.db.Statement.Context, "Failed to revoke token family", "family", reusedFamily, "error", revokeErr)
+		}
+	}
+	if err != nil {
+		return TokenPair{}, err
+	}
+	return pair, nil
+}
+
+// RevokeTokenFamily revokes every active refresh token in a family
+func (s *AdminService) RevokeTokenFamily(familyID uuid.UUID) error {
+	return s.db.Model(&RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAdminTokens invalidates every access and refresh token issued to the admin
+func (s *AdminService) RevokeAdminTokens(adminID uuid.UUID) error {
+	if revocation.StoreInstance != nil {
+		if err := revocation.StoreInstance.RevokeAdmin(adminID); err != nil {
+			return err
+		}
+	}
+	return s.db.Model(&RefreshToken{}).
+		Where("admin_id = ? AND revoked_at IS NULL", adminID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// Logout revokes the access token identified by jti and, when provided, the family of the
+// refresh token so the session cannot be renewed
+func (s *AdminService) Logout(adminID uuid.UUID, jti string, expiresAt time.Time, refreshToken string) error {
+	if jti != "" && revocation.StoreInstance != nil {
+		if err := revocation.StoreInstance.RevokeToken(jti, adminID, expiresAt); err != nil {
+			return err
+		}
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+	var current RefreshToken
+	if err := s.db.Where("token_hash = ? AND admin_id = ?", hashToken(refreshToken), adminID).First(&current).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil // Nothing to revoke, the session is already gone
+		}
+		return err
+	}
+	return s.RevokeTokenFamily(current.FamilyID)
+}
+
+// newRefreshToken builds an unsaved refresh token record and returns it with the raw token value
+func (s *AdminService) newRefreshToken(adminID, familyID uuid.UUID) (RefreshToken, string, error) {
+	rawToken, err := randomToken(32)
+	if err != nil {
+		return RefreshToken{}, "", fmt.Errorf("failed to generate refresh token: %v", err)
+	}
+
+	return RefreshToken{
+		AdminID:   adminID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(rawToken),
+		ExpiresAt: time.Now().Add(s.cfg.RefreshTokenExpiry),
+	}, rawToken, nil
+}
+
+// randomToken returns a URL-safe random string built from n random bytes
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the hex encoded SHA-256 digest of a token
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
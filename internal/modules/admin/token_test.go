@@ -0,0 +1,165 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"goUniAdmin/internal/services/middleware"
+	"goUniAdmin/internal/services/revocation"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRefreshTokensRotates(t *testing.T) {
+	service, _ := newTestService(t)
+	admin := newTestAdmin(t, service, "ann@example.com")
+
+	first, err := service.IssueTokenPair(admin.ID)
+	if err != nil {
+		t.Fatalf("IssueTokenPair() error = %v", err)
+	}
+	second, err := service.RefreshTokens(first.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshTokens() error = %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken || second.AccessToken == "" {
+		t.Fatalf("RefreshTokens() = %+v, want a new pair", second)
+	}
+
+	var rotated, next RefreshToken
+	service.db.Where("token_hash = ?", hashToken(first.RefreshToken)).First(&rotated)
+	service.db.Where("token_hash = ?", hashToken(second.RefreshToken)).First(&next)
+	if rotated.RevokedAt == nil || rotated.ReplacedBy == nil || *rotated.ReplacedBy != next.ID {
+		t.Errorf("rotated token = %+v, want revoked and replaced by %s", rotated, next.ID)
+	}
+	if next.FamilyID != rotated.FamilyID || next.RevokedAt != nil {
+		t.Errorf("new token = %+v, want active in family %s", next, rotated.FamilyID)
+	}
+
+	if _, err := service.RefreshTokens(second.RefreshToken); err != nil {
+		t.Errorf("RefreshTokens() of the new token error = %v", err)
+	}
+}
+
+func TestRefreshTokensReuseRevokesFamily(t *testing.T) {
+	service, _ := newTestService(t)
+	admin := newTestAdmin(t, service, "ann@example.com")
+
+	first, _ := service.IssueTokenPair(admin.ID)
+	second, err := service.RefreshTokens(first.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshTokens() error = %v", err)
+	}
+	other, _ := service.IssueTokenPair(admin.ID)
+
+	if _, err := service.RefreshTokens(first.RefreshToken); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("RefreshTokens() of a rotated token error = %v, want ErrRefreshTokenReused", err)
+	}
+	if _, err := service.RefreshTokens(second.RefreshToken); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Errorf("RefreshTokens() of the stolen family error = %v, want ErrRefreshTokenReused", err)
+	}
+	if _, err := service.RefreshTokens(other.RefreshToken); err != nil {
+		t.Errorf("RefreshTokens() of another session error = %v", err)
+	}
+}
+
+func TestRefreshTokensRejects(t *testing.T) {
+	// Each case prepares a session and returns the refresh token to present
+	tests := []struct {
+		name    string
+		prepare func(s *AdminService, a Admin, raw string) string
+	}{
+		{"unknown token", func(*AdminService, Admin, string) string {
+			return "not-a-token"
+		}},
+		{"expired token", func(s *AdminService, _ Admin, raw string) string {
+			s.db.Model(&RefreshToken{}).Where("token_hash = ?", hashToken(raw)).Update("expires_at", time.Now().Add(-time.Minute))
+			return raw
+		}},
+		{"inactive admin", func(s *AdminService, a Admin, raw string) string {
+			s.db.Model(&Admin{}).Where("id = ?", a.ID).Update("status", false)
+			return raw
+		}},
+		{"deleted admin", func(s *AdminService, a Admin, raw string) string {
+			s.db.Model(&Admin{}).Where("id = ?", a.ID).Update("is_deleted", true)
+			return raw
+		}},
+		{"revoked sessions", func(s *AdminService, a Admin, raw string) string {
+			s.RevokeAdminTokens(a.ID)
+			return raw
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(t)
+			admin := newTestAdmin(t, service, "ann@example.com")
+			pair, err := service.IssueTokenPair(admin.ID)
+			if err != nil {
+				t.Fatalf("IssueTokenPair() error = %v", err)
+			}
+
+			if _, err := service.RefreshTokens(tt.prepare(service, admin, pair.RefreshToken)); err == nil {
+				t.Error("RefreshTokens() error = nil, want a rejection")
+			}
+		})
+	}
+}
+
+func TestLogoutRevokesFamily(t *testing.T) {
+	service, _ := newTestService(t)
+	admin := newTestAdmin(t, service, "ann@example.com")
+	pair, _ := service.IssueTokenPair(admin.ID)
+
+	if err := service.Logout(admin.ID, "", time.Time{}, pair.RefreshToken); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if _, err := service.RefreshTokens(pair.RefreshToken); err == nil {
+		t.Error("RefreshTokens() after Logout() error = nil")
+	}
+	if err := service.Logout(admin.ID, "", time.Time{}, "unknown"); err != nil {
+		t.Errorf("Logout() with an unknown refresh token error = %v", err)
+	}
+}
+
+func TestAccessTokenAfterRevocation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service, _ := newTestService(t)
+	admin := newTestAdmin(t, service, "ann@example.com")
+	if err := service.db.AutoMigrate(&revocation.RevokedToken{}, &revocation.AdminRevocation{}); err != nil {
+		t.Fatal(err)
+	}
+	previous := revocation.StoreInstance
+	if _, err := revocation.NewStore(service.db); err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	defer func() { revocation.StoreInstance = previous }()
+
+	router := gin.New()
+	router.GET("/profile", middleware.AuthMiddleware(service.cfg), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	status := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/profile", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	old, _ := service.IssueTokenPair(admin.ID)
+	if err := service.RevokeAdminTokens(admin.ID); err != nil {
+		t.Fatalf("RevokeAdminTokens() error = %v", err)
+	}
+	// Logging in again within the same second must give a working token
+	fresh, _ := service.IssueTokenPair(admin.ID)
+
+	if got := status(old.AccessToken); got != http.StatusUnauthorized {
+		t.Errorf("status with a token issued before the revocation = %d, want 401", got)
+	}
+	if got := status(fresh.AccessToken); got != http.StatusOK {
+		t.Errorf("status with a token issued after the revocation = %d, want 200", got)
+	}
+}
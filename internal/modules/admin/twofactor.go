@@ -0,0 +1,369 @@
+package admin
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/middleware"
+	"goUniAdmin/internal/services/revocation"
+	"goUniAdmin/internal/services/totp"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	// challengeTokenExpiry is how long a login challenge can be exchanged for a token pair
+	challengeTokenExpiry = 5 * time.Minute
+	// recoveryCodeCount is the number of recovery codes issued at a time
+	recoveryCodeCount = 10
+)
+
+var (
+	// ErrTwoFactorAlreadyEnabled is returned when setting up 2FA on an admin that already has it
+	ErrTwoFactorAlreadyEnabled = apperror.New(http.StatusConflict, "TWO_FACTOR_ALREADY_ENABLED", "two-factor authentication is already enabled")
+	// ErrTwoFactorNotEnabled is returned when a 2FA operation requires it to be enabled
+	ErrTwoFactorNotEnabled = apperror.New(http.StatusConflict, "TWO_FACTOR_NOT_ENABLED", "two-factor authentication is not enabled")
+	// ErrTwoFactorNotSetUp is returned when confirming 2FA before calling setup
+	ErrTwoFactorNotSetUp = apperror.New(http.StatusConflict, "TWO_FACTOR_NOT_SET_UP", "two-factor authentication has not been set up")
+	// ErrInvalidTwoFactorCode is returned for a wrong, reused or expired TOTP or recovery code
+	ErrInvalidTwoFactorCode = apperror.New(http.StatusUnauthorized, "INVALID_TWO_FACTOR_CODE", "invalid two-factor code")
+	// ErrInvalidChallengeToken is returned when a login challenge token is invalid or expired
+	ErrInvalidChallengeToken = apperror.New(http.StatusUnauthorized, "INVALID_CHALLENGE_TOKEN", "invalid or expired challenge token")
+)
+
+// TwoFactorSetup is returned when enrolling an authenticator app
+type TwoFactorSetup struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauthUri"`
+}
+
+// LoginChallenge is returned by login instead of tokens when 2FA is enabled
+type LoginChallenge struct {
+	ChallengeToken string `json:"challengeToken"`
+	ExpiresIn      int64  `json:"expiresIn"` // Challenge lifetime in seconds
+}
+
+// SetupTwoFactor generates a new TOTP secret for the admin. It stays pending until confirmed.
+func (s *AdminService) SetupTwoFactor(adminID uuid.UUID) (TwoFactorSetup, error) {
+	admin, err := s.Read(adminID)
+	if err != nil {
+		return TwoFactorSetup{}, err
+	}
+	if admin.TwoFactorEnabled {
+		return TwoFactorSetup{}, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return TwoFactorSetup{}, fmt.Errorf("failed to generate secret: %v", err)
+	}
+	if err := s.db.Model(&Admin{}).Where("id = ?", adminID).Update("two_factor_secret", secret).Error; err != nil {
+		return TwoFactorSetup{}, err
+	}
+
+	return TwoFactorSetup{
+		Secret:     secret,
+		OtpauthURI: totp.URI(s.cfg.AppName, admin.EmailID, secret),
+	}, nil
+}
+
+// ConfirmTwoFactor enables 2FA once the admin proves the authenticator works, and returns
+// the recovery codes. The codes are only shown this once. Codes are throttled like those of
+// VerifyTwoFactorLogin: it returns how long the admin must wait while delayed or locked out.
+func (s *AdminService) ConfirmTwoFactor(adminID uuid.UUID, code string) ([]string, time.Duration, error) {
+	admin, err := s.Read(adminID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if admin.TwoFactorEnabled {
+		return nil, 0, ErrTwoFactorAlreadyEnabled
+	}
+	if admin.TwoFactorSecret == "" {
+		return nil, 0, ErrTwoFactorNotSetUp
+	}
+
+	var step int64
+	wait, err := s.throttleSecondFactor(adminID, func() error {
+		var ok bool
+		if step, ok = totp.Validate(admin.TwoFactorSecret, code, time.Now()); !ok {
+			return ErrInvalidTwoFactorCode
+		}
+		return nil
+	})
+	if err != nil || wait > 0 {
+		return nil, wait, err
+	}
+
+	var codes []string
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Admin{}).Where("id = ?", adminID).Updates(map[string]interface{}{
+			"two_factor_enabled":   true,
+			"two_factor_last_step": step,
+		}).Error; err != nil {
+			return err
+		}
+		codes, err = replaceRecoveryCodes(tx, adminID)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return codes, 0, nil
+}
+
+// DisableTwoFactor turns 2FA off after checking the password and a TOTP or recovery code. The
+// checks are throttled like codes in VerifyTwoFactorLogin: it returns how long the admin must
+// wait while delayed or locked out.
+func (s *AdminService) DisableTwoFactor(adminID uuid.UUID, password, code string) (time.Duration, error) {
+	admin, err := s.Read(adminID)
+	if err != nil {
+		return 0, err
+	}
+	if !admin.TwoFactorEnabled {
+		return 0, ErrTwoFactorNotEnabled
+	}
+	wait, err := s.throttleSecondFactor(adminID, func() error {
+		if err := bcrypt.CompareHashAndPassword([]byte(admin.Password), []byte(password)); err != nil {
+			return ErrIncorrectPassword
+		}
+		return s.verifySecondFactor(admin, code)
+	})
+	if err != nil || wait > 0 {
+		return wait, err
+	}
+
+	return 0, s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Admin{}).Where("id = ?", adminID).Updates(map[string]interface{}{
+			"two_factor_enabled":   false,
+			"two_factor_secret":    "",
+			"two_factor_last_step": 0,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Where("admin_id = ?", adminID).Delete(&RecoveryCode{}).Error
+	})
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes after checking a TOTP code. Codes are
+// throttled like those of VerifyTwoFactorLogin: it returns how long the admin must wait while
+// delayed or locked out.
+func (s *AdminService) RegenerateRecoveryCodes(adminID uuid.UUID, code string) ([]string, time.Duration, error) {
+	admin, err := s.Read(adminID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !admin.TwoFactorEnabled {
+		return nil, 0, ErrTwoFactorNotEnabled
+	}
+	wait, err := s.throttleSecondFactor(adminID, func() error {
+		return s.verifyTOTP(admin, code)
+	})
+	if err != nil || wait > 0 {
+		return nil, wait, err
+	}
+
+	var codes []string
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		codes, err = replaceRecoveryCodes(tx, adminID)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return codes, 0, nil
+}
+
+// throttleSecondFactor runs a password or code check under the per-admin limit shared with
+// VerifyTwoFactorLogin, so a stolen session cannot be used to guess them. The attempt is
+// counted before the check and the failures are forgotten once it passes. It returns how long
+// the admin must wait while delayed or locked out, in which case check is not run.
+func (s *AdminService) throttleSecondFactor(adminID uuid.UUID, check func() error) (time.Duration, error) {
+	attemptKey := twoFactorAttemptKey(adminID)
+	wait, err := s.reserveAttempt(attemptKey, s.cfg.LoginMaxAttempts)
+	if err != nil || wait > 0 {
+		return wait, err
+	}
+	if err := check(); err != nil {
+		return 0, err
+	}
+	return 0, s.db.Where("key = ?", attemptKey).Delete(&LoginAttempt{}).Error
+}
+
+// GenerateChallengeToken issues the short-lived token returned by login when 2FA is enabled.
+// AuthMiddleware rejects it because its type is not an access token.
+func (s *AdminService) GenerateChallengeToken(adminID uuid.UUID) (LoginChallenge, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"id":  adminID.String(),
+		"jti": uuid.NewString(),
+		"typ": middleware.TokenTypeChallenge,
+		"exp": now.Add(challengeTokenExpiry).Unix(),
+		"iat": middleware.IssuedAtClaim(now),
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.cfg.JWTSecret))
+	if err != nil {
+		return LoginChallenge{}, fmt.Errorf("failed to sign token: %v", err)
+	}
+	return LoginChallenge{ChallengeToken: signed, ExpiresIn: int64(challengeTokenExpiry.Seconds())}, nil
+}
+
+// VerifyTwoFactorLogin exchanges a login challenge and a TOTP or recovery code for a token pair.
+// The challenge token is revoked so it cannot be used again. Codes are throttled per admin under
+// the login delays and limits: it returns how long the caller must wait while the admin is
+// delayed or locked out, and the challenge is revoked once the failures lock the admin out.
+func (s *AdminService) VerifyTwoFactorLogin(challengeToken, code string) (Admin, TokenPair, time.Duration, error) {
+	token, err := jwt.Parse(challengeToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(s.cfg.JWTSecret), nil
+	})
+	if err != nil || !token.Valid {
+		return Admin{}, TokenPair{}, 0, ErrInvalidChallengeToken
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["typ"] != middleware.TokenTypeChallenge {
+		return Admin{}, TokenPair{}, 0, ErrInvalidChallengeToken
+	}
+	adminIDStr, _ := claims["id"].(string)
+	adminID, err := uuid.Parse(adminIDStr)
+	if err != nil {
+		return Admin{}, TokenPair{}, 0, ErrInvalidChallengeToken
+	}
+	jti, _ := claims["jti"].(string)
+	issuedAt := middleware.IssuedAt(claims)
+	var expiresAt time.Time
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		expiresAt = exp.Time
+	}
+	if revocation.StoreInstance != nil && revocation.StoreInstance.IsRevoked(jti, adminID, issuedAt) {
+		return Admin{}, TokenPair{}, 0, ErrInvalidChallengeToken
+	}
+
+	admin, err := s.Read(adminID)
+	if err != nil || !admin.Status {
+		return Admin{}, TokenPair{}, 0, ErrInvalidChallengeToken
+	}
+	if !admin.TwoFactorEnabled {
+		return Admin{}, TokenPair{}, 0, ErrTwoFactorNotEnabled
+	}
+
+	// The attempt is counted before the code is checked, so parallel guesses cannot outrun the limit
+	attemptKey := twoFactorAttemptKey(adminID)
+	wait, err := s.reserveAttempt(attemptKey, s.cfg.LoginMaxAttempts)
+	if err != nil || wait > 0 {
+		return Admin{}, TokenPair{}, wait, err
+	}
+	if err := s.verifySecondFactor(admin, code); err != nil {
+		if locked, lockErr := s.attemptLocked(attemptKey); lockErr == nil && locked {
+			if revokeErr := s.revokeChallenge(jti, adminID, expiresAt); revokeErr != nil {
+				return Admin{}, TokenPair{}, 0, revokeErr
+			}
+		}
+		return Admin{}, TokenPair{}, 0, err
+	}
+	if err := s.db.Where("key = ?", attemptKey).Delete(&LoginAttempt{}).Error; err != nil {
+		return Admin{}, TokenPair{}, 0, err
+	}
+
+	if err := s.revokeChallenge(jti, adminID, expiresAt); err != nil {
+		return Admin{}, TokenPair{}, 0, err
+	}
+
+	tokens, err := s.IssueTokenPair(adminID)
+	if err != nil {
+		return Admin{}, TokenPair{}, 0, err
+	}
+	return admin, tokens, 0, nil
+}
+
+// revokeChallenge revokes a login challenge token so it cannot be exchanged again
+func (s *AdminService) revokeChallenge(jti string, adminID uuid.UUID, expiresAt time.Time) error {
+	if revocation.StoreInstance == nil || jti == "" {
+		return nil
+	}
+	return revocation.StoreInstance.RevokeToken(jti, adminID, expiresAt)
+}
+
+// verifySecondFactor accepts either a TOTP code or an unused recovery code
+func (s *AdminService) verifySecondFactor(admin Admin, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		return s.verifyTOTP(admin, code)
+	}
+	return s.useRecoveryCode(admin.ID, code)
+}
+
+// verifyTOTP checks a TOTP code and records its time step so it cannot be replayed
+func (s *AdminService) verifyTOTP(admin Admin, code string) error {
+	step, ok := totp.Validate(admin.TwoFactorSecret, code, time.Now())
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	// Conditional update so two concurrent requests cannot both use the same code
+	result := s.db.Model(&Admin{}).
+		Where("id = ? AND two_factor_last_step < ?", admin.ID, step).
+		Update("two_factor_last_step", step)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// useRecoveryCode marks a matching unused recovery code as used
+func (s *AdminService) useRecoveryCode(adminID uuid.UUID, code string) error {
+	normalized := normalizeRecoveryCode(code)
+	if normalized == "" {
+		return ErrInvalidTwoFactorCode
+	}
+
+	result := s.db.Model(&RecoveryCode{}).
+		Where("admin_id = ? AND code_hash = ? AND used_at IS NULL", adminID, hashToken(normalized)).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// replaceRecoveryCodes deletes the admin's recovery codes and stores a fresh hashed set
+func replaceRecoveryCodes(tx *gorm.DB, adminID uuid.UUID) ([]string, error) {
+	if err := tx.Where("admin_id = ?", adminID).Delete(&RecoveryCode{}).Error; err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		raw := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))
+		code := raw[:4] + "-" + raw[4:]
+		if err := tx.Create(&RecoveryCode{AdminID: adminID, CodeHash: hashToken(normalizeRecoveryCode(code))}).Error; err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// normalizeRecoveryCode lowercases the code and strips separators and whitespace
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+}
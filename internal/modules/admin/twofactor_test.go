@@ -0,0 +1,123 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"goUniAdmin/internal/services/revocation"
+	"goUniAdmin/internal/services/totp"
+)
+
+// newTwoFactorAdmin stores an admin with 2FA enabled and returns it with its TOTP secret
+func newTwoFactorAdmin(t *testing.T, service *AdminService, emailID string) (Admin, string) {
+	t.Helper()
+	admin := newTestAdmin(t, service, emailID)
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = service.db.Model(&admin).Updates(map[string]interface{}{"two_factor_enabled": true, "two_factor_secret": secret}).Error
+	if err != nil {
+		t.Fatalf("enable 2FA: %v", err)
+	}
+	admin.TwoFactorEnabled, admin.TwoFactorSecret = true, secret
+	return admin, secret
+}
+
+func TestChallengeAfterRevocation(t *testing.T) {
+	service, _ := newTestService(t)
+	admin, secret := newTwoFactorAdmin(t, service, "ann@example.com")
+	if err := service.db.AutoMigrate(&revocation.RevokedToken{}, &revocation.AdminRevocation{}); err != nil {
+		t.Fatal(err)
+	}
+	previous := revocation.StoreInstance
+	if _, err := revocation.NewStore(service.db); err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	defer func() { revocation.StoreInstance = previous }()
+
+	old, _ := service.GenerateChallengeToken(admin.ID)
+	if err := service.RevokeAdminTokens(admin.ID); err != nil {
+		t.Fatalf("RevokeAdminTokens() error = %v", err)
+	}
+	// Logging in again within the same second, e.g. right after a password reset, must work
+	fresh, _ := service.GenerateChallengeToken(admin.ID)
+
+	code, _ := totp.Code(secret, time.Now())
+	if _, _, _, err := service.VerifyTwoFactorLogin(old.ChallengeToken, code); !errors.Is(err, ErrInvalidChallengeToken) {
+		t.Errorf("VerifyTwoFactorLogin() with a challenge issued before the revocation error = %v, want ErrInvalidChallengeToken", err)
+	}
+	if _, tokens, _, err := service.VerifyTwoFactorLogin(fresh.ChallengeToken, code); err != nil || tokens.AccessToken == "" {
+		t.Errorf("VerifyTwoFactorLogin() with a challenge issued after the revocation = %+v, %v", tokens, err)
+	}
+}
+
+func TestTwoFactorChecksAreThrottled(t *testing.T) {
+	// Each case runs the operation with the right password and code or with wrong ones
+	tests := []struct {
+		name    string
+		enabled bool
+		act     func(s *AdminService, a Admin, secret string, valid bool) (time.Duration, error)
+	}{
+		{"confirm", false, func(s *AdminService, a Admin, secret string, valid bool) (time.Duration, error) {
+			_, wait, err := s.ConfirmTwoFactor(a.ID, codeFor(secret, valid))
+			return wait, err
+		}},
+		{"disable with a wrong code", true, func(s *AdminService, a Admin, secret string, valid bool) (time.Duration, error) {
+			return s.DisableTwoFactor(a.ID, testPassword, codeFor(secret, valid))
+		}},
+		{"disable with a wrong password", true, func(s *AdminService, a Admin, secret string, valid bool) (time.Duration, error) {
+			password := testPassword
+			if !valid {
+				password = "wrong-Passw0rd!"
+			}
+			return s.DisableTwoFactor(a.ID, password, codeFor(secret, true))
+		}},
+		{"regenerate recovery codes", true, func(s *AdminService, a Admin, secret string, valid bool) (time.Duration, error) {
+			_, wait, err := s.RegenerateRecoveryCodes(a.ID, codeFor(secret, valid))
+			return wait, err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(t)
+			admin, secret := newTwoFactorAdmin(t, service, "ann@example.com")
+			if !tt.enabled {
+				service.db.Model(&admin).Update("two_factor_enabled", false)
+			}
+
+			for i := 0; i < service.cfg.LoginMaxAttempts; i++ {
+				if wait, err := tt.act(service, admin, secret, false); wait > 0 || err == nil {
+					t.Fatalf("attempt %d = %v, %v, want a rejected check", i+1, wait, err)
+				}
+			}
+			if wait, err := tt.act(service, admin, secret, true); wait <= 0 {
+				t.Fatalf("valid attempt after the limit = %v, %v, want a wait", wait, err)
+			}
+			stored, _ := service.Read(admin.ID)
+			if stored.TwoFactorEnabled != tt.enabled {
+				t.Errorf("TwoFactorEnabled = %v after a throttled attempt, want unchanged", stored.TwoFactorEnabled)
+			}
+
+			if err := service.UnlockAdmin(admin.ID); err != nil {
+				t.Fatalf("UnlockAdmin() error = %v", err)
+			}
+			if wait, err := tt.act(service, admin, secret, true); wait > 0 || err != nil {
+				t.Errorf("valid attempt after unlocking = %v, %v", wait, err)
+			}
+		})
+	}
+}
+
+// codeFor returns the current TOTP code of secret, or a wrong code
+func codeFor(secret string, valid bool) string {
+	code, _ := totp.Code(secret, time.Now())
+	if !valid {
+		n, _ := strconv.Atoi(code)
+		return fmt.Sprintf("%06d", (n+1)%1000000)
+	}
+	return code
+}
@@ -1,33 +1,102 @@
 package admin
 
 import (
-	"errors"
-	"regexp"
+	"strconv"
+
+	"goUniAdmin/internal/modules/mastermanagement"
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/validators"
 )
 
-// ValidateAdmin validates the admin data
+// ValidateAdmin validates the admin data against the rules in its validate tags, and the
+// password against the password rules
 func ValidateAdmin(admin Admin) error {
-	if admin.FirstName == "" {
-		return errors.New("firstName is required")
+	errs := validators.Struct(admin)
+	errs = append(errs, passwordErrors(admin.Password)...)
+	return errs.Err()
+}
+
+// ValidateAdminUpdate validates the fields set in an update of another admin. Optional fields
+// that may be cleared are checked only when not empty.
+func ValidateAdminUpdate(update AdminUpdate) error {
+	errs := validators.Struct(update)
+	optional := []struct {
+		field string
+		value *string
+		tag   string
+	}{
+		{"mobile", update.Mobile, "omitempty,phone"},
+		{"gender", update.Gender, "omitempty,oneof=male female other"},
+		{"website", update.Website, "omitempty,website"},
+		{"countryCode", update.CountryCode, "omitempty,country"},
+		{"timeZone", update.TimeZone, "omitempty,timezone"},
 	}
-	if admin.LastName == "" {
-		return errors.New("lastName is required")
+	for _, field := range optional {
+		if field.value != nil {
+			errs = append(errs, validators.Var(field.field, *field.value, field.tag)...)
+		}
+	}
+	return errs.Err()
+}
+
+// ValidateMasterFields checks the country, currency and time zone of an admin against the
+// matching master data lists. Empty fields are not checked. A value outside its list is a
+// validation error; a failed lookup is returned as is.
+func ValidateMasterFields(admin Admin) error {
+	if mastermanagement.ServiceInstance == nil {
+		return nil
 	}
-	if admin.EmailID == "" {
-		return errors.New("emailId is required")
+	fields := []struct {
+		name, masterType, value string
+	}{
+		{"countryCode", mastermanagement.TypeCountry, admin.CountryCode},
+		{"currency", mastermanagement.TypeCurrency, admin.Currency},
+		{"timeZone", mastermanagement.TypeTimeZone, admin.TimeZone},
 	}
-	if !isValidEmail(admin.EmailID) {
-		return errors.New("invalid email format")
+	var errs validators.Errors
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		ok, err := mastermanagement.ServiceInstance.IsAllowed(field.masterType, field.value)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			errs.Add(field.name, "master", field.masterType)
+		}
 	}
-	if admin.Password == "" {
-		return errors.New("password is required")
+	if len(errs) > 0 {
+		return apperror.Validation(errs)
 	}
-	// Add more validation as needed (e.g., mobile format, gender values)
 	return nil
 }
 
-// isValidEmail checks if the email format is valid
-func isValidEmail(email string) bool {
-	re := regexp.MustCompile(`^[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}$`)
-	return re.MatchString(email)
+// ValidateInvitation validates the data for inviting an admin
+func ValidateInvitation(emailID, firstName, lastName string) error {
+	invitation := struct {
+		FirstName string `json:"firstName" validate:"required"`
+		LastName  string `json:"lastName" validate:"required"`
+		EmailID   string `json:"emailId" validate:"required,email"`
+	}{firstName, lastName, emailID}
+	return validators.Struct(invitation).Err()
+}
+
+// minPasswordLength is the minimum number of characters accepted for a new password
+const minPasswordLength = 8
+
+// ValidatePassword validates a new password chosen by an admin
+func ValidatePassword(password string) error {
+	return passwordErrors(password).Err()
+}
+
+// passwordErrors returns the password rules that password breaks
+func passwordErrors(password string) validators.Errors {
+	var errs validators.Errors
+	if password == "" {
+		errs.Add("password", "required", "")
+	} else if len(password) < minPasswordLength {
+		errs.Add("password", "min", strconv.Itoa(minPasswordLength))
+	}
+	return errs
 }
@@ -0,0 +1,324 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// maxSnapshotRows caps the rows read before an update or delete. Larger bulk writes are
+// audited for the first maxSnapshotRows rows only.
+const maxSnapshotRows = 500
+
+// beforeKey stores the rows read before an update or delete on the statement
+const beforeKey = "audit:before"
+
+// redacted replaces the value of secret columns
+const redacted = "[REDACTED]"
+
+// ignoredTables are high-volume bookkeeping tables whose writes are not audited
+var ignoredTables = map[string]bool{
+	"audit_logs":        true,
+	"schema_migrations": true,
+	"login_attempts":    true,
+	"refresh_tokens":    true,
+	"revoked_tokens":    true,
+	"admin_revocations": true,
+}
+
+// ignoredColumns never appear in update diffs, and an update changing nothing else is not audited
+var ignoredColumns = map[string]bool{
+	"updated_at": true,
+}
+
+// secretColumns name the columns whose values are redacted, either exactly or as a suffix
+// such as forgot_token or code_hash
+var secretColumns = []string{"password", "token", "secret", "hash"}
+
+// IgnoreTables excludes more tables from auditing. Call it during startup.
+func IgnoreTables(tables ...string) {
+	for _, table := range tables {
+		ignoredTables[table] = true
+	}
+}
+
+// RegisterCallbacks audits every create, update and delete made through db, including those
+// of modules added later. Entries are written in the same transaction as the change.
+func RegisterCallbacks(db *gorm.DB) error {
+	callback := db.Callback()
+	if err := callback.Create().After("gorm:after_create").Before("gorm:commit_or_rollback_transaction").
+		Register("audit:after_create", afterCreate); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register("audit:before_update", snapshotBefore); err != nil {
+		return err
+	}
+	if err := callback.Update().After("gorm:after_update").Before("gorm:commit_or_rollback_transaction").
+		Register("audit:after_update", afterUpdate); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("gorm:delete").Register("audit:before_delete", snapshotBefore); err != nil {
+		return err
+	}
+	return callback.Delete().After("gorm:after_delete").Before("gorm:commit_or_rollback_transaction").
+		Register("audit:after_delete", afterDelete)
+}
+
+// auditable reports whether the statement writes a model table that is audited
+func auditable(db *gorm.DB) bool {
+	stmt := db.Statement
+	return db.Error == nil && stmt.Schema != nil && len(stmt.Schema.PrimaryFields) > 0 && !ignoredTables[stmt.Table]
+}
+
+// afterCreate records the inserted rows
+func afterCreate(db *gorm.DB) {
+	if !auditable(db) || db.Statement.RowsAffected == 0 {
+		return
+	}
+	stmt := db.Statement
+	var entries []AuditLog
+	for _, row := range rowMaps(stmt.Context, stmt.Schema, stmt.ReflectValue) {
+		entries = append(entries, newEntry(stmt, ActionCreate, entityID(stmt.Schema, row), nil, row))
+	}
+	writeEntries(db, entries)
+}
+
+// snapshotBefore reads the rows an update or delete is about to change
+func snapshotBefore(db *gorm.DB) {
+	if !auditable(db) {
+		return
+	}
+	target, ok := targetQuery(db)
+	if !ok {
+		return // Without conditions GORM rejects the write anyway
+	}
+	rows, err := loadRows(target, db.Statement)
+	if err != nil {
+		db.AddError(fmt.Errorf("failed to read rows for audit log: %v", err))
+		return
+	}
+	db.InstanceSet(beforeKey, rows)
+}
+
+// afterUpdate records the changed columns of every updated row
+func afterUpdate(db *gorm.DB) {
+	before := snapshot(db)
+	if len(before) == 0 {
+		return
+	}
+	stmt := db.Statement
+
+	// Re-read by primary key, since the update may have changed the columns its WHERE matched
+	target := db.Session(&gorm.Session{NewDB: true}).Table(stmt.Table).Where(primaryKeyCondition(stmt.Schema, before))
+	after, err := loadRows(target, stmt)
+	if err != nil {
+		db.AddError(fmt.Errorf("failed to read rows for audit log: %v", err))
+		return
+	}
+	afterByID := make(map[string]map[string]interface{}, len(after))
+	for _, row := range after {
+		afterByID[entityID(stmt.Schema, row)] = row
+	}
+
+	var entries []AuditLog
+	for _, old := range before {
+		id := entityID(stmt.Schema, old)
+		current, ok := afterByID[id]
+		if !ok {
+			continue
+		}
+		changedBefore, changedAfter := diff(old, current)
+		if len(changedAfter) == 0 {
+			continue
+		}
+		entries = append(entries, newEntry(stmt, ActionUpdate, id, changedBefore, changedAfter))
+	}
+	writeEntries(db, entries)
+}
+
+// afterDelete records the deleted rows
+func afterDelete(db *gorm.DB) {
+	before := snapshot(db)
+	if len(before) == 0 {
+		return
+	}
+	stmt := db.Statement
+	entries := make([]AuditLog, 0, len(before))
+	for _, row := range before {
+		entries = append(entries, newEntry(stmt, ActionDelete, entityID(stmt.Schema, row), row, nil))
+	}
+	writeEntries(db, entries)
+}
+
+// snapshot returns the rows read by snapshotBefore if the write succeeded and changed rows
+func snapshot(db *gorm.DB) []map[string]interface{} {
+	if !auditable(db) || db.Statement.RowsAffected == 0 {
+		return nil
+	}
+	value, ok := db.InstanceGet(beforeKey)
+	if !ok {
+		return nil
+	}
+	rows, _ := value.([]map[string]interface{})
+	return rows
+}
+
+// targetQuery builds a query for the rows matched by an update or delete: its WHERE clause
+// plus the primary key of the model it was called on, if set. It reports false when the
+// write has no conditions.
+func targetQuery(db *gorm.DB) (*gorm.DB, bool) {
+	stmt := db.Statement
+	target := db.Session(&gorm.Session{NewDB: true}).Table(stmt.Table)
+	scoped := false
+
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+			target = target.Clauses(where)
+			scoped = true
+		}
+	}
+
+	if rv := reflect.Indirect(stmt.ReflectValue); rv.Kind() == reflect.Struct {
+		for _, field := range stmt.Schema.PrimaryFields {
+			if value, zero := field.ValueOf(stmt.Context, rv); !zero {
+				target = target.Where(clause.Eq{Column: clause.Column{Name: field.DBName}, Value: value})
+				scoped = true
+			}
+		}
+	}
+	return target, scoped
+}
+
+// loadRows runs the query into the statement's model type and returns the rows as column maps
+func loadRows(target *gorm.DB, stmt *gorm.Statement) ([]map[string]interface{}, error) {
+	dest := reflect.New(reflect.SliceOf(stmt.Schema.ModelType))
+	if err := target.Limit(maxSnapshotRows).Find(dest.Interface()).Error; err != nil {
+		return nil, err
+	}
+	return rowMaps(stmt.Context, stmt.Schema, dest), nil
+}
+
+// rowMaps converts a model, or a slice or array of models, into column maps
+func rowMaps(ctx context.Context, sch *schema.Schema, rv reflect.Value) []map[string]interface{} {
+	rv = reflect.Indirect(rv)
+	switch rv.Kind() {
+	case reflect.Struct:
+		return []map[string]interface{}{rowMap(ctx, sch, rv)}
+	case reflect.Slice, reflect.Array:
+		rows := make([]map[string]interface{}, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			if elem := reflect.Indirect(rv.Index(i)); elem.Kind() == reflect.Struct {
+				rows = append(rows, rowMap(ctx, sch, elem))
+			}
+		}
+		return rows
+	}
+	return nil
+}
+
+// rowMap converts a model into a map of column name to value
+func rowMap(ctx context.Context, sch *schema.Schema, rv reflect.Value) map[string]interface{} {
+	row := make(map[string]interface{}, len(sch.DBNames))
+	for _, name := range sch.DBNames {
+		field := sch.FieldsByDBName[name]
+		if field.Serializer != nil {
+			// ValueOf wraps serialized fields for the driver; keep the Go value instead
+			row[name] = field.ReflectValueOf(ctx, rv).Interface()
+			continue
+		}
+		value, _ := field.ValueOf(ctx, rv)
+		row[name] = value
+	}
+	return row
+}
+
+// entityID formats the primary key of a row, joining composite keys with commas
+func entityID(sch *schema.Schema, row map[string]interface{}) string {
+	parts := make([]string, len(sch.PrimaryFields))
+	for i, field := range sch.PrimaryFields {
+		parts[i] = fmt.Sprint(row[field.DBName])
+	}
+	return strings.Join(parts, ",")
+}
+
+// primaryKeyCondition matches the given rows by primary key
+func primaryKeyCondition(sch *schema.Schema, rows []map[string]interface{}) clause.Expression {
+	conditions := make([]clause.Expression, 0, len(rows))
+	for _, row := range rows {
+		keys := make([]clause.Expression, 0, len(sch.PrimaryFields))
+		for _, field := range sch.PrimaryFields {
+			keys = append(keys, clause.Eq{Column: clause.Column{Name: field.DBName}, Value: row[field.DBName]})
+		}
+		conditions = append(conditions, clause.And(keys...))
+	}
+	return clause.Or(conditions...)
+}
+
+// diff returns the old and new values of the columns that changed
+func diff(before, after map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	changedBefore := map[string]interface{}{}
+	changedAfter := map[string]interface{}{}
+	for column, value := range after {
+		if ignoredColumns[column] {
+			continue
+		}
+		old, _ := json.Marshal(before[column])
+		current, _ := json.Marshal(value)
+		if !bytes.Equal(old, current) {
+			changedBefore[column] = before[column]
+			changedAfter[column] = value
+		}
+	}
+	return changedBefore, changedAfter
+}
+
+// redact copies a row with the values of secret columns replaced
+func redact(row map[string]interface{}) map[string]interface{} {
+	if row == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(row))
+	for column, value := range row {
+		out[column] = value
+		for _, secret := range secretColumns {
+			if column == secret || strings.HasSuffix(column, "_"+secret) {
+				out[column] = redacted
+				break
+			}
+		}
+	}
+	return out
+}
+
+// newEntry builds an audit entry attributed to the source stored in the statement's context
+func newEntry(stmt *gorm.Statement, action, id string, before, after map[string]interface{}) AuditLog {
+	source := SourceFrom(stmt.Context)
+	return AuditLog{
+		ActorID:   source.ActorID,
+		Action:    action,
+		Entity:    stmt.Table,
+		EntityID:  id,
+		Before:    redact(before),
+		After:     redact(after),
+		IPAddress: source.IPAddress,
+		UserAgent: source.UserAgent,
+	}
+}
+
+// writeEntries inserts audit entries on the statement's connection, so they commit or roll
+// back with the change. A failure fails the change.
+func writeEntries(db *gorm.DB, entries []AuditLog) {
+	if len(entries) == 0 {
+		return
+	}
+	if err := db.Session(&gorm.Session{NewDB: true}).Create(&entries).Error; err != nil {
+		db.AddError(fmt.Errorf("failed to write audit log: %v", err))
+	}
+}
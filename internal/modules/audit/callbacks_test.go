@@ -0,0 +1,85 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		before     map[string]interface{}
+		after      map[string]interface{}
+		wantBefore map[string]interface{}
+		wantAfter  map[string]interface{}
+	}{
+		{
+			name:       "changed column",
+			before:     map[string]interface{}{"name": "old", "status": "active"},
+			after:      map[string]interface{}{"name": "new", "status": "active"},
+			wantBefore: map[string]interface{}{"name": "old"},
+			wantAfter:  map[string]interface{}{"name": "new"},
+		},
+		{
+			name:       "updated_at alone is ignored",
+			before:     map[string]interface{}{"name": "same", "updated_at": at},
+			after:      map[string]interface{}{"name": "same", "updated_at": at.Add(time.Hour)},
+			wantBefore: map[string]interface{}{},
+			wantAfter:  map[string]interface{}{},
+		},
+		{
+			name:       "new column",
+			before:     map[string]interface{}{},
+			after:      map[string]interface{}{"deleted_at": at},
+			wantBefore: map[string]interface{}{"deleted_at": nil},
+			wantAfter:  map[string]interface{}{"deleted_at": at},
+		},
+		{
+			name:       "values compared by their JSON form",
+			before:     map[string]interface{}{"count": int64(3), "tags": []string{"a"}},
+			after:      map[string]interface{}{"count": 3, "tags": []interface{}{"a"}},
+			wantBefore: map[string]interface{}{},
+			wantAfter:  map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotBefore, gotAfter := diff(tt.before, tt.after)
+			if !reflect.DeepEqual(gotBefore, tt.wantBefore) || !reflect.DeepEqual(gotAfter, tt.wantAfter) {
+				t.Errorf("diff() = %v, %v, want %v, %v", gotBefore, gotAfter, tt.wantBefore, tt.wantAfter)
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	row := map[string]interface{}{
+		"email_id":            "ann@example.com",
+		"password":            "$2a$10$hash",
+		"forgot_token":        "abc",
+		"two_factor_secret":   "JBSWY3DP",
+		"code_hash":           "f00",
+		"token_count":         2,
+		"password_changed_at": "2024-01-01",
+	}
+	want := map[string]interface{}{
+		"email_id":            "ann@example.com",
+		"password":            redacted,
+		"forgot_token":        redacted,
+		"two_factor_secret":   redacted,
+		"code_hash":           redacted,
+		"token_count":         2,
+		"password_changed_at": "2024-01-01",
+	}
+	if got := redact(row); !reflect.DeepEqual(got, want) {
+		t.Errorf("redact() = %v, want %v", got, want)
+	}
+	if row["password"] == redacted {
+		t.Error("redact() modified its input")
+	}
+	if redact(nil) != nil {
+		t.Error("redact(nil) != nil")
+	}
+}
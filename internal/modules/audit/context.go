@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Source describes who made a write and from where
+type Source struct {
+	ActorID   *uuid.UUID
+	IPAddress string
+	UserAgent string
+}
+
+// sourceKey is the context key for the Source of a request
+type sourceKey struct{}
+
+// WithSource returns a context carrying the source of the writes made with it
+func WithSource(ctx context.Context, source Source) context.Context {
+	return context.WithValue(ctx, sourceKey{}, source)
+}
+
+// SourceFrom returns the source stored in ctx, if any
+func SourceFrom(ctx context.Context) Source {
+	if ctx == nil {
+		return Source{}
+	}
+	source, _ := ctx.Value(sourceKey{}).(Source)
+	return source
+}
+
+// Context returns the request context carrying the authenticated admin (set by
+// AuthMiddleware as adminID), client IP and user agent. Services given this context through
+// their WithContext method attribute the writes they make to the request.
+func Context(c *gin.Context) context.Context {
+	source := Source{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+	if id, err := uuid.Parse(c.GetString("adminID")); err == nil {
+		source.ActorID = &id
+	}
+	return WithSource(c.Request.Context(), source)
+}
@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"net/http"
+	"strconv"
+
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/query"
+	"goUniAdmin/internal/services/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler handles HTTP requests for the audit log
+type AuditHandler struct {
+	service *AuditService
+}
+
+// NewAuditHandler creates a new handler with the service
+func NewAuditHandler(service *AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+// ListAuditLogs godoc
+// @Summary List audit log entries
+// @Description Retrieves recorded creates, updates and deletes with the acting admin, changed values, IP and user agent. Secret columns are redacted. Pass cursor (empty for the first page) to page by keyset cursor instead of page number.
+// @Tags audit
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Param actorId query string false "Filter by acting admin ID, comma-separated for several"
+// @Param entity query string false "Filter by table name such as admins, comma-separated for several"
+// @Param entityId query string false "Filter by entity ID, comma-separated for several"
+// @Param action query string false "Filter by action: create, update or delete"
+// @Param from query string false "Recorded at or after this date (YYYY-MM-DD) or RFC 3339 timestamp"
+// @Param to query string false "Recorded at or before this date (YYYY-MM-DD) or RFC 3339 timestamp"
+// @Param sort query string false "createdAt or -createdAt" default(-createdAt)
+// @Param cursor query string false "Keyset cursor from next_cursor or prev_cursor; empty for the first page"
+// @Param include_total query bool false "Include total_count; defaults to true for page numbers and false for cursors"
+// @Success 200 {object} response.Envelope{data=response.Page{list=[]AuditLog}}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid filter or sort parameter"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 500 {object} response.ErrorEnvelope "Internal server error"
+// @Router /audit-logs [get]
+func (h *AuditHandler) ListAuditLogs(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	page_size, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || page_size < 1 {
+		page_size = 10
+	}
+
+	values := c.Request.URL.Query()
+	if err := ValidateListQuery(values); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
+	params, err := query.Parse(values, AuditLogListSpec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	cursorPage, useCursor, err := query.ParseCursorPage(values, params, page_size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	withTotal, err := query.IncludeTotal(values, !useCursor)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if useCursor {
+		logs, cursors, count, err := h.service.ListByCursor(params, cursorPage, withTotal)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		data := response.Page{List: logs, PageSize: page_size, NextCursor: cursors.Next, PrevCursor: cursors.Prev}
+		if withTotal {
+			data.TotalCount = &count
+		}
+		response.Success(c, http.StatusOK, "details_fetched", data)
+		return
+	}
+
+	logs, count, err := h.service.List(params, page_size, (page-1)*page_size, withTotal)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	data := response.Page{List: logs, Page: page, PageSize: page_size}
+	if withTotal {
+		data.TotalCount = &count
+	}
+	response.Success(c, http.StatusOK, "details_fetched", data)
+}
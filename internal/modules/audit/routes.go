@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/modules"
+	"goUniAdmin/internal/services/middleware"
+	"log"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// auditModule implements the Module interface
+type auditModule struct {
+	handler *AuditHandler
+}
+
+// RegisterRoutes sets up the audit log routes
+func (m *auditModule) RegisterRoutes(group *gin.RouterGroup, cfg *config.Config, db *db.DB) {
+	auditGroup := group.Group("/audit-logs")
+	auditGroup.Use(middleware.AuthMiddleware(cfg))
+	auditGroup.GET("", middleware.RequirePermission("audit-logs:read"), m.handler.ListAuditLogs)
+}
+
+// RegisterAuditModule registers the audit module and starts auditing every write made through db
+func RegisterAuditModule(cfg *config.Config, db *db.DB) {
+	if err := RegisterCallbacks(db.DB); err != nil {
+		log.Fatalf("Failed to register audit callbacks: %v", err)
+	}
+
+	service := NewAuditService(db, cfg)
+	handler := NewAuditHandler(service)
+	modules.RegisterModule(&auditModule{handler: handler})
+	slog.Info("Module registered", "module", "audit")
+}
@@ -0,0 +1,38 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Audit actions
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// AuditLog records one row written through GORM. Before and After hold the changed columns
+// for updates and the whole row for creates and deletes; secrets are redacted.
+type AuditLog struct {
+	ID        uuid.UUID              `gorm:"type:uuid;primaryKey" json:"_id"`
+	ActorID   *uuid.UUID             `gorm:"type:uuid;index" json:"actorId,omitempty"` // Nil for writes outside an authenticated request
+	Action    string                 `gorm:"not null" json:"action"`
+	Entity    string                 `gorm:"not null" json:"entity"` // Table name
+	EntityID  string                 `json:"entityId"`               // Primary key, comma-separated when composite
+	Before    map[string]interface{} `gorm:"type:jsonb;serializer:json" json:"before,omitempty"`
+	After     map[string]interface{} `gorm:"type:jsonb;serializer:json" json:"after,omitempty"`
+	IPAddress string                 `json:"ipAddress,omitempty"`
+	UserAgent string                 `json:"userAgent,omitempty"`
+	CreatedAt time.Time              `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (l *AuditLog) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return
+}
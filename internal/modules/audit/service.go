@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"time"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/services/query"
+
+	"gorm.io/gorm"
+)
+
+// AuditService reads the audit log
+type AuditService struct {
+	db  *db.DB
+	cfg *config.Config
+}
+
+// NewAuditService initializes the service with a GORM database connection and config
+func NewAuditService(db *db.DB, cfg *config.Config) *AuditService {
+	return &AuditService{
+		db:  db,
+		cfg: cfg,
+	}
+}
+
+// AuditLogListSpec declares the filters and sorts accepted by the audit log list endpoint
+var AuditLogListSpec = query.Spec{
+	Filters: map[string]query.Filter{
+		"actorId":  {Column: "actor_id", Type: query.FilterUUID},
+		"entity":   {Column: "entity", Type: query.FilterIn},
+		"entityId": {Column: "entity_id", Type: query.FilterIn},
+		"action":   {Column: "action", Type: query.FilterIn},
+		"from":     {Column: "created_at", Type: query.FilterFrom},
+		"to":       {Column: "created_at", Type: query.FilterTo},
+	},
+	Sorts: map[string]string{
+		"createdAt": "created_at",
+	},
+	DefaultSort: "-createdAt",
+	TieBreaker:  "id",
+}
+
+// List retrieves audit entries matching the filters, in the requested order
+func (s *AuditService) List(params query.Params, limit, offset int, withTotal bool) ([]AuditLog, int64, error) {
+	base := params.Where(s.db.Model(&AuditLog{}))
+
+	var totalCount int64
+	if withTotal {
+		if err := base.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	var logs []AuditLog
+	if err := params.Order(base.Session(&gorm.Session{})).Limit(limit).Offset(offset).Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+	return logs, totalCount, nil
+}
+
+// ListByCursor returns one keyset page of audit entries matching params, with the cursors of
+// the neighbouring pages. The count is only run when withTotal is set.
+func (s *AuditService) ListByCursor(params query.Params, page query.CursorPage, withTotal bool) ([]AuditLog, query.Cursors, int64, error) {
+	base := params.Where(s.db.Model(&AuditLog{}))
+
+	var totalCount int64
+	if withTotal {
+		if err := base.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+			return nil, query.Cursors{}, 0, err
+		}
+	}
+
+	var logs []AuditLog
+	if err := page.Apply(base.Session(&gorm.Session{})).Find(&logs).Error; err != nil {
+		return nil, query.Cursors{}, 0, err
+	}
+	logs, cursors := query.CursorResult(page, logs, func(l AuditLog) (time.Time, string) {
+		return l.CreatedAt, l.ID.String()
+	})
+	return logs, cursors, totalCount, nil
+}
@@ -0,0 +1,26 @@
+package audit
+
+import (
+	"net/url"
+	"strings"
+
+	"goUniAdmin/internal/services/validators"
+
+	"github.com/google/uuid"
+)
+
+// ValidateListQuery checks the list parameters that Parse cannot, so a malformed actor ID is
+// reported as a bad request rather than a database error
+func ValidateListQuery(values url.Values) error {
+	var errs validators.Errors
+	for _, raw := range strings.Split(values.Get("actorId"), ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		if _, err := uuid.Parse(raw); err != nil {
+			errs.Add("actorId", "uuid", "")
+			break
+		}
+	}
+	return errs.Err()
+}
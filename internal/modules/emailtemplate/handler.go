@@ -0,0 +1,251 @@
+package emailtemplate
+
+import (
+	"net/http"
+	"strconv"
+
+	"goUniAdmin/internal/modules/audit"
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// EmailTemplateHandler handles HTTP requests for email template CRUD and preview
+type EmailTemplateHandler struct {
+	service *EmailTemplateService
+}
+
+// NewEmailTemplateHandler creates a new handler with the service
+func NewEmailTemplateHandler(service *EmailTemplateService) *EmailTemplateHandler {
+	return &EmailTemplateHandler{service: service}
+}
+
+// EmailTemplateRequest defines the request body for creating or updating an email template
+type EmailTemplateRequest struct {
+	Slug         string   `json:"slug"`
+	Locale       string   `json:"locale"`
+	Subject      string   `json:"subject"`
+	HTMLBody     string   `json:"htmlBody"`
+	TextBody     string   `json:"textBody"`
+	Placeholders []string `json:"placeholders"`
+	Status       *bool    `json:"status,omitempty"` // Defaults to active
+}
+
+// PreviewRequest defines the sample data used to render a preview
+type PreviewRequest struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+// toModel converts the request into an EmailTemplate
+func (r EmailTemplateRequest) toModel() EmailTemplate {
+	status := true
+	if r.Status != nil {
+		status = *r.Status
+	}
+	return EmailTemplate{
+		Slug:         r.Slug,
+		Locale:       r.Locale,
+		Subject:      r.Subject,
+		HTMLBody:     r.HTMLBody,
+		TextBody:     r.TextBody,
+		Placeholders: r.Placeholders,
+		Status:       status,
+	}
+}
+
+// CreateEmailTemplate godoc
+// @Summary Create an email template
+// @Description Creates an email template that overrides the built-in copy for its slug and locale
+// @Tags email-templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param template body EmailTemplateRequest true "Email template data"
+// @Success 201 {object} response.Envelope{data=EmailTemplate}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body or validation error"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Router /email-templates [post]
+func (h *EmailTemplateHandler) CreateEmailTemplate(c *gin.Context) {
+	var req EmailTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	created, err := h.service.WithContext(audit.Context(c)).Create(req.toModel())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "email_template_created", created)
+}
+
+// GetEmailTemplate godoc
+// @Summary Get an email template by ID
+// @Description Retrieves an email template by UUID
+// @Tags email-templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Email template ID"
+// @Success 200 {object} response.Envelope{data=EmailTemplate}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Email template not found"
+// @Router /email-templates/{id} [get]
+func (h *EmailTemplateHandler) GetEmailTemplate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	tmpl, err := h.service.Read(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", tmpl)
+}
+
+// UpdateEmailTemplate godoc
+// @Summary Update an email template
+// @Description Replaces the content of an email template
+// @Tags email-templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Email template ID"
+// @Param template body EmailTemplateRequest true "Updated email template data"
+// @Success 200 {object} response.Envelope{data=EmailTemplate}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID or request body"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Router /email-templates/{id} [put]
+func (h *EmailTemplateHandler) UpdateEmailTemplate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	var req EmailTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	updated, err := h.service.WithContext(audit.Context(c)).Update(id, req.toModel())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "updated_successfully", updated)
+}
+
+// DeleteEmailTemplate godoc
+// @Summary Delete an email template
+// @Description Deletes an email template; the built-in copy is used again afterwards
+// @Tags email-templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Email template ID"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Email template not found"
+// @Router /email-templates/{id} [delete]
+func (h *EmailTemplateHandler) DeleteEmailTemplate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	if err := h.service.WithContext(audit.Context(c)).Delete(id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "email_template_deleted", nil)
+}
+
+// ListEmailTemplates godoc
+// @Summary List email templates
+// @Description Retrieves a paginated list of email templates
+// @Tags email-templates
+// @Produce json
+// @Security BearerAuth
+// @Param slug query string false "Filter by slug"
+// @Param locale query string false "Filter by locale"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} response.Envelope{data=response.Page{list=[]EmailTemplate}}
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 500 {object} response.ErrorEnvelope "Internal server error"
+// @Router /email-templates [get]
+func (h *EmailTemplateHandler) ListEmailTemplates(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	page_size, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || page_size < 1 {
+		page_size = 10
+	}
+
+	templates, count, err := h.service.List(c.Query("slug"), c.Query("locale"), page_size, (page-1)*page_size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", response.NewPage(templates, page, page_size, count))
+}
+
+// PreviewEmailTemplate godoc
+// @Summary Preview an email template
+// @Description Renders an email template with sample data and reports unknown, missing and unused placeholders
+// @Tags email-templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Email template ID"
+// @Param sample body PreviewRequest false "Sample placeholder values"
+// @Success 200 {object} response.Envelope{data=PreviewResult}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID, request body or template"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Router /email-templates/{id}/preview [post]
+func (h *EmailTemplateHandler) PreviewEmailTemplate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	var req PreviewRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Error(apperror.InvalidRequest("invalid request body"))
+			return
+		}
+	}
+
+	preview, err := h.service.Preview(id, req.Data)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "preview_rendered", preview)
+}
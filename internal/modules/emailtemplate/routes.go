@@ -0,0 +1,40 @@
+package emailtemplate
+
+import (
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/modules"
+	"goUniAdmin/internal/services/email"
+	"goUniAdmin/internal/services/middleware"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// emailTemplateModule implements the Module interface
+type emailTemplateModule struct {
+	handler *EmailTemplateHandler
+}
+
+// RegisterRoutes sets up the email template routes
+func (m *emailTemplateModule) RegisterRoutes(group *gin.RouterGroup, cfg *config.Config, db *db.DB) {
+	templateGroup := group.Group("/email-templates")
+	templateGroup.Use(middleware.AuthMiddleware(cfg))
+	templateGroup.GET("", middleware.RequirePermission("email-templates:read"), m.handler.ListEmailTemplates)
+	templateGroup.POST("", middleware.RequirePermission("email-templates:create"), m.handler.CreateEmailTemplate)
+	templateGroup.GET("/:id", middleware.RequirePermission("email-templates:read"), m.handler.GetEmailTemplate)
+	templateGroup.PUT("/:id", middleware.RequirePermission("email-templates:update"), m.handler.UpdateEmailTemplate)
+	templateGroup.DELETE("/:id", middleware.RequirePermission("email-templates:delete"), m.handler.DeleteEmailTemplate)
+	templateGroup.POST("/:id/preview", middleware.RequirePermission("email-templates:read"), m.handler.PreviewEmailTemplate)
+}
+
+// RegisterEmailTemplateModule registers the email template module with the given dependencies
+func RegisterEmailTemplateModule(cfg *config.Config, db *db.DB) {
+	service := NewEmailTemplateService(db, cfg)
+	handler := NewEmailTemplateHandler(service)
+
+	// Stored templates take precedence over the copies shipped with the binary
+	email.DefaultRenderer.PrependSource(service)
+	modules.RegisterModule(&emailTemplateModule{handler: handler})
+	slog.Info("Module registered", "module", "email_template")
+}
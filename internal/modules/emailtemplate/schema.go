@@ -0,0 +1,63 @@
+package emailtemplate
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EmailTemplate represents an editable transactional email in one locale. It overrides the
+// built-in template with the same slug, e.g. "verify_email" or "password_reset".
+type EmailTemplate struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey" json:"_id"`
+	Slug         string     `gorm:"not null;uniqueIndex:idx_email_templates_slug_locale" json:"slug" validate:"required,slug"`
+	Locale       string     `gorm:"not null;default:en;uniqueIndex:idx_email_templates_slug_locale" json:"locale" validate:"required,locale"`
+	Subject      string     `gorm:"not null" json:"subject" validate:"required"`
+	HTMLBody     string     `gorm:"type:text" json:"htmlBody"`
+	TextBody     string     `gorm:"type:text" json:"textBody"`
+	Placeholders StringList `gorm:"type:jsonb" json:"placeholders"` // Names the template may reference, e.g. FirstName
+	Status       bool       `json:"status"`                         // Inactive templates fall back to the built-in copy
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"createdAt,omitempty"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime" json:"updatedAt,omitempty"`
+}
+
+// StringList is a list of strings stored as a JSON array
+type StringList []string
+
+// Value implements driver.Valuer
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(l))
+	return string(b), err
+}
+
+// Scan implements sql.Scanner
+func (l *StringList) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("unsupported type for StringList")
+	}
+	return json.Unmarshal(data, (*[]string)(l))
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (t *EmailTemplate) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return
+}
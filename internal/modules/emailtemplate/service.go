@@ -0,0 +1,317 @@
+package emailtemplate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	texttemplate "text/template"
+	"text/template/parse"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/email"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrTemplateNotFound is returned for an unknown template ID
+	ErrTemplateNotFound = apperror.New(http.StatusNotFound, "TEMPLATE_NOT_FOUND", "email template not found")
+	// ErrTemplateExists is returned when another template has the same slug and locale
+	ErrTemplateExists = apperror.New(http.StatusConflict, "TEMPLATE_EXISTS", "a template with this slug and locale already exists")
+)
+
+// builtinPlaceholders are supplied to every template by the mailer
+var builtinPlaceholders = []string{"AppName", "SupportEmail"}
+
+// EmailTemplateService manages email templates and serves them to the email renderer
+type EmailTemplateService struct {
+	db  *db.DB
+	cfg *config.Config
+}
+
+// NewEmailTemplateService initializes the service with a GORM database connection and config
+func NewEmailTemplateService(db *db.DB, cfg *config.Config) *EmailTemplateService {
+	return &EmailTemplateService{
+		db:  db,
+		cfg: cfg,
+	}
+}
+
+// WithContext returns a copy of the service whose queries carry ctx, e.g. audit.Context(c) so
+// the audit log attributes the writes to the request
+func (s *EmailTemplateService) WithContext(ctx context.Context) *EmailTemplateService {
+	clone := *s
+	clone.db = &db.DB{DB: s.db.WithContext(ctx)}
+	return &clone
+}
+
+// PreviewResult is a rendered template along with placeholder diagnostics
+type PreviewResult struct {
+	Subject             string   `json:"subject"`
+	HTMLBody            string   `json:"htmlBody"`
+	TextBody            string   `json:"textBody"`
+	UnknownPlaceholders []string `json:"unknownPlaceholders"` // Referenced by the template but not declared
+	MissingPlaceholders []string `json:"missingPlaceholders"` // Referenced by the template but absent from the sample data
+	UnusedPlaceholders  []string `json:"unusedPlaceholders"`  // Declared but never referenced
+	RenderError         string   `json:"renderError,omitempty"`
+}
+
+// Create adds a new email template
+func (s *EmailTemplateService) Create(tmpl EmailTemplate) (EmailTemplate, error) {
+	if err := ValidateEmailTemplate(tmpl); err != nil {
+		return EmailTemplate{}, apperror.Validation(err)
+	}
+
+	var count int64
+	if err := s.db.Model(&EmailTemplate{}).Where("slug = ? AND locale = ?", tmpl.Slug, tmpl.Locale).Count(&count).Error; err != nil {
+		return EmailTemplate{}, err
+	}
+	if count > 0 {
+		return EmailTemplate{}, ErrTemplateExists
+	}
+
+	if err := s.db.Create(&tmpl).Error; err != nil {
+		return EmailTemplate{}, err
+	}
+	return tmpl, nil
+}
+
+// Read retrieves an email template by ID
+func (s *EmailTemplateService) Read(id uuid.UUID) (EmailTemplate, error) {
+	var tmpl EmailTemplate
+	if err := s.db.Where("id = ?", id).First(&tmpl).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return EmailTemplate{}, ErrTemplateNotFound
+		}
+		return EmailTemplate{}, err
+	}
+	return tmpl, nil
+}
+
+// Update replaces the content of an email template
+func (s *EmailTemplateService) Update(id uuid.UUID, updated EmailTemplate) (EmailTemplate, error) {
+	if err := ValidateEmailTemplate(updated); err != nil {
+		return EmailTemplate{}, apperror.Validation(err)
+	}
+
+	existing, err := s.Read(id)
+	if err != nil {
+		return EmailTemplate{}, err
+	}
+
+	var count int64
+	if err := s.db.Model(&EmailTemplate{}).Where("slug = ? AND locale = ? AND id <> ?", updated.Slug, updated.Locale, id).Count(&count).Error; err != nil {
+		return EmailTemplate{}, err
+	}
+	if count > 0 {
+		return EmailTemplate{}, ErrTemplateExists
+	}
+
+	err = s.db.Model(&existing).Updates(map[string]interface{}{
+		"slug":         updated.Slug,
+		"locale":       updated.Locale,
+		"subject":      updated.Subject,
+		"html_body":    updated.HTMLBody,
+		"text_body":    updated.TextBody,
+		"placeholders": updated.Placeholders,
+		"status":       updated.Status,
+	}).Error
+	if err != nil {
+		return EmailTemplate{}, err
+	}
+	return s.Read(id)
+}
+
+// Delete removes an email template; the built-in copy is used again afterwards
+func (s *EmailTemplateService) Delete(id uuid.UUID) error {
+	result := s.db.Delete(&EmailTemplate{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
+
+// List returns email templates, optionally filtered by slug and locale
+func (s *EmailTemplateService) List(slug, locale string, limit, offset int) ([]EmailTemplate, int64, error) {
+	query := s.db.Model(&EmailTemplate{})
+	if slug != "" {
+		query = query.Where("slug = ?", slug)
+	}
+	if locale != "" {
+		query = query.Where("locale = ?", locale)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var templates []EmailTemplate
+	if err := query.Order("slug ASC, locale ASC").Limit(limit).Offset(offset).Find(&templates).Error; err != nil {
+		return nil, 0, err
+	}
+	return templates, totalCount, nil
+}
+
+// FindTemplate implements email.TemplateSource so active templates override the built-in copy
+func (s *EmailTemplateService) FindTemplate(name, locale string) (email.Template, bool, error) {
+	var tmpl EmailTemplate
+	err := s.db.Where("slug = ? AND locale = ? AND status = ?", name, locale, true).First(&tmpl).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return email.Template{}, false, nil
+		}
+		return email.Template{}, false, err
+	}
+	return toEmailTemplate(tmpl), true, nil
+}
+
+// Preview renders a template with sample data. Placeholders referenced by the template but
+// missing from the sample data are rendered as "[Name]" and reported. Execution errors, e.g.
+// ranging over a stand-in value, are reported in RenderError alongside the diagnostics.
+func (s *EmailTemplateService) Preview(id uuid.UUID, sample map[string]interface{}) (PreviewResult, error) {
+	tmpl, err := s.Read(id)
+	if err != nil {
+		return PreviewResult{}, err
+	}
+
+	referenced, err := referencedPlaceholders(tmpl.Subject, tmpl.HTMLBody, tmpl.TextBody)
+	if err != nil {
+		return PreviewResult{}, err
+	}
+
+	declared := map[string]bool{}
+	for _, p := range append(append([]string{}, builtinPlaceholders...), tmpl.Placeholders...) {
+		declared[p] = true
+	}
+
+	data := email.SiteValues(s.cfg.AppName)
+	for k, v := range sample {
+		data[k] = v
+	}
+
+	result := PreviewResult{UnknownPlaceholders: []string{}, MissingPlaceholders: []string{}, UnusedPlaceholders: []string{}}
+	for _, name := range referenced {
+		if !declared[name] {
+			result.UnknownPlaceholders = append(result.UnknownPlaceholders, name)
+		}
+		if _, ok := data[name]; !ok {
+			result.MissingPlaceholders = append(result.MissingPlaceholders, name)
+			data[name] = "[" + name + "]"
+		}
+	}
+	for _, name := range tmpl.Placeholders {
+		if !contains(referenced, name) {
+			result.UnusedPlaceholders = append(result.UnusedPlaceholders, name)
+		}
+	}
+
+	msg, err := email.RenderTemplate(toEmailTemplate(tmpl), data)
+	if err != nil {
+		result.RenderError = err.Error()
+		return result, nil
+	}
+	result.Subject = msg.Subject
+	result.HTMLBody = msg.HTMLBody
+	result.TextBody = msg.TextBody
+	return result, nil
+}
+
+// toEmailTemplate converts the stored template into the renderer's representation
+func toEmailTemplate(tmpl EmailTemplate) email.Template {
+	return email.Template{
+		Name:     tmpl.Slug,
+		Locale:   tmpl.Locale,
+		Subject:  tmpl.Subject,
+		HTMLBody: tmpl.HTMLBody,
+		TextBody: tmpl.TextBody,
+	}
+}
+
+// referencedPlaceholders returns the sorted top-level data fields used by the given templates,
+// e.g. "FirstName" for {{.FirstName}} or {{$.FirstName}}
+func referencedPlaceholders(texts ...string) ([]string, error) {
+	found := map[string]bool{}
+	for _, text := range texts {
+		t, err := texttemplate.New("placeholders").Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		if t.Tree != nil {
+			collectFields(t.Tree.Root, true, found)
+		}
+	}
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// collectFields walks a template parse tree. Inside range and with blocks dot no longer
+// refers to the template data, so only $-rooted fields are collected there.
+func collectFields(node parse.Node, topLevel bool, found map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectFields(child, topLevel, found)
+		}
+	case *parse.ActionNode:
+		collectFields(n.Pipe, topLevel, found)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			for _, arg := range cmd.Args {
+				collectFields(arg, topLevel, found)
+			}
+		}
+	case *parse.FieldNode:
+		if topLevel && len(n.Ident) > 0 {
+			found[n.Ident[0]] = true
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			found[n.Ident[1]] = true
+		}
+	case *parse.ChainNode:
+		collectFields(n.Node, topLevel, found)
+	case *parse.IfNode:
+		collectFields(n.Pipe, topLevel, found)
+		collectFields(n.List, topLevel, found)
+		collectFields(n.ElseList, topLevel, found)
+	case *parse.RangeNode:
+		collectFields(n.Pipe, topLevel, found)
+		collectFields(n.List, false, found)
+		collectFields(n.ElseList, topLevel, found)
+	case *parse.WithNode:
+		collectFields(n.Pipe, topLevel, found)
+		collectFields(n.List, false, found)
+		collectFields(n.ElseList, topLevel, found)
+	case *parse.TemplateNode:
+		collectFields(n.Pipe, topLevel, found)
+	}
+}
+
+// contains reports whether list holds value
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package emailtemplate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReferencedPlaceholders(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		want  []string
+	}{
+		{"plain text", []string{"Hello"}, []string{}},
+		{"field", []string{"Hi {{.FirstName}}"}, []string{"FirstName"}},
+		{"nested field", []string{"{{.User.Name}}"}, []string{"User"}},
+		{"root variable", []string{"{{$.Link}}"}, []string{"Link"}},
+		{"pipeline and function", []string{`{{printf "%s %s" .FirstName .LastName | html}}`}, []string{"FirstName", "LastName"}},
+		{"parenthesized", []string{"{{len (.Items)}}"}, []string{"Items"}},
+		{"chain", []string{"{{(.User).Name}}"}, []string{"User"}},
+		{"if and else", []string{"{{if .Admin}}{{.Role}}{{else}}{{.Fallback}}{{end}}"}, []string{"Admin", "Fallback", "Role"}},
+		{"range body is not top level", []string{"{{range .Items}}{{.Name}} {{$.Currency}}{{else}}{{.Empty}}{{end}}"}, []string{"Currency", "Empty", "Items"}},
+		{"with body is not top level", []string{"{{with .User}}{{.Name}}{{end}}"}, []string{"User"}},
+		{"variable declaration", []string{"{{$name := .FirstName}}{{$name}}"}, []string{"FirstName"}},
+		{"template call", []string{`{{define "x"}}{{.Inner}}{{end}}{{template "x" .Outer}}`}, []string{"Outer"}},
+		{"several texts deduplicated and sorted", []string{"{{.B}} {{.A}}", "{{.A}}", ""}, []string{"A", "B"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := referencedPlaceholders(tt.texts...)
+			if err != nil {
+				t.Fatalf("referencedPlaceholders() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("referencedPlaceholders() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := referencedPlaceholders("{{.Unclosed"); err == nil {
+		t.Error("referencedPlaceholders() accepted a malformed template")
+	}
+}
@@ -0,0 +1,38 @@
+package emailtemplate
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"regexp"
+	texttemplate "text/template"
+
+	"goUniAdmin/internal/services/validators"
+)
+
+// placeholderPattern matches Go identifiers usable as {{.Name}}
+var placeholderPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateEmailTemplate validates the template against the rules in its validate tags and
+// checks that every part parses
+func ValidateEmailTemplate(tmpl EmailTemplate) error {
+	errs := validators.Struct(tmpl)
+	if tmpl.HTMLBody == "" && tmpl.TextBody == "" {
+		errs.Add("htmlBody", "required_without", "textBody")
+	}
+	for i, p := range tmpl.Placeholders {
+		if !placeholderPattern.MatchString(p) {
+			errs.Add(fmt.Sprintf("placeholders[%d]", i), "placeholder", "")
+		}
+	}
+
+	if _, err := texttemplate.New("subject").Parse(tmpl.Subject); err != nil {
+		errs.Add("subject", "template", "")
+	}
+	if _, err := texttemplate.New("textBody").Parse(tmpl.TextBody); err != nil {
+		errs.Add("textBody", "template", "")
+	}
+	if _, err := htmltemplate.New("htmlBody").Parse(tmpl.HTMLBody); err != nil {
+		errs.Add("htmlBody", "template", "")
+	}
+	return errs.Err()
+}
@@ -0,0 +1,56 @@
+package emailtemplate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"goUniAdmin/internal/services/validators"
+)
+
+func TestValidateEmailTemplate(t *testing.T) {
+	valid := EmailTemplate{Slug: "reset_password", Locale: "en", Subject: "Reset", TextBody: "{{.Link}}", Placeholders: StringList{"Link"}}
+	tests := []struct {
+		name   string
+		modify func(*EmailTemplate)
+		want   validators.Errors
+	}{
+		{"valid", func(*EmailTemplate) {}, nil},
+		{"html body only", func(t *EmailTemplate) { t.TextBody = ""; t.HTMLBody = "<p>{{.Link}}</p>" }, nil},
+		{"missing slug", func(t *EmailTemplate) { t.Slug = "" }, validators.Errors{{Field: "slug", Code: "required"}}},
+		{"upper-case slug", func(t *EmailTemplate) { t.Slug = "Reset" }, validators.Errors{{Field: "slug", Code: "slug"}}},
+		{"invalid locale", func(t *EmailTemplate) { t.Locale = "not a locale" }, validators.Errors{{Field: "locale", Code: "locale"}}},
+		{"missing subject", func(t *EmailTemplate) { t.Subject = "" }, validators.Errors{{Field: "subject", Code: "required"}}},
+		{"no body", func(t *EmailTemplate) { t.TextBody = "" }, validators.Errors{{Field: "htmlBody", Code: "required_without", Param: "textBody"}}},
+		{
+			name:   "invalid placeholder name",
+			modify: func(t *EmailTemplate) { t.Placeholders = StringList{"Link", "first-name"} },
+			want:   validators.Errors{{Field: "placeholders[1]", Code: "placeholder"}},
+		},
+		{"malformed subject", func(t *EmailTemplate) { t.Subject = "{{.Name" }, validators.Errors{{Field: "subject", Code: "template"}}},
+		{"malformed html body", func(t *EmailTemplate) { t.HTMLBody = "{{if .A}}" }, validators.Errors{{Field: "htmlBody", Code: "template"}}},
+		{
+			name:   "every failure reported",
+			modify: func(t *EmailTemplate) { t.Slug = ""; t.Locale = "not a locale"; t.TextBody = "{{end}}" },
+			want: validators.Errors{
+				{Field: "slug", Code: "required"},
+				{Field: "locale", Code: "locale"},
+				{Field: "textBody", Code: "template"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := valid
+			tt.modify(&tmpl)
+			err := ValidateEmailTemplate(tmpl)
+			var got validators.Errors
+			if err != nil && !errors.As(err, &got) {
+				t.Fatalf("ValidateEmailTemplate() error = %v, want validators.Errors", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateEmailTemplate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package files
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"goUniAdmin/internal/modules/audit"
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/query"
+	"goUniAdmin/internal/services/response"
+	"goUniAdmin/internal/services/storage"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// multipartOverhead allows for the multipart framing around the file in an upload body
+const multipartOverhead = 1 << 20
+
+// FileHandler handles HTTP requests for uploads, avatars and serving stored files
+type FileHandler struct {
+	service *FileService
+	signer  *storage.Signer // Set when files are served through the API rather than a bucket
+}
+
+// NewFileHandler creates a new handler with the service and, for local and memory storage,
+// the signer checking served file URLs
+func NewFileHandler(service *FileService, signer *storage.Signer) *FileHandler {
+	return &FileHandler{service: service, signer: signer}
+}
+
+// formFile reads the "file" part of a multipart upload, capping the body at the upload limit
+func (h *FileHandler) formFile(c *gin.Context) (*multipart.FileHeader, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.service.MaxSize()+multipartOverhead)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, ErrFileTooLarge
+		}
+		return nil, apperror.InvalidRequest("a file must be uploaded in the file form field")
+	}
+	return header, nil
+}
+
+// UploadFile godoc
+// @Summary Upload a file
+// @Description Uploads a file as multipart form data. The type is sniffed from the content: JPEG, PNG, GIF and WebP images get a thumbnail, and PDF and plain text are accepted as attachments. The size limit is the uploads.max_size_mb setting, else UPLOAD_MAX_SIZE_MB.
+// @Tags files
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "File to upload"
+// @Param purpose formData string false "Upload purpose" default(attachment)
+// @Success 201 {object} response.Envelope{data=File}
+// @Failure 400 {object} response.ErrorEnvelope "Missing file, invalid image or invalid purpose"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 413 {object} response.ErrorEnvelope "File is too large"
+// @Failure 415 {object} response.ErrorEnvelope "Unsupported file type"
+// @Router /files [post]
+func (h *FileHandler) UploadFile(c *gin.Context) {
+	adminID, err := uuid.Parse(c.GetString("adminID"))
+	if err != nil {
+		c.Error(apperror.Unauthorized("invalid admin ID in token"))
+		return
+	}
+
+	header, err := h.formFile(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	purpose := c.DefaultPostForm("purpose", PurposeAttachment)
+	if err := ValidatePurpose(purpose); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	service := h.service.WithContext(audit.Context(c))
+	file, err := service.Upload(adminID, purpose, header)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := service.SignURLs(&file); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "file_uploaded", file)
+}
+
+// GetFile godoc
+// @Summary Get a file by ID
+// @Description Retrieves the metadata of a file with freshly signed URLs to its content and thumbnail
+// @Tags files
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "File ID"
+// @Success 200 {object} response.Envelope{data=File}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "File not found"
+// @Router /files/{id} [get]
+func (h *FileHandler) GetFile(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	file, err := h.service.Read(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.service.SignURLs(&file); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", file)
+}
+
+// DeleteFile godoc
+// @Summary Delete a file
+// @Description Deletes a file and its stored content. An admin using it as their photo loses it.
+// @Tags files
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "File ID"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "File not found"
+// @Router /files/{id} [delete]
+func (h *FileHandler) DeleteFile(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	if err := h.service.WithContext(audit.Context(c)).Delete(id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "file_deleted", nil)
+}
+
+// ListFiles godoc
+// @Summary List files
+// @Description Retrieves a paginated list of uploaded files with signed URLs
+// @Tags files
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Case-insensitive match on the original file name"
+// @Param ownerId query string false "Filter by uploader, comma-separated for several"
+// @Param purpose query string false "Filter by purpose: avatar, attachment"
+// @Param contentType query string false "Filter by content type, comma-separated for several"
+// @Param from query string false "Uploaded at or after, RFC 3339 or YYYY-MM-DD"
+// @Param to query string false "Uploaded at or before, RFC 3339 or YYYY-MM-DD"
+// @Param sort query string false "Comma-separated sort keys, prefixed with - for descending: originalName, size, createdAt" default(-createdAt)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} response.Envelope{data=response.Page{list=[]File}}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid filter or sort"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 500 {object} response.ErrorEnvelope "Internal server error"
+// @Router /files [get]
+func (h *FileHandler) ListFiles(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	page_size, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || page_size < 1 {
+		page_size = 10
+	}
+
+	params, err := query.Parse(c.Request.URL.Query(), FileListSpec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	files, count, err := h.service.List(params, page_size, (page-1)*page_size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	for i := range files {
+		if err := h.service.SignURLs(&files[i]); err != nil {
+			c.Error(err)
+			return
+		}
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", response.NewPage(files, page, page_size, count))
+}
+
+// UploadAvatar godoc
+// @Summary Upload a profile photo
+// @Description Uploads a JPEG, PNG, GIF or WebP image as the signed-in admin's photo. A square thumbnail is generated and the previous uploaded photo is deleted. The admin's photo field then holds the file ID, and profile responses carry signed photoUrl and photoThumbnailUrl.
+// @Tags admins
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Image to upload"
+// @Success 201 {object} response.Envelope{data=File}
+// @Failure 400 {object} response.ErrorEnvelope "Missing file or invalid image"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 413 {object} response.ErrorEnvelope "File is too large"
+// @Failure 415 {object} response.ErrorEnvelope "Unsupported file type"
+// @Router /admins/profile/photo [post]
+func (h *FileHandler) UploadAvatar(c *gin.Context) {
+	adminID, err := uuid.Parse(c.GetString("adminID"))
+	if err != nil {
+		c.Error(apperror.Unauthorized("invalid admin ID in token"))
+		return
+	}
+
+	header, err := h.formFile(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	service := h.service.WithContext(audit.Context(c))
+	file, err := service.SetAvatar(adminID, header)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := service.SignURLs(&file); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "profile_photo_updated", file)
+}
+
+// DeleteAvatar godoc
+// @Summary Remove the profile photo
+// @Description Clears the signed-in admin's photo and deletes the uploaded image behind it
+// @Tags admins
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Envelope
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 500 {object} response.ErrorEnvelope "Internal server error"
+// @Router /admins/profile/photo [delete]
+func (h *FileHandler) DeleteAvatar(c *gin.Context) {
+	adminID, err := uuid.Parse(c.GetString("adminID"))
+	if err != nil {
+		c.Error(apperror.Unauthorized("invalid admin ID in token"))
+		return
+	}
+
+	if err := h.service.WithContext(audit.Context(c)).RemoveAvatar(adminID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "profile_photo_removed", nil)
+}
+
+// ServeFile godoc
+// @Summary Download a stored file
+// @Description Serves the content of a file kept in local or memory storage through a signed URL handed out by the API. S3 storage hands out presigned bucket URLs instead.
+// @Tags files
+// @Produce octet-stream
+// @Param key path string true "Storage key"
+// @Param expires query int true "Expiry as a Unix timestamp"
+// @Param signature query string true "URL signature"
+// @Success 200 {file} file
+// @Failure 403 {object} response.ErrorEnvelope "Invalid or expired file URL"
+// @Failure 404 {object} response.ErrorEnvelope "File not found"
+// @Router /files/raw/{key} [get]
+func (h *FileHandler) ServeFile(c *gin.Context) {
+	if h.signer == nil {
+		c.Error(ErrFileNotFound)
+		return
+	}
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	if err := h.signer.Verify(key, c.Query("expires"), c.Query("signature")); err != nil {
+		c.Error(ErrInvalidFileURL)
+		return
+	}
+
+	body, err := h.service.storage.Open(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			err = ErrFileNotFound
+		}
+		c.Error(err)
+		return
+	}
+	defer body.Close()
+
+	contentType := contentTypeOf(key)
+	header := c.Writer.Header()
+	header.Set("Content-Type", contentType)
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Cache-Control", "private, max-age="+strconv.Itoa(int(h.service.cfg.FileURLExpiry.Seconds())))
+	if !strings.HasPrefix(contentType, "image/") {
+		header.Set("Content-Disposition", "attachment")
+	}
+
+	if seeker, ok := body.(io.ReadSeeker); ok {
+		http.ServeContent(c.Writer, c.Request, "", time.Time{}, seeker)
+		return
+	}
+	c.Status(http.StatusOK)
+	io.Copy(c.Writer, body)
+}
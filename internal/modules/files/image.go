@@ -0,0 +1,90 @@
+package files
+
+import (
+	"bytes"
+	"image"
+	_ "image/gif" // Registers the GIF decoder
+	"image/jpeg"
+	"image/png"
+
+	_ "golang.org/x/image/webp" // Registers the WebP decoder
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	// maxImagePixels rejects images whose decoded size would exhaust memory
+	maxImagePixels = 40_000_000
+	// thumbnailSize is the longest side of attachment thumbnails
+	thumbnailSize = 320
+	// avatarThumbnailSize is the side of the square avatar thumbnail
+	avatarThumbnailSize = 256
+)
+
+// thumbnail is an encoded thumbnail with the dimensions of the source image
+type thumbnail struct {
+	data        []byte
+	contentType string
+	ext         string
+	width       int // Of the source image
+	height      int
+}
+
+// makeThumbnail decodes an image and encodes a downscaled copy: a centred square for
+// avatars, otherwise the whole image fitted within thumbnailSize. Formats that may be
+// transparent are encoded as PNG, the rest as JPEG.
+func makeThumbnail(data []byte, contentType, purpose string) (thumbnail, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return thumbnail{}, ErrInvalidImage.Wrap(err)
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return thumbnail{}, ErrInvalidImage.WithDetails(map[string]int{"maxPixels": maxImagePixels})
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return thumbnail{}, ErrInvalidImage.Wrap(err)
+	}
+
+	bounds := src.Bounds()
+	var dst *image.RGBA
+	if purpose == PurposeAvatar {
+		side := min(bounds.Dx(), bounds.Dy())
+		x := bounds.Min.X + (bounds.Dx()-side)/2
+		y := bounds.Min.Y + (bounds.Dy()-side)/2
+		bounds = image.Rect(x, y, x+side, y+side)
+		size := min(side, avatarThumbnailSize)
+		dst = image.NewRGBA(image.Rect(0, 0, size, size))
+	} else {
+		width, height := fit(bounds.Dx(), bounds.Dy(), thumbnailSize)
+		dst = image.NewRGBA(image.Rect(0, 0, width, height))
+	}
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	thumb := thumbnail{width: config.Width, height: config.Height}
+	var buf bytes.Buffer
+	switch contentType {
+	case "image/png", "image/gif", "image/webp":
+		err = png.Encode(&buf, dst)
+		thumb.contentType, thumb.ext = "image/png", ".png"
+	default:
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+		thumb.contentType, thumb.ext = "image/jpeg", ".jpg"
+	}
+	if err != nil {
+		return thumbnail{}, err
+	}
+	thumb.data = buf.Bytes()
+	return thumb, nil
+}
+
+// fit scales width and height down so the longer side is at most limit
+func fit(width, height, limit int) (int, int) {
+	if width <= limit && height <= limit {
+		return width, height
+	}
+	if width >= height {
+		return limit, max(1, height*limit/width)
+	}
+	return max(1, width*limit/height), limit
+}
@@ -0,0 +1,56 @@
+package files
+
+import (
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/modules"
+	"goUniAdmin/internal/modules/admin"
+	"goUniAdmin/internal/services/middleware"
+	"goUniAdmin/internal/services/storage"
+	"log"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fileModule implements the Module interface
+type fileModule struct {
+	handler *FileHandler
+}
+
+// RegisterRoutes sets up the file routes, the profile photo routes and the signed download route
+func (m *fileModule) RegisterRoutes(group *gin.RouterGroup, cfg *config.Config, db *db.DB) {
+	group.GET("/files/raw/*key", m.handler.ServeFile)
+
+	fileGroup := group.Group("/files")
+	fileGroup.Use(middleware.AuthMiddleware(cfg))
+	fileGroup.GET("", middleware.RequirePermission("files:read"), m.handler.ListFiles)
+	fileGroup.POST("", middleware.RequirePermission("files:create"), m.handler.UploadFile)
+	fileGroup.GET("/:id", middleware.RequirePermission("files:read"), m.handler.GetFile)
+	fileGroup.DELETE("/:id", middleware.RequirePermission("files:delete"), m.handler.DeleteFile)
+
+	photoGroup := group.Group("/admins/profile/photo")
+	photoGroup.Use(middleware.AuthMiddleware(cfg))
+	photoGroup.POST("", m.handler.UploadAvatar)
+	photoGroup.DELETE("", m.handler.DeleteAvatar)
+}
+
+// RegisterFilesModule registers the files module with the storage selected by STORAGE_DRIVER
+func RegisterFilesModule(cfg *config.Config, db *db.DB) {
+	store, err := storage.New(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize file storage: %v", err)
+	}
+	service := NewFileService(db, cfg, store)
+
+	// Local and memory storage serve files through the API and check their own signatures
+	var signer *storage.Signer
+	if served, ok := store.(interface{ Signer() *storage.Signer }); ok {
+		signer = served.Signer()
+	}
+	handler := NewFileHandler(service, signer)
+
+	admin.PhotoResolverInstance = service
+	modules.RegisterModule(&fileModule{handler: handler})
+	slog.Info("Module registered", "module", "files")
+}
@@ -0,0 +1,40 @@
+package files
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// File purposes
+const (
+	PurposeAvatar     = "avatar"     // Profile photo, linked to Admin.Photo
+	PurposeAttachment = "attachment" // Any other upload
+)
+
+// File is an uploaded file kept in storage. Its content is reached through short-lived
+// signed URLs rather than a stored public link.
+type File struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey" json:"_id"`
+	OwnerID      uuid.UUID `gorm:"type:uuid;not null;index" json:"ownerId"` // Admin who uploaded it
+	Purpose      string    `gorm:"not null;default:attachment" json:"purpose"`
+	OriginalName string    `json:"originalName"`
+	ContentType  string    `gorm:"not null" json:"contentType"` // Sniffed from the content, not taken from the client
+	Size         int64     `gorm:"not null" json:"size"`
+	StorageKey   string    `gorm:"not null;unique" json:"-"`
+	ThumbnailKey string    `json:"-"` // Set for images
+	Width        int       `json:"width,omitempty"`
+	Height       int       `json:"height,omitempty"`
+	URL          string    `gorm:"-" json:"url,omitempty"`          // Signed, expires after FILE_URL_EXPIRY
+	ThumbnailURL string    `gorm:"-" json:"thumbnailUrl,omitempty"` // Signed, expires after FILE_URL_EXPIRY
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"createdAt,omitempty"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (f *File) BeforeCreate(tx *gorm.DB) (err error) {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,332 @@
+package files
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/modules/admin"
+	"goUniAdmin/internal/modules/settings"
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/query"
+	"goUniAdmin/internal/services/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrFileNotFound is returned for an unknown file
+	ErrFileNotFound = apperror.New(http.StatusNotFound, "FILE_NOT_FOUND", "file not found")
+	// ErrInvalidFileURL is returned for a file URL whose signature is wrong or expired
+	ErrInvalidFileURL = apperror.New(http.StatusForbidden, "INVALID_FILE_URL", "invalid or expired file URL")
+)
+
+// FileService stores uploads and their metadata
+type FileService struct {
+	db      *db.DB
+	cfg     *config.Config
+	storage storage.Storage
+}
+
+// NewFileService initializes the service with a GORM database connection, config and storage
+func NewFileService(db *db.DB, cfg *config.Config, store storage.Storage) *FileService {
+	return &FileService{
+		db:      db,
+		cfg:     cfg,
+		storage: store,
+	}
+}
+
+// WithContext returns a copy of the service whose queries carry ctx, e.g. audit.Context(c) so
+// the audit log attributes the writes to the request
+func (s *FileService) WithContext(ctx context.Context) *FileService {
+	clone := *s
+	clone.db = &db.DB{DB: s.db.WithContext(ctx)}
+	return &clone
+}
+
+// FileListSpec declares the search, filters and sorts accepted by the file list endpoint
+var FileListSpec = query.Spec{
+	SearchColumns: []string{"original_name"},
+	Filters: map[string]query.Filter{
+		"ownerId":     {Column: "owner_id", Type: query.FilterUUID},
+		"purpose":     {Column: "purpose", Type: query.FilterIn},
+		"contentType": {Column: "content_type", Type: query.FilterIn},
+		"from":        {Column: "created_at", Type: query.FilterFrom},
+		"to":          {Column: "created_at", Type: query.FilterTo},
+	},
+	Sorts: map[string]string{
+		"originalName": "original_name",
+		"size":         "size",
+		"createdAt":    "created_at",
+	},
+	DefaultSort: "-createdAt",
+	TieBreaker:  "id",
+}
+
+// MaxSize returns the upload size limit in bytes: the uploads.max_size_mb setting when set,
+// else UPLOAD_MAX_SIZE_MB
+func (s *FileService) MaxSize() int64 {
+	if settings.ServiceInstance == nil {
+		return s.cfg.UploadMaxSize
+	}
+	mb := settings.ServiceInstance.GetNumber("uploads.max_size_mb", float64(s.cfg.UploadMaxSize>>20))
+	return int64(mb * (1 << 20))
+}
+
+// Upload checks, stores and records an uploaded file. Images also get a thumbnail.
+func (s *FileService) Upload(ownerID uuid.UUID, purpose string, header *multipart.FileHeader) (File, error) {
+	maxSize := s.MaxSize()
+	if header.Size > maxSize {
+		return File{}, ErrFileTooLarge
+	}
+	src, err := header.Open()
+	if err != nil {
+		return File{}, err
+	}
+	defer src.Close()
+
+	// Read one byte past the limit so a client understating the size is still caught
+	data, err := io.ReadAll(io.LimitReader(src, maxSize+1))
+	if err != nil {
+		return File{}, err
+	}
+	if int64(len(data)) > maxSize {
+		return File{}, ErrFileTooLarge
+	}
+	if len(data) == 0 {
+		return File{}, ErrEmptyFile
+	}
+
+	contentType, ext, err := SniffContentType(data, purpose)
+	if err != nil {
+		return File{}, err
+	}
+
+	file := File{
+		ID:           uuid.New(),
+		OwnerID:      ownerID,
+		Purpose:      purpose,
+		OriginalName: cleanName(header.Filename),
+		ContentType:  contentType,
+		Size:         int64(len(data)),
+	}
+	prefix := path.Join(purpose+"s", time.Now().UTC().Format("2006/01"), file.ID.String())
+	file.StorageKey = prefix + ext
+
+	var thumb thumbnail
+	if _, ok := imageTypes[contentType]; ok {
+		if thumb, err = makeThumbnail(data, contentType, purpose); err != nil {
+			return File{}, err
+		}
+		file.Width, file.Height = thumb.width, thumb.height
+		file.ThumbnailKey = prefix + "_thumb" + thumb.ext
+	}
+
+	ctx := s.db.Statement.Context
+	if err := s.storage.Put(ctx, file.StorageKey, bytes.NewReader(data), file.Size, contentType); err != nil {
+		return File{}, err
+	}
+	if file.ThumbnailKey != "" {
+		if err := s.storage.Put(ctx, file.ThumbnailKey, bytes.NewReader(thumb.data), int64(len(thumb.data)), thumb.contentType); err != nil {
+			s.removeObjects(file)
+			return File{}, err
+		}
+	}
+	if err := s.db.Create(&file).Error; err != nil {
+		s.removeObjects(file)
+		return File{}, err
+	}
+	return file, nil
+}
+
+// Read retrieves a file's metadata by ID
+func (s *FileService) Read(id uuid.UUID) (File, error) {
+	var file File
+	if err := s.db.Where("id = ?", id).First(&file).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return File{}, ErrFileNotFound
+		}
+		return File{}, err
+	}
+	return file, nil
+}
+
+// Delete removes a file and its stored content. An admin using it as their photo loses it.
+func (s *FileService) Delete(id uuid.UUID) error {
+	file, err := s.Read(id)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&admin.Admin{}).Where("photo = ?", id.String()).Update("photo", "").Error; err != nil {
+			return err
+		}
+		return tx.Delete(&File{}, "id = ?", id).Error
+	})
+	if err != nil {
+		return err
+	}
+	s.removeObjects(file)
+	return nil
+}
+
+// List retrieves files matching the search and filters, in the requested order
+func (s *FileService) List(params query.Params, limit, offset int) ([]File, int64, error) {
+	base := params.Where(s.db.Model(&File{}))
+
+	var totalCount int64
+	if err := base.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var files []File
+	if err := params.Order(base.Session(&gorm.Session{})).Limit(limit).Offset(offset).Find(&files).Error; err != nil {
+		return nil, 0, err
+	}
+	return files, totalCount, nil
+}
+
+// SetAvatar stores an uploaded image as the admin's photo and removes the avatar it replaces
+func (s *FileService) SetAvatar(adminID uuid.UUID, header *multipart.FileHeader) (File, error) {
+	current, err := s.currentPhoto(adminID)
+	if err != nil {
+		return File{}, err
+	}
+
+	file, err := s.Upload(adminID, PurposeAvatar, header)
+	if err != nil {
+		return File{}, err
+	}
+	if err := s.db.Model(&admin.Admin{}).Where("id = ?", adminID).Update("photo", file.ID.String()).Error; err != nil {
+		s.discard(file)
+		return File{}, err
+	}
+	s.discardAvatar(current, adminID)
+	return file, nil
+}
+
+// RemoveAvatar clears the admin's photo and deletes the avatar file behind it
+func (s *FileService) RemoveAvatar(adminID uuid.UUID) error {
+	current, err := s.currentPhoto(adminID)
+	if err != nil {
+		return err
+	}
+	if err := s.db.Model(&admin.Admin{}).Where("id = ?", adminID).Update("photo", "").Error; err != nil {
+		return err
+	}
+	s.discardAvatar(current, adminID)
+	return nil
+}
+
+// SignURLs fills in the signed URLs of a file
+func (s *FileService) SignURLs(file *File) error {
+	ctx := s.db.Statement.Context
+	url, err := s.storage.SignedURL(ctx, file.StorageKey, s.cfg.FileURLExpiry)
+	if err != nil {
+		return err
+	}
+	file.URL = url
+	if file.ThumbnailKey != "" {
+		if file.ThumbnailURL, err = s.storage.SignedURL(ctx, file.ThumbnailKey, s.cfg.FileURLExpiry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PhotoURLs implements admin.PhotoResolver. A photo holding the ID of an avatar the admin
+// uploaded is turned into signed URLs; any other value is taken to be a URL already.
+func (s *FileService) PhotoURLs(adminID uuid.UUID, photo string) (string, string, error) {
+	id, err := uuid.Parse(photo)
+	if err != nil {
+		return photo, "", nil
+	}
+	file, err := s.Read(id)
+	if errors.Is(err, ErrFileNotFound) {
+		return "", "", nil
+	} else if err != nil {
+		return "", "", err
+	}
+	// Photo is writable through the profile, so never sign a file the admin does not own
+	if file.Purpose != PurposeAvatar || file.OwnerID != adminID {
+		return "", "", nil
+	}
+	if err := s.SignURLs(&file); err != nil {
+		return "", "", err
+	}
+	return file.URL, file.ThumbnailURL, nil
+}
+
+// currentPhoto returns the admin's photo value
+func (s *FileService) currentPhoto(adminID uuid.UUID) (string, error) {
+	var current admin.Admin
+	if err := s.db.Select("photo").Where("id = ? AND is_deleted = ?", adminID, false).First(&current).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", admin.ErrAdminNotFound
+		}
+		return "", err
+	}
+	return current.Photo, nil
+}
+
+// discardAvatar deletes a replaced avatar, if the photo was one uploaded by the admin
+func (s *FileService) discardAvatar(photo string, adminID uuid.UUID) {
+	id, err := uuid.Parse(photo)
+	if err != nil {
+		return
+	}
+	file, err := s.Read(id)
+	if err != nil || file.Purpose != PurposeAvatar || file.OwnerID != adminID {
+		return
+	}
+	s.discard(file)
+}
+
+// discard deletes a file record and its stored content, logging failures since the caller
+// has already succeeded
+func (s *FileService) discard(file File) {
+	if err := s.db.Delete(&File{}, "id = ?", file.ID).Error; err != nil {
+		slog.ErrorContext(s.db.Statement.Context, "Failed to delete file", "file_id", file.ID, "error", err)
+		return
+	}
+	s.removeObjects(file)
+}
+
+// removeObjects deletes the stored content of a file. Failures are logged; the orphaned
+// objects are unreachable since their keys are random.
+func (s *FileService) removeObjects(file File) {
+	ctx := s.db.Statement.Context
+	for _, key := range []string{file.StorageKey, file.ThumbnailKey} {
+		if key == "" {
+			continue
+		}
+		if err := s.storage.Delete(ctx, key); err != nil {
+			slog.ErrorContext(ctx, "Failed to delete stored object", "key", key, "error", err)
+		}
+	}
+}
+
+// contentTypeOf returns the content type of a stored key from its extension
+func contentTypeOf(key string) string {
+	ext := strings.ToLower(path.Ext(key))
+	for _, types := range []map[string]string{imageTypes, documentTypes} {
+		for contentType, typeExt := range types {
+			if typeExt == ext {
+				return contentType
+			}
+		}
+	}
+	return "application/octet-stream"
+}
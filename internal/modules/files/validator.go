@@ -0,0 +1,91 @@
+package files
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/validators"
+)
+
+var (
+	// ErrFileTooLarge is returned for uploads over the size limit
+	ErrFileTooLarge = apperror.New(http.StatusRequestEntityTooLarge, "FILE_TOO_LARGE", "file is too large")
+	// ErrEmptyFile is returned for uploads without content
+	ErrEmptyFile = apperror.New(http.StatusBadRequest, "FILE_EMPTY", "file is empty")
+	// ErrUnsupportedFileType is returned for content of a type not accepted for the purpose
+	ErrUnsupportedFileType = apperror.New(http.StatusUnsupportedMediaType, "UNSUPPORTED_FILE_TYPE", "file type is not allowed")
+	// ErrInvalidImage is returned for image content that cannot be decoded or is too large
+	ErrInvalidImage = apperror.New(http.StatusBadRequest, "INVALID_IMAGE", "invalid image")
+)
+
+// imageTypes are the accepted image formats, by sniffed content type, with the extension
+// stored files get
+var imageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// documentTypes are the accepted non-image formats for attachments
+var documentTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"text/plain":      ".txt",
+}
+
+// maxNameLength bounds the stored original file name
+const maxNameLength = 255
+
+// SniffContentType detects the content type from the first bytes of a file, ignoring the
+// type claimed by the client, and checks that it is allowed for the purpose. It returns the
+// content type and the extension to store the file under.
+func SniffContentType(data []byte, purpose string) (string, string, error) {
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(data))
+	if err != nil {
+		return "", "", ErrUnsupportedFileType
+	}
+	if ext, ok := imageTypes[contentType]; ok {
+		return contentType, ext, nil
+	}
+	if ext, ok := documentTypes[contentType]; ok && purpose == PurposeAttachment {
+		return contentType, ext, nil
+	}
+	if purpose == PurposeAvatar {
+		return "", "", fileTypeError("file type %s is not allowed; avatars must be JPEG, PNG, GIF or WebP images", contentType)
+	}
+	return "", "", fileTypeError("file type %s is not allowed", contentType)
+}
+
+// fileTypeError is ErrUnsupportedFileType with a message naming the rejected type
+func fileTypeError(format, contentType string) error {
+	err := *ErrUnsupportedFileType
+	err.Message = fmt.Sprintf(format, contentType)
+	return &err
+}
+
+// ValidatePurpose checks the purpose of a generic upload. Avatars go through the profile endpoint.
+func ValidatePurpose(purpose string) error {
+	return validators.Var("purpose", purpose, "oneof="+PurposeAttachment).Err()
+}
+
+// cleanName keeps the base name of a client-supplied file name, without control characters
+func cleanName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "." || name == "/" {
+		return ""
+	}
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	return name
+}
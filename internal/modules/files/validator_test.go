@@ -0,0 +1,78 @@
+package files
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"goUniAdmin/internal/services/apperror"
+)
+
+var (
+	pngHeader  = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	jpegHeader = []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00")
+	gifHeader  = []byte("GIF89a\x01\x00\x01\x00")
+	webpHeader = []byte("RIFF\x00\x00\x00\x00WEBPVP8 ")
+	pdfHeader  = []byte("%PDF-1.7\n")
+	textBody   = []byte("plain notes\n")
+	htmlBody   = []byte("<html><script>alert(1)</script></html>")
+	zipHeader  = []byte("PK\x03\x04\x14\x00\x00\x00")
+)
+
+func TestSniffContentType(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        []byte
+		purpose     string
+		contentType string
+		ext         string
+		wantErr     bool
+	}{
+		{"png avatar", pngHeader, PurposeAvatar, "image/png", ".png", false},
+		{"jpeg avatar", jpegHeader, PurposeAvatar, "image/jpeg", ".jpg", false},
+		{"gif attachment", gifHeader, PurposeAttachment, "image/gif", ".gif", false},
+		{"webp attachment", webpHeader, PurposeAttachment, "image/webp", ".webp", false},
+		{"pdf attachment", pdfHeader, PurposeAttachment, "application/pdf", ".pdf", false},
+		{"text attachment", textBody, PurposeAttachment, "text/plain", ".txt", false},
+		{"pdf avatar", pdfHeader, PurposeAvatar, "", "", true},
+		{"text avatar", textBody, PurposeAvatar, "", "", true},
+		{"html attachment", htmlBody, PurposeAttachment, "", "", true},
+		{"zip attachment", zipHeader, PurposeAttachment, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType, ext, err := SniffContentType(tt.data, tt.purpose)
+			if tt.wantErr {
+				var appErr *apperror.Error
+				if !errors.As(err, &appErr) || appErr.Status != http.StatusUnsupportedMediaType {
+					t.Fatalf("SniffContentType() error = %v, want 415", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SniffContentType() error = %v", err)
+			}
+			if contentType != tt.contentType || ext != tt.ext {
+				t.Errorf("SniffContentType() = %q, %q, want %q, %q", contentType, ext, tt.contentType, tt.ext)
+			}
+		})
+	}
+}
+
+func TestCleanName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"report.pdf", "report.pdf"},
+		{"../../etc/passwd", "passwd"},
+		{"C:\\Users\\me\\photo.png", "photo.png"},
+		{"bad\x00name\n.txt", "badname.txt"},
+		{"/", ""},
+	}
+	for _, tt := range tests {
+		if got := cleanName(tt.name); got != tt.want {
+			t.Errorf("cleanName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
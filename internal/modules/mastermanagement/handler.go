@@ -0,0 +1,445 @@
+package mastermanagement
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"goUniAdmin/internal/modules/audit"
+	"goUniAdmin/internal/services/apperror"
+	localization "goUniAdmin/internal/services/common"
+	"goUniAdmin/internal/services/query"
+	"goUniAdmin/internal/services/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// MasterHandler handles HTTP requests for master type and value CRUD and public lookups
+type MasterHandler struct {
+	service *MasterService
+}
+
+// NewMasterHandler creates a new handler with the service
+func NewMasterHandler(service *MasterService) *MasterHandler {
+	return &MasterHandler{service: service}
+}
+
+// MasterTypeRequest defines the request body for creating or updating a master type
+type MasterTypeRequest struct {
+	Code        string `json:"code"` // Ignored on update; codes are fixed
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	IsActive    *bool  `json:"isActive,omitempty"` // Defaults to active
+}
+
+// toModel converts the request into a MasterType
+func (r MasterTypeRequest) toModel() MasterType {
+	isActive := true
+	if r.IsActive != nil {
+		isActive = *r.IsActive
+	}
+	return MasterType{
+		Code:        r.Code,
+		Name:        r.Name,
+		Description: r.Description,
+		IsActive:    isActive,
+	}
+}
+
+// MasterValueRequest defines the request body for creating or updating a master value
+type MasterValueRequest struct {
+	TypeID    uuid.UUID         `json:"typeId"` // Ignored on update; values stay in their type
+	Code      string            `json:"code"`
+	Label     string            `json:"label"`
+	Labels    map[string]string `json:"labels,omitempty"` // Label by locale, e.g. {"de": "Indien"}
+	ParentID  *uuid.UUID        `json:"parentId,omitempty"`
+	SortOrder int               `json:"sortOrder"`
+	IsActive  *bool             `json:"isActive,omitempty"` // Defaults to active
+}
+
+// toModel converts the request into a MasterValue
+func (r MasterValueRequest) toModel() MasterValue {
+	isActive := true
+	if r.IsActive != nil {
+		isActive = *r.IsActive
+	}
+	return MasterValue{
+		TypeID:    r.TypeID,
+		Code:      r.Code,
+		Label:     r.Label,
+		Labels:    r.Labels,
+		ParentID:  r.ParentID,
+		SortOrder: r.SortOrder,
+		IsActive:  isActive,
+	}
+}
+
+// CreateMasterType godoc
+// @Summary Create a master type
+// @Description Creates a lookup list such as country, currency, timezone or a custom dropdown
+// @Tags masters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param type body MasterTypeRequest true "Master type data"
+// @Success 201 {object} response.Envelope{data=MasterType}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body or validation error"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Router /master-types [post]
+func (h *MasterHandler) CreateMasterType(c *gin.Context) {
+	var req MasterTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	created, err := h.service.WithContext(audit.Context(c)).CreateType(req.toModel())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "master_type_created", created)
+}
+
+// GetMasterType godoc
+// @Summary Get a master type by ID
+// @Description Retrieves a master type by UUID
+// @Tags masters
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Master type ID"
+// @Success 200 {object} response.Envelope{data=MasterType}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Master type not found"
+// @Router /master-types/{id} [get]
+func (h *MasterHandler) GetMasterType(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	masterType, err := h.service.ReadType(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", masterType)
+}
+
+// UpdateMasterType godoc
+// @Summary Update a master type
+// @Description Changes the name, description and activation of a master type. The code is fixed.
+// @Tags masters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Master type ID"
+// @Param type body MasterTypeRequest true "Updated master type data"
+// @Success 200 {object} response.Envelope{data=MasterType}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID, request body or validation error"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Master type not found"
+// @Router /master-types/{id} [put]
+func (h *MasterHandler) UpdateMasterType(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	var req MasterTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	updated, err := h.service.WithContext(audit.Context(c)).UpdateType(id, req.toModel())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "updated_successfully", updated)
+}
+
+// DeleteMasterType godoc
+// @Summary Delete a master type
+// @Description Deletes a master type and all of its values. Fails while values of other types are nested under its values.
+// @Tags masters
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Master type ID"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Master type not found"
+// @Failure 409 {object} response.ErrorEnvelope "Values of other types are nested under this type"
+// @Router /master-types/{id} [delete]
+func (h *MasterHandler) DeleteMasterType(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	if err := h.service.WithContext(audit.Context(c)).DeleteType(id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "master_type_deleted", nil)
+}
+
+// ListMasterTypes godoc
+// @Summary List master types
+// @Description Retrieves a paginated list of master types
+// @Tags masters
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Case-insensitive match on code or name"
+// @Param code query string false "Filter by code, comma-separated for several"
+// @Param isActive query bool false "Filter by activation"
+// @Param sort query string false "Comma-separated sort keys, prefixed with - for descending: code, name, createdAt, updatedAt" default(code)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} response.Envelope{data=response.Page{list=[]MasterType}}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid filter or sort"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 500 {object} response.ErrorEnvelope "Internal server error"
+// @Router /master-types [get]
+func (h *MasterHandler) ListMasterTypes(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	page_size, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || page_size < 1 {
+		page_size = 10
+	}
+
+	params, err := query.Parse(c.Request.URL.Query(), MasterTypeListSpec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	types, count, err := h.service.ListTypes(params, page_size, (page-1)*page_size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", response.NewPage(types, page, page_size, count))
+}
+
+// CreateMasterValue godoc
+// @Summary Create a master value
+// @Description Adds a value to a master type, optionally nested under a value of any type
+// @Tags masters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param value body MasterValueRequest true "Master value data"
+// @Success 201 {object} response.Envelope{data=MasterValue}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body or validation error"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Master type not found"
+// @Router /master-values [post]
+func (h *MasterHandler) CreateMasterValue(c *gin.Context) {
+	var req MasterValueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	created, err := h.service.WithContext(audit.Context(c)).CreateValue(req.toModel())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "master_value_created", created)
+}
+
+// GetMasterValue godoc
+// @Summary Get a master value by ID
+// @Description Retrieves a master value by UUID
+// @Tags masters
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Master value ID"
+// @Success 200 {object} response.Envelope{data=MasterValue}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Master value not found"
+// @Router /master-values/{id} [get]
+func (h *MasterHandler) GetMasterValue(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	value, err := h.service.ReadValue(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", value)
+}
+
+// UpdateMasterValue godoc
+// @Summary Update a master value
+// @Description Replaces the content of a master value. Its type is fixed.
+// @Tags masters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Master value ID"
+// @Param value body MasterValueRequest true "Updated master value data"
+// @Success 200 {object} response.Envelope{data=MasterValue}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID, request body or validation error"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Master value not found"
+// @Router /master-values/{id} [put]
+func (h *MasterHandler) UpdateMasterValue(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	var req MasterValueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	updated, err := h.service.WithContext(audit.Context(c)).UpdateValue(id, req.toModel())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "updated_successfully", updated)
+}
+
+// DeleteMasterValue godoc
+// @Summary Delete a master value
+// @Description Deletes a master value. Fails while other values are nested under it.
+// @Tags masters
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Master value ID"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Master value not found"
+// @Failure 409 {object} response.ErrorEnvelope "Value has child values"
+// @Router /master-values/{id} [delete]
+func (h *MasterHandler) DeleteMasterValue(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	if err := h.service.WithContext(audit.Context(c)).DeleteValue(id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "master_value_deleted", nil)
+}
+
+// ListMasterValues godoc
+// @Summary List master values
+// @Description Retrieves a paginated list of master values, including inactive ones
+// @Tags masters
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Case-insensitive match on code or label"
+// @Param typeId query string false "Filter by master type ID, comma-separated for several"
+// @Param parentId query string false "Filter by parent value ID, comma-separated for several"
+// @Param code query string false "Filter by code, comma-separated for several"
+// @Param isActive query bool false "Filter by activation"
+// @Param sort query string false "Comma-separated sort keys, prefixed with - for descending: sortOrder, code, label, createdAt, updatedAt" default(sortOrder,label)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} response.Envelope{data=response.Page{list=[]MasterValue}}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid filter or sort"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 500 {object} response.ErrorEnvelope "Internal server error"
+// @Router /master-values [get]
+func (h *MasterHandler) ListMasterValues(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	page_size, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || page_size < 1 {
+		page_size = 10
+	}
+
+	if err := ValidateValueListQuery(c.Request.URL.Query()); err != nil {
+		c.Error(apperror.Validation(err))
+		return
+	}
+
+	params, err := query.Parse(c.Request.URL.Query(), MasterValueListSpec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	values, count, err := h.service.ListValues(params, page_size, (page-1)*page_size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", response.NewPage(values, page, page_size, count))
+}
+
+// LookupMasterValues godoc
+// @Summary Look up the values of a master type
+// @Description Public list of the active values of an active master type, e.g. for dropdowns. Labels are in the locale from ?locale, else the request language negotiated from ?lang or Accept-Language, when available. Responses are cached.
+// @Tags masters
+// @Produce json
+// @Param type path string true "Master type code, e.g. country"
+// @Param parent query string false "Only values nested under the value with this code"
+// @Param locale query string false "Preferred locale, e.g. de"
+// @Param Accept-Language header string false "Preferred languages when ?locale and ?lang are absent"
+// @Success 200 {object} response.Envelope{data=[]LookupItem}
+// @Failure 404 {object} response.ErrorEnvelope "Master type not found"
+// @Failure 500 {object} response.ErrorEnvelope "Internal server error"
+// @Router /masters/{type} [get]
+func (h *MasterHandler) LookupMasterValues(c *gin.Context) {
+	items, err := h.service.Lookup(c.Param("type"), localization.RequestLocale(c), c.Query("parent"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.service.cfg.MasterCacheTTL.Seconds())))
+	c.Header("Vary", "Accept-Language")
+	response.Success(c, http.StatusOK, "details_fetched", items)
+}
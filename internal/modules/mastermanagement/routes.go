@@ -0,0 +1,47 @@
+package mastermanagement
+
+import (
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/modules"
+	"goUniAdmin/internal/services/middleware"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// masterModule implements the Module interface
+type masterModule struct {
+	handler *MasterHandler
+}
+
+// RegisterRoutes sets up the master type and value routes and the public lookup route
+func (m *masterModule) RegisterRoutes(group *gin.RouterGroup, cfg *config.Config, db *db.DB) {
+	typeGroup := group.Group("/master-types")
+	typeGroup.Use(middleware.AuthMiddleware(cfg))
+	typeGroup.GET("", middleware.RequirePermission("masters:read"), m.handler.ListMasterTypes)
+	typeGroup.POST("", middleware.RequirePermission("masters:create"), m.handler.CreateMasterType)
+	typeGroup.GET("/:id", middleware.RequirePermission("masters:read"), m.handler.GetMasterType)
+	typeGroup.PUT("/:id", middleware.RequirePermission("masters:update"), m.handler.UpdateMasterType)
+	typeGroup.DELETE("/:id", middleware.RequirePermission("masters:delete"), m.handler.DeleteMasterType)
+
+	valueGroup := group.Group("/master-values")
+	valueGroup.Use(middleware.AuthMiddleware(cfg))
+	valueGroup.GET("", middleware.RequirePermission("masters:read"), m.handler.ListMasterValues)
+	valueGroup.POST("", middleware.RequirePermission("masters:create"), m.handler.CreateMasterValue)
+	valueGroup.GET("/:id", middleware.RequirePermission("masters:read"), m.handler.GetMasterValue)
+	valueGroup.PUT("/:id", middleware.RequirePermission("masters:update"), m.handler.UpdateMasterValue)
+	valueGroup.DELETE("/:id", middleware.RequirePermission("masters:delete"), m.handler.DeleteMasterValue)
+
+	group.GET("/masters/:type", m.handler.LookupMasterValues)
+}
+
+// RegisterMasterModule registers the master management module with the given dependencies
+func RegisterMasterModule(cfg *config.Config, db *db.DB) {
+	service := NewMasterService(db, cfg)
+	handler := NewMasterHandler(service)
+
+	ServiceInstance = service
+	modules.RegisterModule(&masterModule{handler: handler})
+	slog.Info("Module registered", "module", "master")
+}
@@ -0,0 +1,65 @@
+package mastermanagement
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Master types whose values validate admin profile fields
+const (
+	TypeCountry  = "country"
+	TypeCurrency = "currency"
+	TypeTimeZone = "timezone"
+)
+
+// MasterType is a named lookup list such as "country" or a custom dropdown
+type MasterType struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"_id"`
+	Code        string    `gorm:"not null;unique" json:"code" validate:"required,max=100,slug"`
+	Name        string    `gorm:"not null" json:"name" validate:"required"`
+	Description string    `json:"description,omitempty"`
+	IsActive    bool      `gorm:"not null" json:"isActive"` // Inactive types are hidden from lookups
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"createdAt,omitempty"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updatedAt,omitempty"`
+}
+
+// MasterValue is one entry of a master type, optionally nested under a value of any type,
+// e.g. a state under its country
+type MasterValue struct {
+	ID        uuid.UUID         `gorm:"type:uuid;primaryKey" json:"_id"`
+	TypeID    uuid.UUID         `gorm:"type:uuid;not null;uniqueIndex:idx_master_values_type_code" json:"typeId" validate:"required"`
+	Code      string            `gorm:"not null;uniqueIndex:idx_master_values_type_code" json:"code" validate:"required,max=100"`        // e.g. "IN", "USD" or "Asia/Kolkata"
+	Label     string            `gorm:"not null" json:"label" validate:"required"`                                                       // Default label
+	Labels    map[string]string `gorm:"type:jsonb;serializer:json" json:"labels,omitempty" validate:"dive,keys,locale,endkeys,notblank"` // Label by locale, e.g. {"de": "Indien"}
+	ParentID  *uuid.UUID        `gorm:"type:uuid;index" json:"parentId,omitempty"`
+	SortOrder int               `gorm:"not null;default:0" json:"sortOrder"`
+	IsActive  bool              `gorm:"not null" json:"isActive"` // Inactive values are hidden from lookups and rejected by validation
+	CreatedAt time.Time         `gorm:"autoCreateTime" json:"createdAt,omitempty"`
+	UpdatedAt time.Time         `gorm:"autoUpdateTime" json:"updatedAt,omitempty"`
+}
+
+// LookupItem is a master value as served by the public lookup endpoint
+type LookupItem struct {
+	Code       string `json:"code"`
+	Label      string `json:"label"` // In the requested locale when available
+	ParentCode string `json:"parentCode,omitempty"`
+	SortOrder  int    `json:"sortOrder"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (t *MasterType) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (v *MasterValue) BeforeCreate(tx *gorm.DB) (err error) {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return
+}
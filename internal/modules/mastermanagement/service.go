@@ -0,0 +1,489 @@
+package mastermanagement
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/services/apperror"
+	localization "goUniAdmin/internal/services/common"
+	"goUniAdmin/internal/services/query"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrTypeNotFound is returned for an unknown master type
+	ErrTypeNotFound = apperror.New(http.StatusNotFound, "MASTER_TYPE_NOT_FOUND", "master type not found")
+	// ErrTypeExists is returned when creating a type whose code already exists
+	ErrTypeExists = apperror.New(http.StatusConflict, "MASTER_TYPE_EXISTS", "a master type with this code already exists")
+	// ErrTypeInUse is returned when deleting a type whose values are parents of other types' values
+	ErrTypeInUse = apperror.New(http.StatusConflict, "MASTER_TYPE_IN_USE", "values of other types are nested under this type")
+	// ErrValueNotFound is returned for an unknown master value
+	ErrValueNotFound = apperror.New(http.StatusNotFound, "MASTER_VALUE_NOT_FOUND", "master value not found")
+	// ErrValueExists is returned when a value with the same code already exists in its type
+	ErrValueExists = apperror.New(http.StatusConflict, "MASTER_VALUE_EXISTS", "a value with this code already exists in this type")
+	// ErrValueHasChildren is returned when deleting a value that other values are nested under
+	ErrValueHasChildren = apperror.New(http.StatusConflict, "MASTER_VALUE_HAS_CHILDREN", "value has child values")
+	// ErrParentNotFound is returned when a value's parent does not exist
+	ErrParentNotFound = apperror.New(http.StatusBadRequest, "PARENT_NOT_FOUND", "parent value not found")
+	// ErrParentCycle is returned when a parent would make a value its own ancestor
+	ErrParentCycle = apperror.New(http.StatusBadRequest, "PARENT_CYCLE", "parent would create a cycle")
+)
+
+// maxDepth bounds the parent chain walked when checking for cycles
+const maxDepth = 32
+
+// ServiceInstance is the master service used to validate codes from other modules
+var ServiceInstance *MasterService
+
+// MasterService manages master types and values and serves lookups from an in-process cache
+type MasterService struct {
+	db    *db.DB
+	cfg   *config.Config
+	cache *lookupCache // Shared by copies made with WithContext
+}
+
+// lookupCache holds the active values of every active type by type code. Writes through the
+// service invalidate it; the TTL bounds how long writes made by other instances go unnoticed.
+type lookupCache struct {
+	mu         sync.RWMutex
+	types      map[string][]cachedValue
+	loadedAt   time.Time
+	generation uint64 // Incremented on invalidation so a load racing a write is not kept
+}
+
+// cachedValue is an active master value with its parent's code resolved
+type cachedValue struct {
+	MasterValue
+	ParentCode string
+}
+
+// NewMasterService initializes the service with a GORM database connection and config
+func NewMasterService(db *db.DB, cfg *config.Config) *MasterService {
+	return &MasterService{
+		db:    db,
+		cfg:   cfg,
+		cache: &lookupCache{},
+	}
+}
+
+// WithContext returns a copy of the service whose queries carry ctx, e.g. audit.Context(c) so
+// the audit log attributes the writes to the request
+func (s *MasterService) WithContext(ctx context.Context) *MasterService {
+	clone := *s
+	clone.db = &db.DB{DB: s.db.WithContext(ctx)}
+	return &clone
+}
+
+// MasterTypeListSpec declares the search, filters and sorts accepted by the type list endpoint
+var MasterTypeListSpec = query.Spec{
+	SearchColumns: []string{"code", "name"},
+	Filters: map[string]query.Filter{
+		"code":     {Column: "code", Type: query.FilterIn},
+		"isActive": {Column: "is_active", Type: query.FilterBool},
+	},
+	Sorts: map[string]string{
+		"code":      "code",
+		"name":      "name",
+		"createdAt": "created_at",
+		"updatedAt": "updated_at",
+	},
+	DefaultSort: "code",
+	TieBreaker:  "id",
+}
+
+// MasterValueListSpec declares the search, filters and sorts accepted by the value list endpoint
+var MasterValueListSpec = query.Spec{
+	SearchColumns: []string{"code", "label"},
+	Filters: map[string]query.Filter{
+		"typeId":   {Column: "type_id", Type: query.FilterUUID},
+		"parentId": {Column: "parent_id", Type: query.FilterUUID},
+		"code":     {Column: "code", Type: query.FilterIn},
+		"isActive": {Column: "is_active", Type: query.FilterBool},
+	},
+	Sorts: map[string]string{
+		"sortOrder": "sort_order",
+		"code":      "code",
+		"label":     "label",
+		"createdAt": "created_at",
+		"updatedAt": "updated_at",
+	},
+	DefaultSort: "sortOrder,label",
+	TieBreaker:  "id",
+}
+
+// CreateType adds a new master type
+func (s *MasterService) CreateType(masterType MasterType) (MasterType, error) {
+	if err := ValidateMasterType(masterType); err != nil {
+		return MasterType{}, apperror.Validation(err)
+	}
+
+	var count int64
+	if err := s.db.Model(&MasterType{}).Where("code = ?", masterType.Code).Count(&count).Error; err != nil {
+		return MasterType{}, err
+	}
+	if count > 0 {
+		return MasterType{}, ErrTypeExists
+	}
+
+	if err := s.db.Create(&masterType).Error; err != nil {
+		return MasterType{}, err
+	}
+	s.invalidate()
+	return masterType, nil
+}
+
+// ReadType retrieves a master type by ID
+func (s *MasterService) ReadType(id uuid.UUID) (MasterType, error) {
+	var masterType MasterType
+	if err := s.db.Where("id = ?", id).First(&masterType).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return MasterType{}, ErrTypeNotFound
+		}
+		return MasterType{}, err
+	}
+	return masterType, nil
+}
+
+// ReadTypeByCode retrieves a master type by code
+func (s *MasterService) ReadTypeByCode(code string) (MasterType, error) {
+	var masterType MasterType
+	if err := s.db.Where("code = ?", code).First(&masterType).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return MasterType{}, ErrTypeNotFound
+		}
+		return MasterType{}, err
+	}
+	return masterType, nil
+}
+
+// UpdateType changes the name, description and activation of a master type. The code is
+// fixed, since other modules look types up by code.
+func (s *MasterService) UpdateType(id uuid.UUID, updated MasterType) (MasterType, error) {
+	existing, err := s.ReadType(id)
+	if err != nil {
+		return MasterType{}, err
+	}
+
+	updated.Code = existing.Code
+	if err := ValidateMasterType(updated); err != nil {
+		return MasterType{}, apperror.Validation(err)
+	}
+
+	err = s.db.Model(&existing).Updates(map[string]interface{}{
+		"name":        updated.Name,
+		"description": updated.Description,
+		"is_active":   updated.IsActive,
+	}).Error
+	if err != nil {
+		return MasterType{}, err
+	}
+	s.invalidate()
+	return s.ReadType(id)
+}
+
+// DeleteType removes a master type together with its values
+func (s *MasterService) DeleteType(id uuid.UUID) error {
+	if _, err := s.ReadType(id); err != nil {
+		return err
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var nested int64
+		err := tx.Model(&MasterValue{}).
+			Where("type_id <> ? AND parent_id IN (?)", id, tx.Model(&MasterValue{}).Select("id").Where("type_id = ?", id)).
+			Count(&nested).Error
+		if err != nil {
+			return err
+		}
+		if nested > 0 {
+			return ErrTypeInUse
+		}
+
+		// Children first, so no row ever points at a deleted parent
+		if err := tx.Where("type_id = ? AND parent_id IS NOT NULL", id).Delete(&MasterValue{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("type_id = ?", id).Delete(&MasterValue{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&MasterType{}, "id = ?", id).Error
+	})
+	if err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+// ListTypes retrieves master types matching the search and filters, in the requested order
+func (s *MasterService) ListTypes(params query.Params, limit, offset int) ([]MasterType, int64, error) {
+	base := params.Where(s.db.Model(&MasterType{}))
+
+	var totalCount int64
+	if err := base.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var types []MasterType
+	if err := params.Order(base.Session(&gorm.Session{})).Limit(limit).Offset(offset).Find(&types).Error; err != nil {
+		return nil, 0, err
+	}
+	return types, totalCount, nil
+}
+
+// CreateValue adds a new value to a master type
+func (s *MasterService) CreateValue(value MasterValue) (MasterValue, error) {
+	value.ID = uuid.New()
+	if err := ValidateMasterValue(value); err != nil {
+		return MasterValue{}, apperror.Validation(err)
+	}
+	if _, err := s.ReadType(value.TypeID); err != nil {
+		return MasterValue{}, err
+	}
+	if err := s.checkValue(value); err != nil {
+		return MasterValue{}, err
+	}
+
+	if err := s.db.Create(&value).Error; err != nil {
+		return MasterValue{}, err
+	}
+	s.invalidate()
+	return value, nil
+}
+
+// ReadValue retrieves a master value by ID
+func (s *MasterService) ReadValue(id uuid.UUID) (MasterValue, error) {
+	var value MasterValue
+	if err := s.db.Where("id = ?", id).First(&value).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return MasterValue{}, ErrValueNotFound
+		}
+		return MasterValue{}, err
+	}
+	return value, nil
+}
+
+// ReadValueByCode retrieves a master value by the codes of its type and itself
+func (s *MasterService) ReadValueByCode(typeCode, code string) (MasterValue, error) {
+	var value MasterValue
+	err := s.db.Joins("JOIN master_types ON master_types.id = master_values.type_id").
+		Where("master_types.code = ? AND master_values.code = ?", typeCode, code).
+		First(&value).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return MasterValue{}, ErrValueNotFound
+		}
+		return MasterValue{}, err
+	}
+	return value, nil
+}
+
+// UpdateValue replaces the content of a master value. Its type is fixed.
+func (s *MasterService) UpdateValue(id uuid.UUID, updated MasterValue) (MasterValue, error) {
+	existing, err := s.ReadValue(id)
+	if err != nil {
+		return MasterValue{}, err
+	}
+
+	updated.ID = id
+	updated.TypeID = existing.TypeID
+	if err := ValidateMasterValue(updated); err != nil {
+		return MasterValue{}, apperror.Validation(err)
+	}
+	if err := s.checkValue(updated); err != nil {
+		return MasterValue{}, err
+	}
+
+	// A struct rather than a map, so labels go through their JSON serializer; the selected
+	// columns are written even when zero
+	err = s.db.Model(&existing).
+		Select("code", "label", "labels", "parent_id", "sort_order", "is_active").
+		Updates(&updated).Error
+	if err != nil {
+		return MasterValue{}, err
+	}
+	s.invalidate()
+	return s.ReadValue(id)
+}
+
+// DeleteValue removes a master value that has no child values
+func (s *MasterService) DeleteValue(id uuid.UUID) error {
+	var children int64
+	if err := s.db.Model(&MasterValue{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+		return err
+	}
+	if children > 0 {
+		return ErrValueHasChildren
+	}
+
+	result := s.db.Delete(&MasterValue{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrValueNotFound
+	}
+	s.invalidate()
+	return nil
+}
+
+// ListValues retrieves master values matching the search and filters, in the requested order
+func (s *MasterService) ListValues(params query.Params, limit, offset int) ([]MasterValue, int64, error) {
+	base := params.Where(s.db.Model(&MasterValue{}))
+
+	var totalCount int64
+	if err := base.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var values []MasterValue
+	if err := params.Order(base.Session(&gorm.Session{})).Limit(limit).Offset(offset).Find(&values).Error; err != nil {
+		return nil, 0, err
+	}
+	return values, totalCount, nil
+}
+
+// checkValue enforces that the code is unique within the type and that the parent exists and
+// is not the value itself or one of its descendants
+func (s *MasterService) checkValue(value MasterValue) error {
+	var count int64
+	err := s.db.Model(&MasterValue{}).Where("type_id = ? AND code = ? AND id <> ?", value.TypeID, value.Code, value.ID).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrValueExists
+	}
+
+	parentID := value.ParentID
+	for depth := 0; parentID != nil; depth++ {
+		if *parentID == value.ID || depth == maxDepth {
+			return ErrParentCycle
+		}
+		parent, err := s.ReadValue(*parentID)
+		if errors.Is(err, ErrValueNotFound) {
+			return ErrParentNotFound
+		} else if err != nil {
+			return err
+		}
+		parentID = parent.ParentID
+	}
+	return nil
+}
+
+// Lookup returns the active values of an active type, with labels in the given locale and
+// optionally only those nested under the value coded parentCode
+func (s *MasterService) Lookup(typeCode, locale, parentCode string) ([]LookupItem, error) {
+	types, err := s.cached()
+	if err != nil {
+		return nil, err
+	}
+	values, ok := types[typeCode]
+	if !ok {
+		return nil, ErrTypeNotFound
+	}
+
+	candidates := localization.LocaleCandidates(locale)
+	items := make([]LookupItem, 0, len(values))
+	for _, value := range values {
+		if parentCode != "" && value.ParentCode != parentCode {
+			continue
+		}
+		items = append(items, LookupItem{
+			Code:       value.Code,
+			Label:      localizedLabel(value.MasterValue, candidates),
+			ParentCode: value.ParentCode,
+			SortOrder:  value.SortOrder,
+		})
+	}
+	return items, nil
+}
+
+// IsAllowed reports whether code is an active value of the type. A type that does not exist,
+// is inactive or has no active values accepts any code, so validation starts once its list
+// has been filled in.
+func (s *MasterService) IsAllowed(typeCode, code string) (bool, error) {
+	types, err := s.cached()
+	if err != nil {
+		return false, err
+	}
+	values := types[typeCode]
+	if len(values) == 0 {
+		return true, nil
+	}
+	for _, value := range values {
+		if value.Code == code {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// localizedLabel returns the label of the first candidate locale that has one, else the default label
+func localizedLabel(value MasterValue, candidates []string) string {
+	for _, locale := range candidates {
+		if label, ok := value.Labels[locale]; ok {
+			return label
+		}
+	}
+	return value.Label
+}
+
+// cached returns the active values of every active type by type code, reloading them when
+// the cache is empty or expired
+func (s *MasterService) cached() (map[string][]cachedValue, error) {
+	s.cache.mu.RLock()
+	types, loadedAt, generation := s.cache.types, s.cache.loadedAt, s.cache.generation
+	s.cache.mu.RUnlock()
+	if types != nil && time.Since(loadedAt) < s.cfg.MasterCacheTTL {
+		return types, nil
+	}
+
+	var masterTypes []MasterType
+	if err := s.db.Where("is_active = ?", true).Find(&masterTypes).Error; err != nil {
+		return nil, err
+	}
+	var values []MasterValue
+	if err := s.db.Where("is_active = ?", true).Order("sort_order ASC").Order("label ASC").Find(&values).Error; err != nil {
+		return nil, err
+	}
+
+	codes := make(map[uuid.UUID]string, len(values))
+	for _, value := range values {
+		codes[value.ID] = value.Code
+	}
+	byType := make(map[uuid.UUID][]cachedValue, len(masterTypes))
+	for _, value := range values {
+		entry := cachedValue{MasterValue: value}
+		if value.ParentID != nil {
+			entry.ParentCode = codes[*value.ParentID]
+		}
+		byType[value.TypeID] = append(byType[value.TypeID], entry)
+	}
+	types = make(map[string][]cachedValue, len(masterTypes))
+	for _, masterType := range masterTypes {
+		types[masterType.Code] = byType[masterType.ID]
+	}
+
+	s.cache.mu.Lock()
+	if s.cache.generation == generation {
+		s.cache.types, s.cache.loadedAt = types, time.Now()
+	}
+	s.cache.mu.Unlock()
+	return types, nil
+}
+
+// invalidate drops the cache so the next lookup sees the latest values
+func (s *MasterService) invalidate() {
+	s.cache.mu.Lock()
+	s.cache.types = nil
+	s.cache.generation++
+	s.cache.mu.Unlock()
+}
@@ -0,0 +1,287 @@
+package mastermanagement
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestService returns a master service on a fresh SQLite database whose cache never expires
+func newTestService(t *testing.T) *MasterService {
+	t.Helper()
+	conn, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "masters.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := conn.AutoMigrate(&MasterType{}, &MasterValue{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return NewMasterService(&db.DB{DB: conn}, &config.Config{MasterCacheTTL: time.Hour})
+}
+
+// newType creates an active master type
+func newType(t *testing.T, s *MasterService, code string) MasterType {
+	t.Helper()
+	masterType, err := s.CreateType(MasterType{Code: code, Name: code, IsActive: true})
+	if err != nil {
+		t.Fatalf("CreateType(%s) error = %v", code, err)
+	}
+	return masterType
+}
+
+// newValue creates an active value of the type, nested under parent unless it is nil
+func newValue(t *testing.T, s *MasterService, typeID uuid.UUID, code string, parent *MasterValue) MasterValue {
+	t.Helper()
+	value := MasterValue{TypeID: typeID, Code: code, Label: code, IsActive: true}
+	if parent != nil {
+		value.ParentID = &parent.ID
+	}
+	created, err := s.CreateValue(value)
+	if err != nil {
+		t.Fatalf("CreateValue(%s) error = %v", code, err)
+	}
+	return created
+}
+
+func TestParentCycle(t *testing.T) {
+	s := newTestService(t)
+	region := newType(t, s, "region")
+	a := newValue(t, s, region.ID, "A", nil)
+	b := newValue(t, s, region.ID, "B", &a)
+	c := newValue(t, s, region.ID, "C", &b)
+
+	tests := []struct {
+		name    string
+		parent  uuid.UUID
+		wantErr error
+	}{
+		{"own child", b.ID, ErrParentCycle},
+		{"own grandchild", c.ID, ErrParentCycle},
+		{"missing parent", uuid.New(), ErrParentNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.ParentID = &tt.parent
+			if _, err := s.UpdateValue(a.ID, a); !errors.Is(err, tt.wantErr) {
+				t.Errorf("UpdateValue() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParentDepthLimit(t *testing.T) {
+	s := newTestService(t)
+	region := newType(t, s, "region")
+
+	// Inserted directly, since the chain is deeper than the service allows
+	chain := make([]MasterValue, maxDepth+1)
+	for i := range chain {
+		chain[i] = MasterValue{ID: uuid.New(), TypeID: region.ID, Code: fmt.Sprintf("R%d", i), Label: "Region", IsActive: true}
+		if i > 0 {
+			chain[i].ParentID = &chain[i-1].ID
+		}
+		if err := s.db.Create(&chain[i]).Error; err != nil {
+			t.Fatalf("create value: %v", err)
+		}
+	}
+
+	if _, err := s.CreateValue(MasterValue{TypeID: region.ID, Code: "LEAF1", Label: "Leaf", ParentID: &chain[maxDepth-1].ID}); err != nil {
+		t.Errorf("CreateValue() with %d ancestors error = %v", maxDepth, err)
+	}
+	_, err := s.CreateValue(MasterValue{TypeID: region.ID, Code: "LEAF2", Label: "Leaf", ParentID: &chain[maxDepth].ID})
+	if !errors.Is(err, ErrParentCycle) {
+		t.Errorf("CreateValue() with %d ancestors error = %v, want ErrParentCycle", maxDepth+1, err)
+	}
+}
+
+func TestUpdateValue(t *testing.T) {
+	s := newTestService(t)
+	country := newType(t, s, "country")
+	state := newType(t, s, "state")
+	in := newValue(t, s, country.ID, "IN", nil)
+	mh := newValue(t, s, state.ID, "MH", &in)
+
+	mh.Labels = map[string]string{"de": "Maharashtra (de)"}
+	mh.ParentID = nil
+	mh.IsActive = false
+	if _, err := s.UpdateValue(mh.ID, mh); err != nil {
+		t.Fatalf("UpdateValue() error = %v", err)
+	}
+	got, err := s.ReadValue(mh.ID)
+	if err != nil {
+		t.Fatalf("ReadValue() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Labels, mh.Labels) || got.ParentID != nil || got.IsActive {
+		t.Errorf("ReadValue() after update = %+v, want new labels, no parent and inactive", got)
+	}
+}
+
+func TestCacheDropsLoadRacingInvalidation(t *testing.T) {
+	s := newTestService(t)
+	country := newType(t, s, "country")
+	newValue(t, s, country.ID, "IN", nil)
+
+	// Invalidate once while the cache is loading, as a concurrent write would
+	raced := false
+	err := s.db.Callback().Query().After("gorm:query").Register("test:invalidate", func(*gorm.DB) {
+		if !raced {
+			raced = true
+			s.invalidate()
+		}
+	})
+	if err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+
+	items, err := s.Lookup("country", "en", "")
+	if err != nil || len(items) != 1 {
+		t.Fatalf("Lookup() = %+v, %v, want the loaded value", items, err)
+	}
+	if s.cache.types != nil {
+		t.Error("load that raced an invalidation was cached")
+	}
+
+	if _, err := s.Lookup("country", "en", ""); err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if s.cache.types == nil {
+		t.Error("load without an invalidation was not cached")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	s := newTestService(t)
+	country := newType(t, s, "country")
+	state := newType(t, s, "state")
+	in := newValue(t, s, country.ID, "IN", nil)
+	us := newValue(t, s, country.ID, "US", nil)
+	newValue(t, s, state.ID, "MH", &in)
+	newValue(t, s, state.ID, "CA", &us)
+	_, err := s.CreateValue(MasterValue{
+		TypeID:   country.ID,
+		Code:     "DE",
+		Label:    "Germany",
+		Labels:   map[string]string{"de": "Deutschland", "es": "Alemania", "es-MX": "Alemania (MX)"},
+		IsActive: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateValue(DE) error = %v", err)
+	}
+
+	t.Run("parent filter", func(t *testing.T) {
+		items, err := s.Lookup("state", "en", "IN")
+		if err != nil {
+			t.Fatalf("Lookup() error = %v", err)
+		}
+		if len(items) != 1 || items[0].Code != "MH" || items[0].ParentCode != "IN" {
+			t.Errorf("Lookup(state, IN) = %+v, want only MH", items)
+		}
+	})
+
+	labels := []struct {
+		locale string
+		want   string
+	}{
+		{"es-MX", "Alemania (MX)"},
+		{"es-AR", "Alemania"},
+		{"de", "Deutschland"},
+		{"fr", "Germany"},
+	}
+	for _, tt := range labels {
+		t.Run("label in "+tt.locale, func(t *testing.T) {
+			items, err := s.Lookup("country", tt.locale, "")
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			for _, item := range items {
+				if item.Code == "DE" && item.Label != tt.want {
+					t.Errorf("label in %s = %q, want %q", tt.locale, item.Label, tt.want)
+				}
+			}
+		})
+	}
+
+	if _, err := s.Lookup("planet", "en", ""); !errors.Is(err, ErrTypeNotFound) {
+		t.Errorf("Lookup() of an unknown type error = %v, want ErrTypeNotFound", err)
+	}
+}
+
+func TestIsAllowed(t *testing.T) {
+	s := newTestService(t)
+	country := newType(t, s, "country")
+	newValue(t, s, country.ID, "IN", nil)
+	retired := newValue(t, s, country.ID, "YU", nil)
+	retired.IsActive = false
+	if _, err := s.UpdateValue(retired.ID, retired); err != nil {
+		t.Fatalf("UpdateValue() error = %v", err)
+	}
+	newType(t, s, "currency")
+	inactive := newType(t, s, "timezone")
+	newValue(t, s, inactive.ID, "Asia/Kolkata", nil)
+	inactive.IsActive = false
+	if _, err := s.UpdateType(inactive.ID, inactive); err != nil {
+		t.Fatalf("UpdateType() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		typeCode string
+		code     string
+		want     bool
+	}{
+		{"active value", "country", "IN", true},
+		{"unlisted code", "country", "XX", false},
+		{"inactive value", "country", "YU", false},
+		{"type without values", "currency", "XYZ", true},
+		{"inactive type", "timezone", "Mars/Olympus", true},
+		{"unknown type", "planet", "Mars", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.IsAllowed(tt.typeCode, tt.code)
+			if err != nil {
+				t.Fatalf("IsAllowed() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsAllowed(%s, %s) = %v, want %v", tt.typeCode, tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteTypeInUse(t *testing.T) {
+	s := newTestService(t)
+	country := newType(t, s, "country")
+	state := newType(t, s, "state")
+	in := newValue(t, s, country.ID, "IN", nil)
+	newValue(t, s, state.ID, "MH", &in)
+
+	if err := s.DeleteType(country.ID); !errors.Is(err, ErrTypeInUse) {
+		t.Fatalf("DeleteType() of a parent type error = %v, want ErrTypeInUse", err)
+	}
+	if _, err := s.ReadValue(in.ID); err != nil {
+		t.Errorf("rejected delete removed a value: %v", err)
+	}
+
+	for _, masterType := range []MasterType{state, country} {
+		if err := s.DeleteType(masterType.ID); err != nil {
+			t.Fatalf("DeleteType(%s) error = %v", masterType.Code, err)
+		}
+	}
+	var remaining []MasterValue
+	s.db.Find(&remaining)
+	if !reflect.DeepEqual(remaining, []MasterValue{}) {
+		t.Errorf("values after deleting their types = %+v", remaining)
+	}
+}
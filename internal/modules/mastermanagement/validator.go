@@ -0,0 +1,50 @@
+package mastermanagement
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"goUniAdmin/internal/services/validators"
+
+	"github.com/google/uuid"
+)
+
+// valueCodePattern matches value codes such as "IN", "USD" or "America/New_York"
+var valueCodePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_+./-]*$`)
+
+// ValidateMasterType validates the master type against the rules in its validate tags
+func ValidateMasterType(masterType MasterType) error {
+	return validators.Struct(masterType).Err()
+}
+
+// ValidateMasterValue validates the master value against the rules in its validate tags, and
+// checks its code and that it is not its own parent
+func ValidateMasterValue(value MasterValue) error {
+	errs := validators.Struct(value)
+	if value.Code != "" && !valueCodePattern.MatchString(value.Code) {
+		errs.Add("code", "master_code", "")
+	}
+	if value.ParentID != nil && *value.ParentID == value.ID {
+		errs.Add("parentId", "not_self", "")
+	}
+	return errs.Err()
+}
+
+// ValidateValueListQuery checks the list parameters that Parse cannot, so a malformed type or
+// parent ID is reported as a bad request rather than a database error
+func ValidateValueListQuery(values url.Values) error {
+	var errs validators.Errors
+	for _, param := range []string{"typeId", "parentId"} {
+		for _, raw := range strings.Split(values.Get(param), ",") {
+			if raw = strings.TrimSpace(raw); raw == "" {
+				continue
+			}
+			if _, err := uuid.Parse(raw); err != nil {
+				errs.Add(param, "uuid", "")
+				break
+			}
+		}
+	}
+	return errs.Err()
+}
@@ -3,6 +3,7 @@ package modules
 import (
 	"goUniAdmin/internal/config"
 	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/services/middleware"
 
 	"github.com/gin-gonic/gin"
 )
@@ -25,9 +26,11 @@ func Modules() []Module {
 	return modules
 }
 
-// InitializeModules initializes all registered modules under /api prefix
+// InitializeModules initializes all registered modules under /api prefix. Each request gets a
+// negotiated language, and errors handlers record with c.Error are rendered as the standard
+// error envelope.
 func InitializeModules(router *gin.Engine, cfg *config.Config, db *db.DB) {
-	apiGroup := router.Group("/api")
+	apiGroup := router.Group("/api", middleware.Language(), middleware.ErrorHandler())
 	{
 		for _, m := range modules {
 			m.RegisterRoutes(apiGroup, cfg, db)
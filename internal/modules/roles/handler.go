@@ -0,0 +1,308 @@
+package roles
+
+import (
+	"net/http"
+	"strconv"
+
+	"goUniAdmin/internal/modules/audit"
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/middleware"
+	"goUniAdmin/internal/services/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RoleHandler handles HTTP requests for role CRUD and assignment
+type RoleHandler struct {
+	service *RoleService
+}
+
+// NewRoleHandler creates a new handler with the service
+func NewRoleHandler(service *RoleService) *RoleHandler {
+	return &RoleHandler{service: service}
+}
+
+// RoleRequest defines the request body for creating or updating a role
+type RoleRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Permissions []string `json:"permissions"`
+}
+
+// AssignRolesRequest defines the request body for assigning roles to an admin
+type AssignRolesRequest struct {
+	RoleIDs []uuid.UUID `json:"roleIds"`
+}
+
+// CreateRole godoc
+// @Summary Create a new role
+// @Description Creates a role with a set of permission strings such as "admins:delete"
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param role body RoleRequest true "Role data"
+// @Success 201 {object} response.Envelope{data=Role}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body or validation error"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden, or the role carries permissions the caller does not hold"
+// @Router /roles [post]
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	service, err := h.callerService(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	created, err := service.Create(Role{Name: req.Name, Description: req.Description, Permissions: req.Permissions})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "role_created", created)
+}
+
+// GetRole godoc
+// @Summary Get a role by ID
+// @Description Retrieves a role and its permissions by UUID
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Role ID"
+// @Success 200 {object} response.Envelope{data=Role}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Role not found"
+// @Router /roles/{id} [get]
+func (h *RoleHandler) GetRole(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	role, err := h.service.Read(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", role)
+}
+
+// UpdateRole godoc
+// @Summary Update a role
+// @Description Updates a role and replaces its permissions. The permissions of system roles cannot be changed.
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Role ID"
+// @Param role body RoleRequest true "Updated role data"
+// @Success 200 {object} response.Envelope{data=Role}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID or request body"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden, or the role carries permissions the caller does not hold"
+// @Failure 409 {object} response.ErrorEnvelope "System role renamed or its permissions changed"
+// @Router /roles/{id} [put]
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	var req RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	service, err := h.callerService(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	updated, err := service.Update(id, Role{Name: req.Name, Description: req.Description, Permissions: req.Permissions})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "updated_successfully", updated)
+}
+
+// DeleteRole godoc
+// @Summary Delete a role
+// @Description Deletes a role and removes it from every admin. System roles cannot be deleted.
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Role ID"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID or system role"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden, or the role carries permissions the caller does not hold"
+// @Router /roles/{id} [delete]
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	service, err := h.callerService(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := service.Delete(id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "role_deleted", nil)
+}
+
+// ListRoles godoc
+// @Summary List all roles
+// @Description Retrieves a paginated list of roles with their permissions
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} response.Envelope{data=response.Page{list=[]Role}}
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 500 {object} response.ErrorEnvelope "Internal server error"
+// @Router /roles [get]
+func (h *RoleHandler) ListRoles(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	page_size, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || page_size < 1 {
+		page_size = 10
+	}
+
+	roles, count, err := h.service.List(page_size, (page-1)*page_size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", response.NewPage(roles, page, page_size, count))
+}
+
+// ListPermissions godoc
+// @Summary List known permissions
+// @Description Retrieves every permission string that guards an API route
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Envelope{data=[]string}
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Router /roles/permissions [get]
+func (h *RoleHandler) ListPermissions(c *gin.Context) {
+	response.Success(c, http.StatusOK, "details_fetched", middleware.RegisteredPermissions())
+}
+
+// GetAdminRoles godoc
+// @Summary Get the roles of an admin
+// @Description Retrieves the roles assigned to an admin
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Admin ID"
+// @Success 200 {object} response.Envelope{data=[]Role}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Router /admins/{id}/roles [get]
+func (h *RoleHandler) GetAdminRoles(c *gin.Context) {
+	adminID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	roles, err := h.service.AdminRoles(adminID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", roles)
+}
+
+// AssignAdminRoles godoc
+// @Summary Assign roles to an admin
+// @Description Replaces the roles assigned to an admin. Roles added or removed must not carry permissions the caller does not hold.
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Admin ID"
+// @Param roles body AssignRolesRequest true "Role IDs"
+// @Success 200 {object} response.Envelope{data=[]Role}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID or request body"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden, or the role carries permissions the caller does not hold"
+// @Router /admins/{id}/roles [put]
+func (h *RoleHandler) AssignAdminRoles(c *gin.Context) {
+	adminID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	var req AssignRolesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	service, err := h.callerService(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := service.AssignRoles(adminID, req.RoleIDs); err != nil {
+		c.Error(err)
+		return
+	}
+
+	roles, err := h.service.AdminRoles(adminID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "roles_assigned", roles)
+}
+
+// callerService returns the service acting on behalf of the authenticated admin, with writes
+// attributed to the request in the audit log
+func (h *RoleHandler) callerService(c *gin.Context) (*RoleService, error) {
+	callerID, err := uuid.Parse(c.GetString("adminID"))
+	if err != nil {
+		return nil, apperror.Unauthorized("invalid admin ID in token")
+	}
+	return h.service.WithContext(audit.Context(c)).WithCaller(callerID), nil
+}
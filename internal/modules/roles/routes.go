@@ -0,0 +1,47 @@
+package roles
+
+import (
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/modules"
+	"goUniAdmin/internal/services/middleware"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rolesModule implements the Module interface
+type rolesModule struct {
+	handler *RoleHandler
+}
+
+// RegisterRoutes sets up the role routes
+func (m *rolesModule) RegisterRoutes(group *gin.RouterGroup, cfg *config.Config, db *db.DB) {
+	roleGroup := group.Group("/roles")
+	roleGroup.Use(middleware.AuthMiddleware(cfg))
+	roleGroup.GET("", middleware.RequirePermission("roles:read"), m.handler.ListRoles)
+	roleGroup.POST("", middleware.RequirePermission("roles:create"), m.handler.CreateRole)
+	roleGroup.GET("/permissions", middleware.RequirePermission("roles:read"), m.handler.ListPermissions)
+	roleGroup.GET("/:id", middleware.RequirePermission("roles:read"), m.handler.GetRole)
+	roleGroup.PUT("/:id", middleware.RequirePermission("roles:update"), m.handler.UpdateRole)
+	roleGroup.DELETE("/:id", middleware.RequirePermission("roles:delete"), m.handler.DeleteRole)
+
+	// Role assignment lives under the admin resource it modifies
+	adminGroup := group.Group("/admins")
+	adminGroup.Use(middleware.AuthMiddleware(cfg))
+	adminGroup.GET("/:id/roles", middleware.RequirePermission("roles:read"), m.handler.GetAdminRoles)
+	adminGroup.PUT("/:id/roles", middleware.RequirePermission("roles:assign"), m.handler.AssignAdminRoles)
+}
+
+// RegisterRolesModule registers the roles module with the given dependencies
+func RegisterRolesModule(cfg *config.Config, db *db.DB) {
+	service := NewRoleService(db, cfg)
+	if err := service.EnsureSystemRoles(); err != nil {
+		slog.Error("Failed to create system roles", "error", err)
+	}
+
+	handler := NewRoleHandler(service)
+	middleware.PermissionCheckerInstance = service
+	modules.RegisterModule(&rolesModule{handler: handler})
+	slog.Info("Module registered", "module", "roles")
+}
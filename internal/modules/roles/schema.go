@@ -0,0 +1,46 @@
+package roles
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SuperAdminRole is the name of the built-in role that is granted every permission
+const SuperAdminRole = "super_admin"
+
+// WildcardPermission grants every permission when assigned to a role
+const WildcardPermission = "*"
+
+// Role represents a named set of permissions that can be assigned to admins
+type Role struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"_id"`
+	Name        string    `gorm:"not null;unique" json:"name" validate:"required"`
+	Description string    `json:"description,omitempty"`
+	IsSystem    bool      `gorm:"default:false" json:"isSystem"` // System roles cannot be deleted
+	Permissions []string  `gorm:"-" json:"permissions"`          // Loaded from role_permissions
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"createdAt,omitempty"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updatedAt,omitempty"`
+}
+
+// RolePermission grants a permission string such as "admins:delete" to a role
+type RolePermission struct {
+	RoleID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"roleId"`
+	Permission string    `gorm:"primaryKey" json:"permission"`
+}
+
+// AdminRole assigns a role to an admin
+type AdminRole struct {
+	AdminID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"adminId"`
+	RoleID    uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"roleId"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt,omitempty"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (r *Role) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,471 @@
+package roles
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/services/apperror"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrRoleNotFound is returned for an unknown role ID
+	ErrRoleNotFound = apperror.New(http.StatusNotFound, "ROLE_NOT_FOUND", "role not found")
+	// ErrRoleExists is returned when another role has the same name
+	ErrRoleExists = apperror.New(http.StatusConflict, "ROLE_EXISTS", "role name already exists")
+	// ErrSystemRole is returned when renaming or deleting a built-in role
+	ErrSystemRole = apperror.New(http.StatusConflict, "SYSTEM_ROLE_PROTECTED", "system roles cannot be renamed or deleted")
+	// ErrSystemRolePermissions is returned when changing the permissions of a built-in role
+	ErrSystemRolePermissions = apperror.New(http.StatusConflict, "SYSTEM_ROLE_PROTECTED", "system role permissions cannot be changed")
+	// ErrPermissionEscalation is returned when an admin grants or edits permissions they do not hold
+	ErrPermissionEscalation = apperror.New(http.StatusForbidden, "PERMISSION_ESCALATION", "you cannot grant permissions you do not have")
+)
+
+// RoleService manages roles, their permissions and admin assignments
+type RoleService struct {
+	db     *db.DB
+	cfg    *config.Config
+	caller uuid.UUID // Admin acting through the API; uuid.Nil for internal callers such as the seeder
+}
+
+// NewRoleService initializes the service with a GORM database connection and config
+func NewRoleService(db *db.DB, cfg *config.Config) *RoleService {
+	return &RoleService{
+		db:  db,
+		cfg: cfg,
+	}
+}
+
+// WithContext returns a copy of the service whose queries carry ctx, e.g. audit.Context(c) so
+// the audit log attributes the writes to the request
+func (s *RoleService) WithContext(ctx context.Context) *RoleService {
+	clone := *s
+	clone.db = &db.DB{DB: s.db.WithContext(ctx)}
+	return &clone
+}
+
+// WithCaller returns a copy of the service acting on behalf of an admin. Creating, editing,
+// deleting and assigning roles then requires the admin to hold every permission involved.
+func (s *RoleService) WithCaller(adminID uuid.UUID) *RoleService {
+	clone := *s
+	clone.caller = adminID
+	return &clone
+}
+
+// Create adds a new role with its permissions
+func (s *RoleService) Create(role Role) (Role, error) {
+	if err := ValidateRole(role); err != nil {
+		return Role{}, apperror.Validation(err)
+	}
+	if err := s.checkGrantable(role.Permissions); err != nil {
+		return Role{}, err
+	}
+
+	var count int64
+	if err := s.db.Model(&Role{}).Where("name = ?", role.Name).Count(&count).Error; err != nil {
+		return Role{}, err
+	}
+	if count > 0 {
+		return Role{}, ErrRoleExists
+	}
+
+	role.IsSystem = false // System roles are only created internally
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&role).Error; err != nil {
+			return err
+		}
+		return replacePermissions(tx, role.ID, role.Permissions)
+	})
+	if err != nil {
+		return Role{}, err
+	}
+	return role, nil
+}
+
+// Read retrieves a role by ID together with its permissions
+func (s *RoleService) Read(id uuid.UUID) (Role, error) {
+	var role Role
+	if err := s.db.Where("id = ?", id).First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Role{}, ErrRoleNotFound
+		}
+		return Role{}, err
+	}
+
+	permissions, err := s.permissions(role.ID)
+	if err != nil {
+		return Role{}, err
+	}
+	role.Permissions = permissions
+	return role, nil
+}
+
+// Update modifies a role and replaces its permissions. The permissions of system roles are fixed.
+func (s *RoleService) Update(id uuid.UUID, updated Role) (Role, error) {
+	if err := ValidateRole(updated); err != nil {
+		return Role{}, apperror.Validation(err)
+	}
+
+	existing, err := s.Read(id)
+	if err != nil {
+		return Role{}, err
+	}
+	if existing.IsSystem && updated.Name != existing.Name {
+		return Role{}, ErrSystemRole
+	}
+	if existing.IsSystem && !samePermissions(existing.Permissions, updated.Permissions) {
+		return Role{}, ErrSystemRolePermissions
+	}
+	if err := s.checkGrantable(append(existing.Permissions, updated.Permissions...)); err != nil {
+		return Role{}, err
+	}
+
+	var count int64
+	if err := s.db.Model(&Role{}).Where("name = ? AND id <> ?", updated.Name, id).Count(&count).Error; err != nil {
+		return Role{}, err
+	}
+	if count > 0 {
+		return Role{}, ErrRoleExists
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Role{ID: id}).Updates(map[string]interface{}{
+			"name":        updated.Name,
+			"description": updated.Description,
+		}).Error; err != nil {
+			return err
+		}
+		return replacePermissions(tx, id, updated.Permissions)
+	})
+	if err != nil {
+		return Role{}, err
+	}
+	return s.Read(id)
+}
+
+// Delete removes a role, its permissions and its admin assignments
+func (s *RoleService) Delete(id uuid.UUID) error {
+	role, err := s.Read(id)
+	if err != nil {
+		return err
+	}
+	if role.IsSystem {
+		return ErrSystemRole
+	}
+	if err := s.checkGrantable(role.Permissions); err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", id).Delete(&RolePermission{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("role_id = ?", id).Delete(&AdminRole{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&Role{}, "id = ?", id).Error
+	})
+}
+
+// List returns roles ordered by name
+func (s *RoleService) List(limit, offset int) ([]Role, int64, error) {
+	var roles []Role
+	if err := s.db.Order("name ASC").Limit(limit).Offset(offset).Find(&roles).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var totalCount int64
+	if err := s.db.Model(&Role{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	for i := range roles {
+		permissions, err := s.permissions(roles[i].ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		roles[i].Permissions = permissions
+	}
+	return roles, totalCount, nil
+}
+
+// ReadByName retrieves a role by its unique name together with its permissions
+func (s *RoleService) ReadByName(name string) (Role, error) {
+	var role Role
+	if err := s.db.Where("name = ?", name).First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Role{}, ErrRoleNotFound
+		}
+		return Role{}, err
+	}
+
+	permissions, err := s.permissions(role.ID)
+	if err != nil {
+		return Role{}, err
+	}
+	role.Permissions = permissions
+	return role, nil
+}
+
+// AdminRoles returns the roles assigned to an admin
+func (s *RoleService) AdminRoles(adminID uuid.UUID) ([]Role, error) {
+	var roles []Role
+	err := s.db.Joins("JOIN admin_roles ON admin_roles.role_id = roles.id").
+		Where("admin_roles.admin_id = ?", adminID).
+		Order("roles.name ASC").
+		Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range roles {
+		permissions, err := s.permissions(roles[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		roles[i].Permissions = permissions
+	}
+	return roles, nil
+}
+
+// AssignRoles replaces the set of roles assigned to an admin. Roles added or removed must not
+// carry permissions the caller lacks, so a caller can neither escalate nor strip a stronger admin.
+func (s *RoleService) AssignRoles(adminID uuid.UUID, roleIDs []uuid.UUID) error {
+	if err := s.checkRolesExist(roleIDs); err != nil {
+		return err
+	}
+
+	if s.caller != uuid.Nil {
+		var current []uuid.UUID
+		if err := s.db.Model(&AdminRole{}).Where("admin_id = ?", adminID).Pluck("role_id", &current).Error; err != nil {
+			return err
+		}
+		if err := s.checkRolesGrantable(symmetricDifference(current, uniqueIDs(roleIDs))); err != nil {
+			return err
+		}
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("admin_id = ?", adminID).Delete(&AdminRole{}).Error; err != nil {
+			return err
+		}
+		for _, roleID := range uniqueIDs(roleIDs) {
+			if err := tx.Create(&AdminRole{AdminID: adminID, RoleID: roleID}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CheckAssignable returns ErrRoleNotFound unless every role exists, and ErrPermissionEscalation
+// unless the caller holds every permission of the roles, e.g. before roles are promised in an
+// invitation
+func (s *RoleService) CheckAssignable(roleIDs []uuid.UUID) error {
+	if err := s.checkRolesExist(roleIDs); err != nil {
+		return err
+	}
+	return s.checkRolesGrantable(roleIDs)
+}
+
+// CheckManageable returns ErrPermissionEscalation unless the caller holds every permission of
+// the admin's roles, so a caller cannot change, deactivate, delete or unlock a stronger admin
+func (s *RoleService) CheckManageable(adminID uuid.UUID) error {
+	if s.caller == uuid.Nil {
+		return nil
+	}
+	var roleIDs []uuid.UUID
+	if err := s.db.Model(&AdminRole{}).Where("admin_id = ?", adminID).Pluck("role_id", &roleIDs).Error; err != nil {
+		return err
+	}
+	return s.checkRolesGrantable(roleIDs)
+}
+
+// GrantRole adds a single role to an admin, keeping the roles already assigned
+func (s *RoleService) GrantRole(adminID, roleID uuid.UUID) error {
+	entry := AdminRole{AdminID: adminID, RoleID: roleID}
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entry).Error
+}
+
+// HasPermission reports whether any of the admin's roles grants the permission,
+// either directly, through "resource:*" or through the "*" wildcard
+func (s *RoleService) HasPermission(adminID uuid.UUID, permission string) (bool, error) {
+	candidates := []string{permission, WildcardPermission}
+	if resource, _, ok := strings.Cut(permission, ":"); ok {
+		candidates = append(candidates, resource+":*")
+	}
+
+	var count int64
+	err := s.db.Model(&RolePermission{}).
+		Joins("JOIN admin_roles ON admin_roles.role_id = role_permissions.role_id").
+		Where("admin_roles.admin_id = ? AND role_permissions.permission IN ?", adminID, candidates).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// checkGrantable returns ErrPermissionEscalation unless the caller holds every permission.
+// Internal callers without a caller are not checked.
+func (s *RoleService) checkGrantable(permissions []string) error {
+	if s.caller == uuid.Nil || len(permissions) == 0 {
+		return nil
+	}
+
+	var held []string
+	err := s.db.Model(&RolePermission{}).
+		Joins("JOIN admin_roles ON admin_roles.role_id = role_permissions.role_id").
+		Where("admin_roles.admin_id = ?", s.caller).
+		Pluck("role_permissions.permission", &held).Error
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range permissions {
+		if !grants(held, permission) {
+			return ErrPermissionEscalation
+		}
+	}
+	return nil
+}
+
+// checkRolesExist returns ErrRoleNotFound unless every role exists
+func (s *RoleService) checkRolesExist(roleIDs []uuid.UUID) error {
+	if len(roleIDs) == 0 {
+		return nil
+	}
+	var count int64
+	if err := s.db.Model(&Role{}).Where("id IN ?", roleIDs).Count(&count).Error; err != nil {
+		return err
+	}
+	if count != int64(len(uniqueIDs(roleIDs))) {
+		return ErrRoleNotFound
+	}
+	return nil
+}
+
+// checkRolesGrantable returns ErrPermissionEscalation unless the caller holds every permission
+// of the roles
+func (s *RoleService) checkRolesGrantable(roleIDs []uuid.UUID) error {
+	if s.caller == uuid.Nil || len(roleIDs) == 0 {
+		return nil
+	}
+	var permissions []string
+	if err := s.db.Model(&RolePermission{}).Where("role_id IN ?", roleIDs).Pluck("permission", &permissions).Error; err != nil {
+		return err
+	}
+	return s.checkGrantable(permissions)
+}
+
+// grants reports whether the held permissions cover a permission. "*" covers everything and
+// "resource:*" covers every action on the resource, including "resource:*" itself.
+func grants(held []string, permission string) bool {
+	for _, h := range held {
+		if h == WildcardPermission || h == permission {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(h, "*"); ok && strings.HasSuffix(prefix, ":") && strings.HasPrefix(permission, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// samePermissions reports whether two permission lists hold the same set
+func samePermissions(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// symmetricDifference returns the IDs found in exactly one of the two lists
+func symmetricDifference(a, b []uuid.UUID) []uuid.UUID {
+	inA := make(map[uuid.UUID]struct{}, len(a))
+	for _, id := range a {
+		inA[id] = struct{}{}
+	}
+	inB := make(map[uuid.UUID]struct{}, len(b))
+	for _, id := range b {
+		inB[id] = struct{}{}
+	}
+
+	var diff []uuid.UUID
+	for id := range inA {
+		if _, ok := inB[id]; !ok {
+			diff = append(diff, id)
+		}
+	}
+	for id := range inB {
+		if _, ok := inA[id]; !ok {
+			diff = append(diff, id)
+		}
+	}
+	return diff
+}
+
+// EnsureSystemRoles creates the built-in roles if they do not exist yet
+func (s *RoleService) EnsureSystemRoles() error {
+	var role Role
+	err := s.db.Where("name = ?", SuperAdminRole).First(&role).Error
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		role = Role{Name: SuperAdminRole, Description: "Full access to every feature", IsSystem: true}
+		if err := tx.Create(&role).Error; err != nil {
+			return err
+		}
+		return replacePermissions(tx, role.ID, []string{WildcardPermission})
+	})
+}
+
+// permissions returns the permission strings granted to a role
+func (s *RoleService) permissions(roleID uuid.UUID) ([]string, error) {
+	permissions := []string{}
+	err := s.db.Model(&RolePermission{}).
+		Where("role_id = ?", roleID).
+		Order("permission ASC").
+		Pluck("permission", &permissions).Error
+	return permissions, err
+}
+
+// replacePermissions swaps the permissions of a role inside a transaction
+func replacePermissions(tx *gorm.DB, roleID uuid.UUID, permissions []string) error {
+	if err := tx.Where("role_id = ?", roleID).Delete(&RolePermission{}).Error; err != nil {
+		return err
+	}
+	for _, p := range permissions {
+		entry := RolePermission{RoleID: roleID, Permission: p}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entry).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uniqueIDs removes duplicate IDs while keeping their order
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	var unique []uuid.UUID
+	for _, id := range ids {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
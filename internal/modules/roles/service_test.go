@@ -0,0 +1,212 @@
+package roles
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestService returns a role service on a fresh SQLite database with the system roles
+func newTestService(t *testing.T) *RoleService {
+	t.Helper()
+	conn, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "roles.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := conn.AutoMigrate(&Role{}, &RolePermission{}, &AdminRole{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	service := NewRoleService(&db.DB{DB: conn}, &config.Config{})
+	if err := service.EnsureSystemRoles(); err != nil {
+		t.Fatalf("EnsureSystemRoles() error = %v", err)
+	}
+	return service
+}
+
+// newTestRole stores a role with the permissions and grants it to the admins
+func newTestRole(t *testing.T, service *RoleService, name string, permissions []string, adminIDs ...uuid.UUID) Role {
+	t.Helper()
+	role, err := service.Create(Role{Name: name, Permissions: permissions})
+	if err != nil {
+		t.Fatalf("Create(%s) error = %v", name, err)
+	}
+	for _, adminID := range adminIDs {
+		if err := service.GrantRole(adminID, role.ID); err != nil {
+			t.Fatalf("GrantRole() error = %v", err)
+		}
+	}
+	return role
+}
+
+func TestHasPermission(t *testing.T) {
+	service := newTestService(t)
+	editor, superAdmin, nobody := uuid.New(), uuid.New(), uuid.New()
+	newTestRole(t, service, "editor", []string{"pages:*", "admins:read"}, editor)
+	super, err := service.ReadByName(SuperAdminRole)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := service.GrantRole(superAdmin, super.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		adminID    uuid.UUID
+		permission string
+		want       bool
+	}{
+		{"exact", editor, "admins:read", true},
+		{"other action", editor, "admins:delete", false},
+		{"resource wildcard", editor, "pages:delete", true},
+		{"resource wildcard is not a prefix", editor, "pagesx:delete", false},
+		{"global wildcard", superAdmin, "roles:assign", true},
+		{"no roles", nobody, "admins:read", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.HasPermission(tt.adminID, tt.permission)
+			if err != nil || got != tt.want {
+				t.Errorf("HasPermission(%q) = %v, %v, want %v", tt.permission, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrants(t *testing.T) {
+	tests := []struct {
+		held       []string
+		permission string
+		want       bool
+	}{
+		{[]string{"*"}, "roles:assign", true},
+		{[]string{"admins:read"}, "admins:read", true},
+		{[]string{"admins:read"}, "admins:delete", false},
+		{[]string{"admins:*"}, "admins:delete", true},
+		{[]string{"admins:*"}, "admins:*", true},
+		{[]string{"admins:*"}, "*", false},
+		{[]string{"admins:read"}, "admins:*", false},
+		{nil, "admins:read", false},
+	}
+	for _, tt := range tests {
+		if got := grants(tt.held, tt.permission); got != tt.want {
+			t.Errorf("grants(%q, %q) = %v, want %v", tt.held, tt.permission, got, tt.want)
+		}
+	}
+}
+
+func TestCreateChecksGrantable(t *testing.T) {
+	service := newTestService(t)
+	caller := uuid.New()
+	newTestRole(t, service, "manager", []string{"admins:*", "roles:create"}, caller)
+	asCaller := service.WithCaller(caller)
+
+	tests := []struct {
+		name        string
+		permissions []string
+		wantErr     error
+	}{
+		{"held permission", []string{"admins:read"}, nil},
+		{"covered by a resource wildcard", []string{"admins:delete", "admins:*"}, nil},
+		{"permission not held", []string{"admins:read", "roles:assign"}, ErrPermissionEscalation},
+		{"global wildcard", []string{"*"}, ErrPermissionEscalation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := asCaller.Create(Role{Name: tt.name, Permissions: tt.permissions})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Internal callers such as the seeder are not checked
+	if _, err := service.Create(Role{Name: "internal", Permissions: []string{"*"}}); err != nil {
+		t.Errorf("Create() without a caller error = %v", err)
+	}
+}
+
+func TestAssignRolesSuperAdmin(t *testing.T) {
+	service := newTestService(t)
+	super, err := service.ReadByName(SuperAdminRole)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager, superAdmin, target := uuid.New(), uuid.New(), uuid.New()
+	viewer := newTestRole(t, service, "viewer", []string{"admins:read"}, manager)
+	newTestRole(t, service, "assigner", []string{"roles:assign"}, manager)
+	if err := service.GrantRole(superAdmin, super.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		caller  uuid.UUID
+		current []uuid.UUID
+		assign  []uuid.UUID
+		wantErr error
+	}{
+		{"grant a held role", manager, nil, []uuid.UUID{viewer.ID}, nil},
+		{"grant super_admin", manager, nil, []uuid.UUID{viewer.ID, super.ID}, ErrPermissionEscalation},
+		{"remove super_admin", manager, []uuid.UUID{super.ID}, []uuid.UUID{viewer.ID}, ErrPermissionEscalation},
+		{"keep super_admin while adding a held role", manager, []uuid.UUID{super.ID}, []uuid.UUID{super.ID, viewer.ID}, nil},
+		{"super admin grants super_admin", superAdmin, nil, []uuid.UUID{super.ID}, nil},
+		{"super admin removes super_admin", superAdmin, []uuid.UUID{super.ID}, nil, nil},
+		{"unknown role", superAdmin, nil, []uuid.UUID{uuid.New()}, ErrRoleNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := service.AssignRoles(target, tt.current); err != nil {
+				t.Fatalf("AssignRoles() setup error = %v", err)
+			}
+
+			err := service.WithCaller(tt.caller).AssignRoles(target, tt.assign)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AssignRoles() error = %v, want %v", err, tt.wantErr)
+			}
+			want := tt.assign
+			if err != nil {
+				want = tt.current
+			}
+			roles, err := service.AdminRoles(target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(roles) != len(want) {
+				t.Errorf("admin has %d roles, want %d", len(roles), len(want))
+			}
+		})
+	}
+}
+
+func TestEnsureSystemRolesIsIdempotent(t *testing.T) {
+	service := newTestService(t)
+	first, err := service.ReadByName(SuperAdminRole)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := service.EnsureSystemRoles(); err != nil {
+			t.Fatalf("EnsureSystemRoles() error = %v", err)
+		}
+	}
+
+	var count int64
+	service.db.Model(&Role{}).Where("name = ?", SuperAdminRole).Count(&count)
+	role, err := service.ReadByName(SuperAdminRole)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 || role.ID != first.ID || !role.IsSystem || len(role.Permissions) != 1 || role.Permissions[0] != WildcardPermission {
+		t.Errorf("super_admin = %+v (%d rows), want one system role with %q", role, count, WildcardPermission)
+	}
+}
@@ -0,0 +1,28 @@
+package roles
+
+import (
+	"fmt"
+	"regexp"
+
+	"goUniAdmin/internal/services/validators"
+)
+
+// permissionPattern matches "resource:action" and "resource:*" permission strings
+var permissionPattern = regexp.MustCompile(`^[a-z][a-z0-9-]*:([a-z][a-z0-9-]*|\*)$`)
+
+// ValidateRole validates the role against the rules in its validate tags and checks the format
+// of every permission
+func ValidateRole(role Role) error {
+	errs := validators.Struct(role)
+	for i, p := range role.Permissions {
+		if !isValidPermission(p) {
+			errs.Add(fmt.Sprintf("permissions[%d]", i), "permission", "")
+		}
+	}
+	return errs.Err()
+}
+
+// isValidPermission checks the permission string format
+func isValidPermission(permission string) bool {
+	return permission == WildcardPermission || permissionPattern.MatchString(permission)
+}
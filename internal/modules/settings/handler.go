@@ -0,0 +1,195 @@
+package settings
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"goUniAdmin/internal/modules/audit"
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SettingHandler handles HTTP requests for setting CRUD
+type SettingHandler struct {
+	service *SettingService
+}
+
+// NewSettingHandler creates a new handler with the service
+func NewSettingHandler(service *SettingService) *SettingHandler {
+	return &SettingHandler{service: service}
+}
+
+// SettingRequest defines the request body for creating or updating a setting
+type SettingRequest struct {
+	Key         string          `json:"key"` // Ignored on update; the key in the path is used
+	Category    string          `json:"category"`
+	Type        SettingType     `json:"type" enums:"string,number,bool,json"`
+	Value       json.RawMessage `json:"value" swaggertype:"object"`
+	Description string          `json:"description,omitempty"`
+}
+
+// toModel converts the request into a Setting
+func (r SettingRequest) toModel() Setting {
+	category := r.Category
+	if category == "" {
+		category = "general"
+	}
+	return Setting{
+		Key:         r.Key,
+		Category:    category,
+		Type:        r.Type,
+		Value:       r.Value,
+		Description: r.Description,
+	}
+}
+
+// CreateSetting godoc
+// @Summary Create a setting
+// @Description Creates a runtime-editable setting. The value must match the declared type: string, number, bool or json (an object or array).
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param setting body SettingRequest true "Setting data"
+// @Success 201 {object} response.Envelope{data=Setting}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body or validation error"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Router /settings [post]
+func (h *SettingHandler) CreateSetting(c *gin.Context) {
+	var req SettingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	created, err := h.service.WithContext(audit.Context(c)).Create(req.toModel())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "setting_created", created)
+}
+
+// GetSetting godoc
+// @Summary Get a setting by key
+// @Description Retrieves a setting by its key, e.g. site.name
+// @Tags settings
+// @Produce json
+// @Security BearerAuth
+// @Param key path string true "Setting key"
+// @Success 200 {object} response.Envelope{data=Setting}
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Setting not found"
+// @Router /settings/{key} [get]
+func (h *SettingHandler) GetSetting(c *gin.Context) {
+	setting, err := h.service.Read(c.Param("key"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", setting)
+}
+
+// UpdateSetting godoc
+// @Summary Update a setting
+// @Description Changes the category, type, value and description of a setting. Takes effect immediately without a restart.
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param key path string true "Setting key"
+// @Param setting body SettingRequest true "Updated setting data"
+// @Success 200 {object} response.Envelope{data=Setting}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body or validation error"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Setting not found"
+// @Router /settings/{key} [put]
+func (h *SettingHandler) UpdateSetting(c *gin.Context) {
+	var req SettingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	updated, err := h.service.WithContext(audit.Context(c)).Update(c.Param("key"), req.toModel())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "updated_successfully", updated)
+}
+
+// DeleteSetting godoc
+// @Summary Delete a setting
+// @Description Deletes a setting; code reading it falls back to its default value
+// @Tags settings
+// @Produce json
+// @Security BearerAuth
+// @Param key path string true "Setting key"
+// @Success 200 {object} response.Envelope
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Setting not found"
+// @Router /settings/{key} [delete]
+func (h *SettingHandler) DeleteSetting(c *gin.Context) {
+	if err := h.service.WithContext(audit.Context(c)).Delete(c.Param("key")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "setting_deleted", nil)
+}
+
+// ListSettings godoc
+// @Summary List settings
+// @Description Retrieves a paginated list of settings ordered by category and key
+// @Tags settings
+// @Produce json
+// @Security BearerAuth
+// @Param category query string false "Filter by category"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} response.Envelope{data=response.Page{list=[]Setting}}
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 500 {object} response.ErrorEnvelope "Internal server error"
+// @Router /settings [get]
+func (h *SettingHandler) ListSettings(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	page_size, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || page_size < 1 {
+		page_size = 10
+	}
+
+	settings, count, err := h.service.List(c.Query("category"), page_size, (page-1)*page_size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", response.NewPage(settings, page, page_size, count))
+}
+
+// GetSiteInfo godoc
+// @Summary Get public site information
+// @Description Public read of the site name and support email. Edits to the site.name and site.support_email settings are served immediately.
+// @Tags settings
+// @Produce json
+// @Success 200 {object} response.Envelope{data=SiteInfo}
+// @Router /site [get]
+func (h *SettingHandler) GetSiteInfo(c *gin.Context) {
+	response.Success(c, http.StatusOK, "details_fetched", h.service.SiteInfo())
+}
@@ -0,0 +1,41 @@
+package settings
+
+import (
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/modules"
+	"goUniAdmin/internal/services/email"
+	"goUniAdmin/internal/services/middleware"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// settingsModule implements the Module interface
+type settingsModule struct {
+	handler *SettingHandler
+}
+
+// RegisterRoutes sets up the setting routes and the public site information route
+func (m *settingsModule) RegisterRoutes(group *gin.RouterGroup, cfg *config.Config, db *db.DB) {
+	settingGroup := group.Group("/settings")
+	settingGroup.Use(middleware.AuthMiddleware(cfg))
+	settingGroup.GET("", middleware.RequirePermission("settings:read"), m.handler.ListSettings)
+	settingGroup.POST("", middleware.RequirePermission("settings:create"), m.handler.CreateSetting)
+	settingGroup.GET("/:key", middleware.RequirePermission("settings:read"), m.handler.GetSetting)
+	settingGroup.PUT("/:key", middleware.RequirePermission("settings:update"), m.handler.UpdateSetting)
+	settingGroup.DELETE("/:key", middleware.RequirePermission("settings:delete"), m.handler.DeleteSetting)
+
+	group.GET("/site", m.handler.GetSiteInfo)
+}
+
+// RegisterSettingsModule registers the settings module with the given dependencies
+func RegisterSettingsModule(cfg *config.Config, db *db.DB) {
+	service := NewSettingService(db, cfg)
+	handler := NewSettingHandler(service)
+
+	ServiceInstance = service
+	email.SiteSettingsInstance = service
+	modules.RegisterModule(&settingsModule{handler: handler})
+	slog.Info("Module registered", "module", "settings")
+}
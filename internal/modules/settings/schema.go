@@ -0,0 +1,46 @@
+package settings
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SettingType declares how a setting's value is validated and read
+type SettingType string
+
+// Setting types
+const (
+	TypeString SettingType = "string"
+	TypeNumber SettingType = "number"
+	TypeBool   SettingType = "bool"
+	TypeJSON   SettingType = "json" // Any JSON object or array
+)
+
+// Setting is a runtime-editable configuration value such as "site.name"
+type Setting struct {
+	ID          uuid.UUID       `gorm:"type:uuid;primaryKey" json:"_id"`
+	Key         string          `gorm:"not null;unique" json:"key" validate:"required"`
+	Category    string          `gorm:"not null;default:general;index" json:"category" validate:"required,slug"`
+	Type        SettingType     `gorm:"not null" json:"type" validate:"required,oneof=string number bool json"`
+	Value       json.RawMessage `gorm:"type:jsonb;not null" json:"value" swaggertype:"object"` // JSON encoding of a value of Type
+	Description string          `json:"description,omitempty"`
+	CreatedAt   time.Time       `gorm:"autoCreateTime" json:"createdAt,omitempty"`
+	UpdatedAt   time.Time       `gorm:"autoUpdateTime" json:"updatedAt,omitempty"`
+}
+
+// SiteInfo is the public information about the site, read from the site.* settings
+type SiteInfo struct {
+	Name         string `json:"name"`
+	SupportEmail string `json:"supportEmail,omitempty"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (s *Setting) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
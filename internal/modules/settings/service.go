@@ -0,0 +1,277 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/services/apperror"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrSettingNotFound is returned for an unknown key
+	ErrSettingNotFound = apperror.New(http.StatusNotFound, "SETTING_NOT_FOUND", "setting not found")
+	// ErrSettingExists is returned when creating a key that already exists
+	ErrSettingExists = apperror.New(http.StatusConflict, "SETTING_EXISTS", "a setting with this key already exists")
+)
+
+// ServiceInstance is the settings service used to read live values from other modules
+var ServiceInstance *SettingService
+
+// SettingService manages settings and serves them from an in-process cache
+type SettingService struct {
+	db    *db.DB
+	cfg   *config.Config
+	cache *settingsCache // Shared by copies made with WithContext
+}
+
+// settingsCache holds every setting by key. Writes through the service invalidate it; the
+// TTL bounds how long writes made by other instances go unnoticed. Stale values are kept, to
+// be served while another caller reloads them or when reloading fails.
+type settingsCache struct {
+	mu         sync.RWMutex
+	loadMu     sync.Mutex // Held by the caller reloading the values
+	values     map[string]Setting
+	loadedAt   time.Time // Zero once invalidated
+	generation uint64    // Incremented on invalidation so a load racing a write is not kept
+}
+
+// NewSettingService initializes the service with a GORM database connection and config
+func NewSettingService(db *db.DB, cfg *config.Config) *SettingService {
+	return &SettingService{
+		db:    db,
+		cfg:   cfg,
+		cache: &settingsCache{},
+	}
+}
+
+// WithContext returns a copy of the service whose queries carry ctx, e.g. audit.Context(c) so
+// the audit log attributes the writes to the request
+func (s *SettingService) WithContext(ctx context.Context) *SettingService {
+	clone := *s
+	clone.db = &db.DB{DB: s.db.WithContext(ctx)}
+	return &clone
+}
+
+// Create adds a new setting
+func (s *SettingService) Create(setting Setting) (Setting, error) {
+	if err := ValidateSetting(setting); err != nil {
+		return Setting{}, apperror.Validation(err)
+	}
+
+	var count int64
+	if err := s.db.Model(&Setting{}).Where("key = ?", setting.Key).Count(&count).Error; err != nil {
+		return Setting{}, err
+	}
+	if count > 0 {
+		return Setting{}, ErrSettingExists
+	}
+
+	if err := s.db.Create(&setting).Error; err != nil {
+		return Setting{}, err
+	}
+	s.invalidate()
+	return setting, nil
+}
+
+// Read retrieves a setting by key from the database
+func (s *SettingService) Read(key string) (Setting, error) {
+	var setting Setting
+	if err := s.db.Where("key = ?", key).First(&setting).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Setting{}, ErrSettingNotFound
+		}
+		return Setting{}, err
+	}
+	return setting, nil
+}
+
+// Update changes the category, type, value and description of a setting. The key is fixed.
+func (s *SettingService) Update(key string, updated Setting) (Setting, error) {
+	existing, err := s.Read(key)
+	if err != nil {
+		return Setting{}, err
+	}
+
+	updated.Key = key
+	if err := ValidateSetting(updated); err != nil {
+		return Setting{}, apperror.Validation(err)
+	}
+
+	err = s.db.Model(&existing).Updates(map[string]interface{}{
+		"category":    updated.Category,
+		"type":        updated.Type,
+		"value":       updated.Value,
+		"description": updated.Description,
+	}).Error
+	if err != nil {
+		return Setting{}, err
+	}
+	s.invalidate()
+	return s.Read(key)
+}
+
+// Delete removes a setting
+func (s *SettingService) Delete(key string) error {
+	result := s.db.Where("key = ?", key).Delete(&Setting{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrSettingNotFound
+	}
+	s.invalidate()
+	return nil
+}
+
+// List retrieves settings ordered by category and key, optionally limited to one category
+func (s *SettingService) List(category string, limit, offset int) ([]Setting, int64, error) {
+	query := s.db.Model(&Setting{})
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var settings []Setting
+	if err := query.Order("category ASC").Order("key ASC").Limit(limit).Offset(offset).Find(&settings).Error; err != nil {
+		return nil, 0, err
+	}
+	return settings, totalCount, nil
+}
+
+// Get returns the live value of a setting from the cache
+func (s *SettingService) Get(key string) (Setting, error) {
+	values, err := s.cached()
+	if err != nil {
+		return Setting{}, err
+	}
+	setting, ok := values[key]
+	if !ok {
+		return Setting{}, ErrSettingNotFound
+	}
+	return setting, nil
+}
+
+// GetString returns a string setting, or defaultValue if it is missing or of another type
+func (s *SettingService) GetString(key, defaultValue string) string {
+	var value string
+	if s.decode(key, TypeString, &value) != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// GetNumber returns a number setting, or defaultValue if it is missing or of another type
+func (s *SettingService) GetNumber(key string, defaultValue float64) float64 {
+	var value float64
+	if s.decode(key, TypeNumber, &value) != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// GetBool returns a bool setting, or defaultValue if it is missing or of another type
+func (s *SettingService) GetBool(key string, defaultValue bool) bool {
+	var value bool
+	if s.decode(key, TypeBool, &value) != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// GetJSON decodes a JSON setting into dest
+func (s *SettingService) GetJSON(key string, dest interface{}) error {
+	return s.decode(key, TypeJSON, dest)
+}
+
+// SiteInfo returns the live public site information. The name falls back to the configured
+// app name, as it does in emails.
+func (s *SettingService) SiteInfo() SiteInfo {
+	return SiteInfo{
+		Name:         s.GetString("site.name", s.cfg.AppName),
+		SupportEmail: s.GetString("site.support_email", ""),
+	}
+}
+
+// decode unmarshals the cached value of a setting of the expected type
+func (s *SettingService) decode(key string, settingType SettingType, dest interface{}) error {
+	setting, err := s.Get(key)
+	if err != nil {
+		return err
+	}
+	if setting.Type != settingType {
+		return errors.New("setting " + key + " is not a " + string(settingType))
+	}
+	return json.Unmarshal(setting.Value, dest)
+}
+
+// cached returns every setting by key, reloading them when the cache is empty or expired. Only
+// one caller reloads at a time; while it does, the others serve the stale values, or wait for
+// it when there are none yet. A failed reload is logged and the stale values are served.
+func (s *SettingService) cached() (map[string]Setting, error) {
+	values, fresh, _ := s.cache.current(s.cfg.SettingsCacheTTL)
+	if fresh {
+		return values, nil
+	}
+	if !s.cache.loadMu.TryLock() {
+		if values != nil {
+			return values, nil
+		}
+		s.cache.loadMu.Lock()
+	}
+	defer s.cache.loadMu.Unlock()
+
+	// Another caller may have reloaded between the check above and taking the lock
+	values, fresh, generation := s.cache.current(s.cfg.SettingsCacheTTL)
+	if fresh {
+		return values, nil
+	}
+
+	var settings []Setting
+	if err := s.db.Find(&settings).Error; err != nil {
+		if values == nil {
+			return nil, err
+		}
+		slog.ErrorContext(s.db.Statement.Context, "Failed to reload settings, serving cached values", "error", err)
+		return values, nil
+	}
+	values = make(map[string]Setting, len(settings))
+	for _, setting := range settings {
+		values[setting.Key] = setting
+	}
+
+	s.cache.mu.Lock()
+	if s.cache.generation == generation {
+		s.cache.values, s.cache.loadedAt = values, time.Now()
+	}
+	s.cache.mu.Unlock()
+	return values, nil
+}
+
+// current returns the cached values, whether they are younger than ttl, and the generation
+// they belong to
+func (c *settingsCache) current(ttl time.Duration) (map[string]Setting, bool, uint64) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.values, c.values != nil && time.Since(c.loadedAt) < ttl, c.generation
+}
+
+// invalidate expires the cache so the next read sees the latest values
+func (s *SettingService) invalidate() {
+	s.cache.mu.Lock()
+	s.cache.loadedAt = time.Time{}
+	s.cache.generation++
+	s.cache.mu.Unlock()
+}
@@ -0,0 +1,139 @@
+package settings
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/services/email"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestService returns a settings service on a fresh SQLite database whose cache never expires
+func newTestService(t *testing.T) *SettingService {
+	t.Helper()
+	conn, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "settings.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := conn.AutoMigrate(&Setting{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return NewSettingService(&db.DB{DB: conn}, &config.Config{AppName: "goUniAdmin", SettingsCacheTTL: time.Hour})
+}
+
+func TestSiteSettingsApplyWithoutRestart(t *testing.T) {
+	service := newTestService(t)
+	email.SiteSettingsInstance = service
+	t.Cleanup(func() { email.SiteSettingsInstance = nil })
+
+	sender := email.NewMemorySender()
+	mailer := email.NewMailer(sender, email.DefaultRenderer, "goUniAdmin")
+	send := func() email.Message {
+		t.Helper()
+		data := map[string]interface{}{"FirstName": "Ann", "Link": "http://admin.test", "ExpiresIn": "1 hour"}
+		if err := mailer.SendTemplate("ann@example.com", "verify_email", "en", data); err != nil {
+			t.Fatalf("SendTemplate() error = %v", err)
+		}
+		messages := sender.Messages()
+		return messages[len(messages)-1]
+	}
+
+	if info := service.SiteInfo(); info.Name != "goUniAdmin" || info.SupportEmail != "" {
+		t.Errorf("SiteInfo() without settings = %+v, want the configured app name", info)
+	}
+	if msg := send(); !strings.HasSuffix(msg.Subject, "goUniAdmin") || strings.Contains(msg.TextBody, "Questions?") {
+		t.Errorf("email without settings = %q / %q", msg.Subject, msg.TextBody)
+	}
+
+	// Reads fill the cache, so the writes below must invalidate it to be seen
+	for _, setting := range []Setting{
+		{Key: "site.name", Category: "general", Type: TypeString, Value: json.RawMessage(`"Acme Admin"`)},
+		{Key: "site.support_email", Category: "general", Type: TypeString, Value: json.RawMessage(`"help@acme.test"`)},
+	} {
+		if _, err := service.Create(setting); err != nil {
+			t.Fatalf("Create(%s) error = %v", setting.Key, err)
+		}
+	}
+	if info := service.SiteInfo(); info.Name != "Acme Admin" || info.SupportEmail != "help@acme.test" {
+		t.Errorf("SiteInfo() after create = %+v", info)
+	}
+	if msg := send(); !strings.HasSuffix(msg.Subject, "Acme Admin") || !strings.Contains(msg.TextBody, "help@acme.test") {
+		t.Errorf("email after create = %q / %q", msg.Subject, msg.TextBody)
+	}
+
+	_, err := service.Update("site.name", Setting{Category: "general", Type: TypeString, Value: json.RawMessage(`"Acme Console"`)})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if info := service.SiteInfo(); info.Name != "Acme Console" {
+		t.Errorf("SiteInfo().Name after update = %q, want %q", info.Name, "Acme Console")
+	}
+	if msg := send(); !strings.HasSuffix(msg.Subject, "Acme Console") {
+		t.Errorf("email subject after update = %q", msg.Subject)
+	}
+}
+
+// expire makes the cached settings older than the TTL
+func expire(s *SettingService) {
+	s.cache.mu.Lock()
+	s.cache.loadedAt = time.Now().Add(-2 * s.cfg.SettingsCacheTTL)
+	s.cache.mu.Unlock()
+}
+
+func TestCacheServesStaleValuesWhenReloadFails(t *testing.T) {
+	service := newTestService(t)
+	if _, err := service.Create(Setting{Key: "site.name", Category: "general", Type: TypeString, Value: json.RawMessage(`"Acme Admin"`)}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if got := service.GetString("site.name", ""); got != "Acme Admin" {
+		t.Fatalf("GetString() = %q", got)
+	}
+
+	sqlDB, err := service.db.DB.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.Close()
+	expire(service)
+	if got := service.GetString("site.name", ""); got != "Acme Admin" {
+		t.Errorf("GetString() after a failed reload = %q, want the stale value", got)
+	}
+
+	// Without values to fall back on the failure is returned
+	service.invalidate()
+	service.cache.values = nil
+	if _, err := service.Get("site.name"); err == nil {
+		t.Error("Get() with an empty cache and no database succeeded")
+	}
+}
+
+func TestCacheReloadsOnceAtATime(t *testing.T) {
+	service := newTestService(t)
+	if _, err := service.Create(Setting{Key: "site.name", Category: "general", Type: TypeString, Value: json.RawMessage(`"Acme Admin"`)}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	service.GetString("site.name", "")
+
+	// Changed behind the cache's back, as another instance would
+	service.db.Model(&Setting{}).Where("key = ?", "site.name").Update("value", json.RawMessage(`"Acme Console"`))
+	expire(service)
+
+	// While another caller reloads, the stale values are served without waiting for it
+	service.cache.loadMu.Lock()
+	if got := service.GetString("site.name", ""); got != "Acme Admin" {
+		t.Errorf("GetString() during another reload = %q, want the stale value", got)
+	}
+	service.cache.loadMu.Unlock()
+
+	if got := service.GetString("site.name", ""); got != "Acme Console" {
+		t.Errorf("GetString() after the reload = %q, want the new value", got)
+	}
+}
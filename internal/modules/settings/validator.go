@@ -0,0 +1,60 @@
+package settings
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+
+	"goUniAdmin/internal/services/validators"
+)
+
+// keyPattern matches keys such as "site.name" or "uploads.max_size_mb"
+var keyPattern = regexp.MustCompile(`^[a-z0-9]+(?:[._-][a-z0-9]+)*$`)
+
+// ValidateSetting validates the setting against the rules in its validate tags and checks that
+// the value matches its type
+func ValidateSetting(setting Setting) error {
+	errs := validators.Struct(setting)
+	if setting.Key != "" && !keyPattern.MatchString(setting.Key) {
+		errs.Add("key", "setting_key", "")
+	}
+	errs = append(errs, valueErrors(setting.Type, setting.Value)...)
+	return errs.Err()
+}
+
+// valueErrors checks that a JSON-encoded value is of the declared type. The type itself is
+// checked by its tag, so a value of an unknown type is only checked to be JSON.
+func valueErrors(settingType SettingType, value json.RawMessage) validators.Errors {
+	var errs validators.Errors
+	trimmed := bytes.TrimSpace(value)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		errs.Add("value", "required", "")
+		return errs
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(trimmed, &decoded); err != nil {
+		errs.Add("value", "json", "")
+		return errs
+	}
+
+	ok := true
+	switch settingType {
+	case TypeString:
+		_, ok = decoded.(string)
+	case TypeNumber:
+		_, ok = decoded.(float64)
+	case TypeBool:
+		_, ok = decoded.(bool)
+	case TypeJSON:
+		switch decoded.(type) {
+		case map[string]interface{}, []interface{}:
+		default:
+			ok = false
+		}
+	}
+	if !ok {
+		errs.Add("value", "value_type", string(settingType))
+	}
+	return errs
+}
@@ -0,0 +1,252 @@
+package staticpagemanagement
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"goUniAdmin/internal/modules/audit"
+	"goUniAdmin/internal/services/apperror"
+	localization "goUniAdmin/internal/services/common"
+	"goUniAdmin/internal/services/query"
+	"goUniAdmin/internal/services/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// StaticPageHandler handles HTTP requests for static page CRUD and public reads
+type StaticPageHandler struct {
+	service *StaticPageService
+}
+
+// NewStaticPageHandler creates a new handler with the service
+func NewStaticPageHandler(service *StaticPageService) *StaticPageHandler {
+	return &StaticPageHandler{service: service}
+}
+
+// StaticPageRequest defines the request body for creating or updating a static page
+type StaticPageRequest struct {
+	Slug            string     `json:"slug"`
+	Locale          string     `json:"locale"` // Defaults to en
+	Title           string     `json:"title"`
+	Body            string     `json:"body"` // HTML; unsafe markup is removed before saving
+	MetaTitle       string     `json:"metaTitle"`
+	MetaDescription string     `json:"metaDescription"`
+	MetaKeywords    string     `json:"metaKeywords"`
+	Status          string     `json:"status"`              // draft or published; defaults to draft
+	PublishAt       *time.Time `json:"publishAt,omitempty"` // Schedules a published page, e.g. 2025-01-01T00:00:00Z
+}
+
+// toModel converts the request into a StaticPage
+func (r StaticPageRequest) toModel() StaticPage {
+	page := StaticPage{
+		Slug:            r.Slug,
+		Locale:          r.Locale,
+		Title:           r.Title,
+		Body:            r.Body,
+		MetaTitle:       r.MetaTitle,
+		MetaDescription: r.MetaDescription,
+		MetaKeywords:    r.MetaKeywords,
+		Status:          r.Status,
+		PublishAt:       r.PublishAt,
+	}
+	if page.Locale == "" {
+		page.Locale = localization.DefaultLanguage
+	}
+	if page.Status == "" {
+		page.Status = StatusDraft
+	}
+	return page
+}
+
+// CreateStaticPage godoc
+// @Summary Create a static page
+// @Description Creates a page such as terms, privacy or FAQ in one locale. Unsafe HTML is removed from the body.
+// @Tags static-pages
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page body StaticPageRequest true "Static page data"
+// @Success 201 {object} response.Envelope{data=StaticPage}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body or validation error"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Router /static-pages [post]
+func (h *StaticPageHandler) CreateStaticPage(c *gin.Context) {
+	var req StaticPageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	created, err := h.service.WithContext(audit.Context(c)).Create(req.toModel())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "page_created", created)
+}
+
+// GetStaticPage godoc
+// @Summary Get a static page by ID
+// @Description Retrieves a static page by UUID, whatever its status
+// @Tags static-pages
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Page ID"
+// @Success 200 {object} response.Envelope{data=StaticPage}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Page not found"
+// @Router /static-pages/{id} [get]
+func (h *StaticPageHandler) GetStaticPage(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	page, err := h.service.Read(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", page)
+}
+
+// UpdateStaticPage godoc
+// @Summary Update a static page
+// @Description Replaces the content of a static page. Unsafe HTML is removed from the body.
+// @Tags static-pages
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Page ID"
+// @Param page body StaticPageRequest true "Updated static page data"
+// @Success 200 {object} response.Envelope{data=StaticPage}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID or request body"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Page not found"
+// @Router /static-pages/{id} [put]
+func (h *StaticPageHandler) UpdateStaticPage(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	var req StaticPageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	updated, err := h.service.WithContext(audit.Context(c)).Update(id, req.toModel())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "updated_successfully", updated)
+}
+
+// DeleteStaticPage godoc
+// @Summary Delete a static page
+// @Description Deletes a static page in one locale
+// @Tags static-pages
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Page ID"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Page not found"
+// @Router /static-pages/{id} [delete]
+func (h *StaticPageHandler) DeleteStaticPage(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	if err := h.service.WithContext(audit.Context(c)).Delete(id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "page_deleted", nil)
+}
+
+// ListStaticPages godoc
+// @Summary List static pages
+// @Description Retrieves a paginated list of static pages in every locale and status
+// @Tags static-pages
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Case-insensitive match on title or slug"
+// @Param slug query string false "Filter by slug, comma-separated for several"
+// @Param locale query string false "Filter by locale, comma-separated for several"
+// @Param status query string false "Filter by status: draft or published"
+// @Param sort query string false "Comma-separated sort keys, prefixed with - for descending: title, slug, locale, publishAt, createdAt, updatedAt" default(slug,locale)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} response.Envelope{data=response.Page{list=[]StaticPage}}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid filter or sort"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 500 {object} response.ErrorEnvelope "Internal server error"
+// @Router /static-pages [get]
+func (h *StaticPageHandler) ListStaticPages(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	page_size, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || page_size < 1 {
+		page_size = 10
+	}
+
+	params, err := query.Parse(c.Request.URL.Query(), StaticPageListSpec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	pages, count, err := h.service.List(params, page_size, (page-1)*page_size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", response.NewPage(pages, page, page_size, count))
+}
+
+// GetPublicPage godoc
+// @Summary Get a published page by slug
+// @Description Public read of a published page whose scheduled time has passed. The locale is taken from ?locale, else the request language negotiated from ?lang or Accept-Language, and falls back to its base language, then English; Content-Language names the locale served.
+// @Tags pages
+// @Produce json
+// @Param slug path string true "Page slug, e.g. terms"
+// @Param locale query string false "Preferred locale, e.g. es-MX"
+// @Param Accept-Language header string false "Preferred languages when ?locale and ?lang are absent"
+// @Success 200 {object} response.Envelope{data=StaticPage}
+// @Failure 404 {object} response.ErrorEnvelope "Page not found"
+// @Failure 500 {object} response.ErrorEnvelope "Internal server error"
+// @Router /pages/{slug} [get]
+func (h *StaticPageHandler) GetPublicPage(c *gin.Context) {
+	page, err := h.service.FindLive(c.Param("slug"), localization.RequestLocale(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Language", page.Locale)
+	response.Success(c, http.StatusOK, "details_fetched", page)
+}
@@ -0,0 +1,37 @@
+package staticpagemanagement
+
+import (
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/modules"
+	"goUniAdmin/internal/services/middleware"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// staticPageModule implements the Module interface
+type staticPageModule struct {
+	handler *StaticPageHandler
+}
+
+// RegisterRoutes sets up the static page management routes and the public page route
+func (m *staticPageModule) RegisterRoutes(group *gin.RouterGroup, cfg *config.Config, db *db.DB) {
+	pageGroup := group.Group("/static-pages")
+	pageGroup.Use(middleware.AuthMiddleware(cfg))
+	pageGroup.GET("", middleware.RequirePermission("static-pages:read"), m.handler.ListStaticPages)
+	pageGroup.POST("", middleware.RequirePermission("static-pages:create"), m.handler.CreateStaticPage)
+	pageGroup.GET("/:id", middleware.RequirePermission("static-pages:read"), m.handler.GetStaticPage)
+	pageGroup.PUT("/:id", middleware.RequirePermission("static-pages:update"), m.handler.UpdateStaticPage)
+	pageGroup.DELETE("/:id", middleware.RequirePermission("static-pages:delete"), m.handler.DeleteStaticPage)
+
+	group.GET("/pages/:slug", m.handler.GetPublicPage)
+}
+
+// RegisterStaticPageModule registers the static page module with the given dependencies
+func RegisterStaticPageModule(cfg *config.Config, db *db.DB) {
+	service := NewStaticPageService(db, cfg)
+	handler := NewStaticPageHandler(service)
+	modules.RegisterModule(&staticPageModule{handler: handler})
+	slog.Info("Module registered", "module", "static_page")
+}
@@ -0,0 +1,38 @@
+package staticpagemanagement
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Page statuses
+const (
+	StatusDraft     = "draft"
+	StatusPublished = "published"
+)
+
+// StaticPage is a CMS page such as terms, privacy or FAQ in one locale
+type StaticPage struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey" json:"_id"`
+	Slug            string     `gorm:"not null;uniqueIndex:idx_static_pages_slug_locale" json:"slug" validate:"required,slug"`
+	Locale          string     `gorm:"not null;default:en;uniqueIndex:idx_static_pages_slug_locale" json:"locale" validate:"required,locale"`
+	Title           string     `gorm:"not null" json:"title" validate:"required"`
+	Body            string     `gorm:"type:text" json:"body"` // Sanitised HTML
+	MetaTitle       string     `json:"metaTitle,omitempty"`
+	MetaDescription string     `json:"metaDescription,omitempty"`
+	MetaKeywords    string     `json:"metaKeywords,omitempty"`
+	Status          string     `gorm:"not null;default:draft" json:"status" validate:"oneof=draft published"`
+	PublishAt       *time.Time `json:"publishAt,omitempty"` // A published page is public from this time; immediately when unset
+	CreatedAt       time.Time  `gorm:"autoCreateTime" json:"createdAt,omitempty"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updatedAt,omitempty"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (p *StaticPage) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
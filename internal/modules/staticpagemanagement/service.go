@@ -0,0 +1,186 @@
+package staticpagemanagement
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/services/apperror"
+	localization "goUniAdmin/internal/services/common"
+	"goUniAdmin/internal/services/query"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrPageNotFound is returned for an unknown page, or one that is not live on the public endpoint
+	ErrPageNotFound = apperror.New(http.StatusNotFound, "PAGE_NOT_FOUND", "page not found")
+	// ErrPageExists is returned when a page with the same slug and locale already exists
+	ErrPageExists = apperror.New(http.StatusConflict, "PAGE_EXISTS", "a page with this slug and locale already exists")
+)
+
+// StaticPageService manages static pages
+type StaticPageService struct {
+	db  *db.DB
+	cfg *config.Config
+}
+
+// NewStaticPageService initializes the service with a GORM database connection and config
+func NewStaticPageService(db *db.DB, cfg *config.Config) *StaticPageService {
+	return &StaticPageService{
+		db:  db,
+		cfg: cfg,
+	}
+}
+
+// WithContext returns a copy of the service whose queries carry ctx, e.g. audit.Context(c) so
+// the audit log attributes the writes to the request
+func (s *StaticPageService) WithContext(ctx context.Context) *StaticPageService {
+	clone := *s
+	clone.db = &db.DB{DB: s.db.WithContext(ctx)}
+	return &clone
+}
+
+// StaticPageListSpec declares the search, filters and sorts accepted by the page list endpoint
+var StaticPageListSpec = query.Spec{
+	SearchColumns: []string{"title", "slug"},
+	Filters: map[string]query.Filter{
+		"slug":   {Column: "slug", Type: query.FilterIn},
+		"locale": {Column: "locale", Type: query.FilterIn},
+		"status": {Column: "status", Type: query.FilterIn},
+	},
+	Sorts: map[string]string{
+		"title":     "title",
+		"slug":      "slug",
+		"locale":    "locale",
+		"publishAt": "publish_at",
+		"createdAt": "created_at",
+		"updatedAt": "updated_at",
+	},
+	DefaultSort: "slug,locale",
+	TieBreaker:  "id",
+}
+
+// Create adds a new page with its body sanitised
+func (s *StaticPageService) Create(page StaticPage) (StaticPage, error) {
+	if err := ValidateStaticPage(page); err != nil {
+		return StaticPage{}, apperror.Validation(err)
+	}
+	page.Body = SanitizeBody(page.Body)
+
+	var count int64
+	if err := s.db.Model(&StaticPage{}).Where("slug = ? AND locale = ?", page.Slug, page.Locale).Count(&count).Error; err != nil {
+		return StaticPage{}, err
+	}
+	if count > 0 {
+		return StaticPage{}, ErrPageExists
+	}
+
+	if err := s.db.Create(&page).Error; err != nil {
+		return StaticPage{}, err
+	}
+	return page, nil
+}
+
+// Read retrieves a page by ID regardless of its status
+func (s *StaticPageService) Read(id uuid.UUID) (StaticPage, error) {
+	var page StaticPage
+	if err := s.db.Where("id = ?", id).First(&page).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return StaticPage{}, ErrPageNotFound
+		}
+		return StaticPage{}, err
+	}
+	return page, nil
+}
+
+// Update replaces the content of a page with its body sanitised
+func (s *StaticPageService) Update(id uuid.UUID, updated StaticPage) (StaticPage, error) {
+	if err := ValidateStaticPage(updated); err != nil {
+		return StaticPage{}, apperror.Validation(err)
+	}
+	existing, err := s.Read(id)
+	if err != nil {
+		return StaticPage{}, err
+	}
+
+	var count int64
+	if err := s.db.Model(&StaticPage{}).Where("slug = ? AND locale = ? AND id <> ?", updated.Slug, updated.Locale, id).Count(&count).Error; err != nil {
+		return StaticPage{}, err
+	}
+	if count > 0 {
+		return StaticPage{}, ErrPageExists
+	}
+
+	err = s.db.Model(&existing).Updates(map[string]interface{}{
+		"slug":             updated.Slug,
+		"locale":           updated.Locale,
+		"title":            updated.Title,
+		"body":             SanitizeBody(updated.Body),
+		"meta_title":       updated.MetaTitle,
+		"meta_description": updated.MetaDescription,
+		"meta_keywords":    updated.MetaKeywords,
+		"status":           updated.Status,
+		"publish_at":       updated.PublishAt,
+	}).Error
+	if err != nil {
+		return StaticPage{}, err
+	}
+	return s.Read(id)
+}
+
+// Delete removes a page
+func (s *StaticPageService) Delete(id uuid.UUID) error {
+	result := s.db.Delete(&StaticPage{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPageNotFound
+	}
+	return nil
+}
+
+// List retrieves pages matching the search and filters, in the requested order
+func (s *StaticPageService) List(params query.Params, limit, offset int) ([]StaticPage, int64, error) {
+	base := params.Where(s.db.Model(&StaticPage{}))
+
+	var totalCount int64
+	if err := base.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var pages []StaticPage
+	if err := params.Order(base.Session(&gorm.Session{})).Limit(limit).Offset(offset).Find(&pages).Error; err != nil {
+		return nil, 0, err
+	}
+	return pages, totalCount, nil
+}
+
+// FindLive returns the live page for slug, trying the locale, its base language and then the
+// default locale, e.g. "es-MX" -> es-MX, es, en
+func (s *StaticPageService) FindLive(slug, locale string) (StaticPage, error) {
+	candidates := localization.LocaleCandidates(locale)
+	now := time.Now()
+
+	var pages []StaticPage
+	err := s.db.Where("slug = ? AND locale IN ? AND status = ?", slug, candidates, StatusPublished).
+		Where("publish_at IS NULL OR publish_at <= ?", now).
+		Find(&pages).Error
+	if err != nil {
+		return StaticPage{}, err
+	}
+
+	for _, candidate := range candidates {
+		for _, page := range pages {
+			if page.Locale == candidate {
+				return page, nil
+			}
+		}
+	}
+	return StaticPage{}, ErrPageNotFound
+}
@@ -0,0 +1,95 @@
+package staticpagemanagement
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestService returns a static page service on a fresh SQLite database
+func newTestService(t *testing.T) *StaticPageService {
+	t.Helper()
+	conn, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "pages.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := conn.AutoMigrate(&StaticPage{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return NewStaticPageService(&db.DB{DB: conn}, &config.Config{})
+}
+
+// newPage creates a page titled after its locale
+func newPage(t *testing.T, s *StaticPageService, slug, locale, status string, publishAt *time.Time) {
+	t.Helper()
+	page := StaticPage{Slug: slug, Locale: locale, Title: "Terms " + locale, Status: status, PublishAt: publishAt}
+	if _, err := s.Create(page); err != nil {
+		t.Fatalf("Create(%s, %s) error = %v", slug, locale, err)
+	}
+}
+
+func TestFindLive(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	s := newTestService(t)
+	newPage(t, s, "terms", "en", StatusPublished, nil)
+	newPage(t, s, "terms", "es", StatusPublished, &past)
+	newPage(t, s, "terms", "es-MX", StatusPublished, nil)
+	newPage(t, s, "terms", "de", StatusDraft, nil)
+	newPage(t, s, "terms", "fr", StatusPublished, &future)
+	newPage(t, s, "privacy", "es", StatusDraft, nil)
+	newPage(t, s, "faq", "es", StatusPublished, &future)
+
+	tests := []struct {
+		name      string
+		slug      string
+		locale    string
+		wantTitle string
+		wantErr   error
+	}{
+		{"exact locale", "terms", "es-MX", "Terms es-MX", nil},
+		{"base language", "terms", "es-AR", "Terms es", nil},
+		{"English fallback", "terms", "it", "Terms en", nil},
+		{"draft falls back", "terms", "de", "Terms en", nil},
+		{"scheduled falls back", "terms", "fr", "Terms en", nil},
+		{"only a draft", "privacy", "es", "", ErrPageNotFound},
+		{"only scheduled", "faq", "es", "", ErrPageNotFound},
+		{"unknown slug", "cookies", "en", "", ErrPageNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := s.FindLive(tt.slug, tt.locale)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FindLive() error = %v, want %v", err, tt.wantErr)
+			}
+			if page.Title != tt.wantTitle {
+				t.Errorf("FindLive() = %q, want %q", page.Title, tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestCreateSanitizesBody(t *testing.T) {
+	s := newTestService(t)
+	page, err := s.Create(StaticPage{Slug: "terms", Locale: "en", Title: "Terms", Status: StatusDraft, Body: `<p onclick="x()">Hi</p><script>x()</script>`})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	stored, err := s.Read(page.ID)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if strings.Contains(stored.Body, "script") || strings.Contains(stored.Body, "onclick") {
+		t.Errorf("stored body = %q, want it sanitised", stored.Body)
+	}
+}
@@ -0,0 +1,21 @@
+package staticpagemanagement
+
+import (
+	"goUniAdmin/internal/services/validators"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// bodyPolicy allows the formatting, links, images and tables produced by rich text editors
+// and strips scripts, styles, event handlers and javascript: URLs
+var bodyPolicy = bluemonday.UGCPolicy()
+
+// SanitizeBody removes unsafe markup from a page body
+func SanitizeBody(body string) string {
+	return bodyPolicy.Sanitize(body)
+}
+
+// ValidateStaticPage validates the page against the rules in its validate tags
+func ValidateStaticPage(page StaticPage) error {
+	return validators.Struct(page).Err()
+}
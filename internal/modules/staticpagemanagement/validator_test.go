@@ -0,0 +1,33 @@
+package staticpagemanagement
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		removed string
+		kept    string
+	}{
+		{"script tag", `<p>Terms</p><script>alert(1)</script>`, "<script", "<p>Terms</p>"},
+		{"script in attribute case", `<SCRIPT src="https://evil.test/x.js"></SCRIPT><p>Terms</p>`, "evil.test", "<p>Terms</p>"},
+		{"event handler", `<img src="/logo.png" onerror="alert(1)">`, "onerror", `src="/logo.png"`},
+		{"click handler", `<a href="/faq" onclick="steal()">FAQ</a>`, "onclick", `href="/faq"`},
+		{"javascript link", `<a href="javascript:alert(1)">Click</a>`, "javascript:", "Click"},
+		{"style tag", `<style>body{display:none}</style><h1>Privacy</h1>`, "display:none", "<h1>Privacy</h1>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SanitizeBody(tt.body)
+			if strings.Contains(strings.ToLower(got), strings.ToLower(tt.removed)) {
+				t.Errorf("SanitizeBody() = %q, want %q removed", got, tt.removed)
+			}
+			if !strings.Contains(got, tt.kept) {
+				t.Errorf("SanitizeBody() = %q, want %q kept", got, tt.kept)
+			}
+		})
+	}
+}
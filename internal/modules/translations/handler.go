@@ -0,0 +1,264 @@
+package translations
+
+import (
+	"net/http"
+	"strconv"
+
+	"goUniAdmin/internal/modules/audit"
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/query"
+	"goUniAdmin/internal/services/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TranslationHandler handles HTTP requests for translation CRUD, import and export
+type TranslationHandler struct {
+	service *TranslationService
+}
+
+// NewTranslationHandler creates a new handler with the service
+func NewTranslationHandler(service *TranslationService) *TranslationHandler {
+	return &TranslationHandler{service: service}
+}
+
+// TranslationRequest defines the request body for creating a translation
+type TranslationRequest struct {
+	Language  string `json:"language" enums:"en,de,es"`
+	MessageID string `json:"messageId" example:"admin_not_found"`
+	Text      string `json:"text" example:"Administrator nicht gefunden"`
+}
+
+// TranslationUpdateRequest defines the request body for updating a translation
+type TranslationUpdateRequest struct {
+	Text string `json:"text" example:"Administrator nicht gefunden"`
+}
+
+// CreateTranslation godoc
+// @Summary Create a translation
+// @Description Overrides the text of a catalog message in one language. Takes effect immediately without a restart. Texts are go-i18n templates, e.g. "{{.Field}} is required".
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param translation body TranslationRequest true "Translation data"
+// @Success 201 {object} response.Envelope{data=Translation}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body or validation error"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 409 {object} response.ErrorEnvelope "The message already has a translation in this language"
+// @Router /translations [post]
+func (h *TranslationHandler) CreateTranslation(c *gin.Context) {
+	var req TranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	translation := Translation{Language: req.Language, MessageID: req.MessageID, Text: req.Text}
+	created, err := h.service.WithContext(audit.Context(c)).Create(translation)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "translation_created", created)
+}
+
+// GetTranslation godoc
+// @Summary Get a translation by ID
+// @Description Retrieves a translation by UUID, with the text of the catalog file it overrides
+// @Tags translations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Translation ID"
+// @Success 200 {object} response.Envelope{data=Translation}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Translation not found"
+// @Router /translations/{id} [get]
+func (h *TranslationHandler) GetTranslation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	translation, err := h.service.Read(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", translation)
+}
+
+// UpdateTranslation godoc
+// @Summary Update a translation
+// @Description Changes the text of a translation. Takes effect immediately without a restart.
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Translation ID"
+// @Param translation body TranslationUpdateRequest true "Updated text"
+// @Success 200 {object} response.Envelope{data=Translation}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID, request body or validation error"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Translation not found"
+// @Router /translations/{id} [put]
+func (h *TranslationHandler) UpdateTranslation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	var req TranslationUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	updated, err := h.service.WithContext(audit.Context(c)).Update(id, req.Text)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "updated_successfully", updated)
+}
+
+// DeleteTranslation godoc
+// @Summary Delete a translation
+// @Description Deletes a translation; the message reverts to the text of its catalog file
+// @Tags translations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Translation ID"
+// @Success 200 {object} response.Envelope
+// @Failure 400 {object} response.ErrorEnvelope "Invalid ID"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 404 {object} response.ErrorEnvelope "Translation not found"
+// @Router /translations/{id} [delete]
+func (h *TranslationHandler) DeleteTranslation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidRequest("invalid ID"))
+		return
+	}
+
+	if err := h.service.WithContext(audit.Context(c)).Delete(id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "translation_deleted", nil)
+}
+
+// ListTranslations godoc
+// @Summary List translations
+// @Description Retrieves a paginated list of the translations that override catalog messages
+// @Tags translations
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Case-insensitive match on message ID or text"
+// @Param language query string false "Filter by language, comma-separated for several"
+// @Param messageId query string false "Filter by message ID, comma-separated for several"
+// @Param sort query string false "Comma-separated sort keys, prefixed with - for descending: language, messageId, createdAt, updatedAt" default(language,messageId)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} response.Envelope{data=response.Page{list=[]Translation}}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid filter or sort"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Failure 500 {object} response.ErrorEnvelope "Internal server error"
+// @Router /translations [get]
+func (h *TranslationHandler) ListTranslations(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	page_size, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || page_size < 1 {
+		page_size = 10
+	}
+
+	params, err := query.Parse(c.Request.URL.Query(), TranslationListSpec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	translations, count, err := h.service.List(params, page_size, (page-1)*page_size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", response.NewPage(translations, page, page_size, count))
+}
+
+// ExportTranslations godoc
+// @Summary Export a catalog
+// @Description Returns the catalog of a language as a JSON object of message ID to text: the catalog file with the translations applied, or only the translations. The object can be edited and imported back.
+// @Tags translations
+// @Produce json
+// @Security BearerAuth
+// @Param language query string true "Language" Enums(en, de, es)
+// @Param overrides_only query bool false "Only export the translations stored in the database"
+// @Success 200 {object} response.Envelope{data=map[string]string}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid language"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Router /translations/export [get]
+func (h *TranslationHandler) ExportTranslations(c *gin.Context) {
+	overridesOnly, err := strconv.ParseBool(c.DefaultQuery("overrides_only", "false"))
+	if err != nil {
+		c.Error(apperror.Validationf("overrides_only must be true or false"))
+		return
+	}
+
+	messages, err := h.service.Export(c.Query("language"), overridesOnly)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "details_fetched", messages)
+}
+
+// ImportTranslations godoc
+// @Summary Import a catalog
+// @Description Stores a JSON object of message ID to text as the translations of a language, creating or updating one per message in a single transaction. Messages left out are not changed. Takes effect immediately without a restart.
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param language query string true "Language" Enums(en, de, es)
+// @Param messages body map[string]string true "Message ID to text"
+// @Success 200 {object} response.Envelope{data=ImportResult}
+// @Failure 400 {object} response.ErrorEnvelope "Invalid request body or validation error"
+// @Failure 401 {object} response.ErrorEnvelope "Unauthorized"
+// @Failure 403 {object} response.ErrorEnvelope "Forbidden"
+// @Router /translations/import [post]
+func (h *TranslationHandler) ImportTranslations(c *gin.Context) {
+	var messages map[string]string
+	if err := c.ShouldBindJSON(&messages); err != nil {
+		c.Error(apperror.InvalidRequest("invalid request body"))
+		return
+	}
+
+	result, err := h.service.WithContext(audit.Context(c)).Import(c.Query("language"), messages)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "translations_imported", result)
+}
@@ -0,0 +1,41 @@
+package translations
+
+import (
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/modules"
+	localization "goUniAdmin/internal/services/common"
+	"goUniAdmin/internal/services/middleware"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// translationsModule implements the Module interface
+type translationsModule struct {
+	handler *TranslationHandler
+}
+
+// RegisterRoutes sets up the translation routes
+func (m *translationsModule) RegisterRoutes(group *gin.RouterGroup, cfg *config.Config, db *db.DB) {
+	translationGroup := group.Group("/translations")
+	translationGroup.Use(middleware.AuthMiddleware(cfg))
+	translationGroup.GET("", middleware.RequirePermission("translations:read"), m.handler.ListTranslations)
+	translationGroup.POST("", middleware.RequirePermission("translations:create"), m.handler.CreateTranslation)
+	translationGroup.GET("/export", middleware.RequirePermission("translations:read"), m.handler.ExportTranslations)
+	translationGroup.POST("/import", middleware.RequirePermission("translations:import"), m.handler.ImportTranslations)
+	translationGroup.GET("/:id", middleware.RequirePermission("translations:read"), m.handler.GetTranslation)
+	translationGroup.PUT("/:id", middleware.RequirePermission("translations:update"), m.handler.UpdateTranslation)
+	translationGroup.DELETE("/:id", middleware.RequirePermission("translations:delete"), m.handler.DeleteTranslation)
+}
+
+// RegisterTranslationsModule registers the translations module with the given dependencies.
+// Its translations override the catalog files in every localized message.
+func RegisterTranslationsModule(cfg *config.Config, db *db.DB) {
+	service := NewTranslationService(db, cfg)
+	handler := NewTranslationHandler(service)
+
+	localization.SetOverrideSource(service, cfg.TranslationsCacheTTL)
+	modules.RegisterModule(&translationsModule{handler: handler})
+	slog.Info("Module registered", "module", "translations")
+}
@@ -0,0 +1,27 @@
+package translations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Translation replaces the text of one message of a catalog file in one language
+type Translation struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"_id"`
+	Language    string    `gorm:"not null;uniqueIndex:idx_translations_language_message_id" json:"language"` // One of localization.Languages
+	MessageID   string    `gorm:"not null;uniqueIndex:idx_translations_language_message_id" json:"messageId" validate:"required"`
+	Text        string    `gorm:"not null" json:"text" validate:"required"` // go-i18n template, e.g. "{{.Field}} is required"
+	DefaultText string    `gorm:"-" json:"defaultText,omitempty"`           // Text of the catalog file, used when the override is deleted
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"createdAt,omitempty"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updatedAt,omitempty"`
+}
+
+// BeforeCreate hook to set UUID if not provided
+func (t *Translation) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return
+}
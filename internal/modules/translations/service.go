@@ -0,0 +1,248 @@
+package translations
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/services/apperror"
+	localization "goUniAdmin/internal/services/common"
+	"goUniAdmin/internal/services/query"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrTranslationNotFound is returned for an unknown translation ID
+	ErrTranslationNotFound = apperror.New(http.StatusNotFound, "TRANSLATION_NOT_FOUND", "translation not found")
+	// ErrTranslationExists is returned when a message already has an override in the language
+	ErrTranslationExists = apperror.New(http.StatusConflict, "TRANSLATION_EXISTS", "this message already has a translation in this language")
+)
+
+// TranslationListSpec declares the search, filters and sorts accepted by the list endpoint
+var TranslationListSpec = query.Spec{
+	SearchColumns: []string{"message_id", "text"},
+	Filters: map[string]query.Filter{
+		"language":  {Column: "language", Type: query.FilterIn},
+		"messageId": {Column: "message_id", Type: query.FilterIn},
+	},
+	Sorts: map[string]string{
+		"language":  "language",
+		"messageId": "message_id",
+		"createdAt": "created_at",
+		"updatedAt": "updated_at",
+	},
+	DefaultSort: "language,messageId",
+	TieBreaker:  "id",
+}
+
+// ImportResult counts the overrides an import created and updated
+type ImportResult struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+}
+
+// TranslationService manages the overrides of the catalog files and supplies them to the
+// localization bundle, which is rebuilt on the next lookup after each change
+type TranslationService struct {
+	db  *db.DB
+	cfg *config.Config
+}
+
+// NewTranslationService initializes the service with a GORM database connection and config
+func NewTranslationService(db *db.DB, cfg *config.Config) *TranslationService {
+	return &TranslationService{
+		db:  db,
+		cfg: cfg,
+	}
+}
+
+// WithContext returns a copy of the service whose queries carry ctx, e.g. audit.Context(c) so
+// the audit log attributes the writes to the request
+func (s *TranslationService) WithContext(ctx context.Context) *TranslationService {
+	clone := *s
+	clone.db = &db.DB{DB: s.db.WithContext(ctx)}
+	return &clone
+}
+
+// Overrides returns every override by language and message ID. It implements
+// localization.OverrideSource.
+func (s *TranslationService) Overrides() (map[string]map[string]string, error) {
+	var translations []Translation
+	if err := s.db.Select("language", "message_id", "text").Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	overrides := map[string]map[string]string{}
+	for _, translation := range translations {
+		if overrides[translation.Language] == nil {
+			overrides[translation.Language] = map[string]string{}
+		}
+		overrides[translation.Language][translation.MessageID] = translation.Text
+	}
+	return overrides, nil
+}
+
+// Create adds an override of a message in a language
+func (s *TranslationService) Create(translation Translation) (Translation, error) {
+	if err := ValidateTranslation(translation); err != nil {
+		return Translation{}, apperror.Validation(err)
+	}
+
+	var count int64
+	err := s.db.Model(&Translation{}).
+		Where("language = ? AND message_id = ?", translation.Language, translation.MessageID).
+		Count(&count).Error
+	if err != nil {
+		return Translation{}, err
+	}
+	if count > 0 {
+		return Translation{}, ErrTranslationExists
+	}
+
+	if err := s.db.Create(&translation).Error; err != nil {
+		return Translation{}, err
+	}
+	localization.Invalidate()
+	return withDefault(translation), nil
+}
+
+// Read retrieves an override by ID
+func (s *TranslationService) Read(id uuid.UUID) (Translation, error) {
+	var translation Translation
+	if err := s.db.Where("id = ?", id).First(&translation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Translation{}, ErrTranslationNotFound
+		}
+		return Translation{}, err
+	}
+	return withDefault(translation), nil
+}
+
+// Update changes the text of an override. The language and message are fixed.
+func (s *TranslationService) Update(id uuid.UUID, text string) (Translation, error) {
+	existing, err := s.Read(id)
+	if err != nil {
+		return Translation{}, err
+	}
+
+	existing.Text = text
+	if err := ValidateTranslation(existing); err != nil {
+		return Translation{}, apperror.Validation(err)
+	}
+
+	if err := s.db.Model(&existing).Update("text", text).Error; err != nil {
+		return Translation{}, err
+	}
+	localization.Invalidate()
+	return s.Read(id)
+}
+
+// Delete removes an override, so the message reverts to the text of its catalog file
+func (s *TranslationService) Delete(id uuid.UUID) error {
+	result := s.db.Where("id = ?", id).Delete(&Translation{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTranslationNotFound
+	}
+	localization.Invalidate()
+	return nil
+}
+
+// List retrieves overrides matching the search and filters in params
+func (s *TranslationService) List(params query.Params, limit, offset int) ([]Translation, int64, error) {
+	base := params.Where(s.db.Model(&Translation{}))
+
+	var totalCount int64
+	if err := base.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var translations []Translation
+	if err := params.Order(base.Session(&gorm.Session{})).Limit(limit).Offset(offset).Find(&translations).Error; err != nil {
+		return nil, 0, err
+	}
+	for i := range translations {
+		translations[i] = withDefault(translations[i])
+	}
+	return translations, totalCount, nil
+}
+
+// Export returns the catalog of a language by message ID: the file defaults with the
+// overrides applied, or only the overrides
+func (s *TranslationService) Export(lang string, overridesOnly bool) (map[string]string, error) {
+	if err := ValidateLanguage(lang); err != nil {
+		return nil, apperror.Validation(err)
+	}
+
+	var translations []Translation
+	if err := s.db.Where("language = ?", lang).Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	messages := map[string]string{}
+	if !overridesOnly {
+		messages = localization.Defaults(lang)
+	}
+	for _, translation := range translations {
+		messages[translation.MessageID] = translation.Text
+	}
+	return messages, nil
+}
+
+// Import stores the texts of a catalog as overrides in one transaction, creating or updating
+// one per message. Messages left out are not changed.
+func (s *TranslationService) Import(lang string, messages map[string]string) (ImportResult, error) {
+	if err := ValidateLanguage(lang); err != nil {
+		return ImportResult{}, apperror.Validation(err)
+	}
+	if err := ValidateImport(messages); err != nil {
+		return ImportResult{}, apperror.Validation(err)
+	}
+
+	var result ImportResult
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var existing []Translation
+		if err := tx.Where("language = ?", lang).Find(&existing).Error; err != nil {
+			return err
+		}
+		byMessageID := make(map[string]Translation, len(existing))
+		for _, translation := range existing {
+			byMessageID[translation.MessageID] = translation
+		}
+
+		for _, messageID := range sortedIDs(messages) {
+			text := messages[messageID]
+			if translation, ok := byMessageID[messageID]; ok {
+				if translation.Text == text {
+					continue
+				}
+				if err := tx.Model(&translation).Update("text", text).Error; err != nil {
+					return err
+				}
+				result.Updated++
+				continue
+			}
+			translation := Translation{Language: lang, MessageID: messageID, Text: text}
+			if err := tx.Create(&translation).Error; err != nil {
+				return err
+			}
+			result.Created++
+		}
+		return nil
+	})
+	if err != nil {
+		return ImportResult{}, err
+	}
+	localization.Invalidate()
+	return result, nil
+}
+
+// withDefault sets the text of the catalog file the override replaces
+func withDefault(translation Translation) Translation {
+	translation.DefaultText, _ = localization.DefaultText(translation.Language, translation.MessageID)
+	return translation
+}
@@ -0,0 +1,108 @@
+package translations
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	localization "goUniAdmin/internal/services/common"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestService returns a translation service on a fresh SQLite database, installed as the
+// override source of the localization bundle for the duration of the test
+func newTestService(t *testing.T) *TranslationService {
+	t.Helper()
+	conn, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "translations.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := conn.AutoMigrate(&Translation{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	service := NewTranslationService(&db.DB{DB: conn}, &config.Config{})
+	localization.SetOverrideSource(service, time.Hour)
+	t.Cleanup(func() { localization.SetOverrideSource(nil, 0) })
+	return service
+}
+
+func TestOverrideReplacesCatalogText(t *testing.T) {
+	s := newTestService(t)
+	data := map[string]interface{}{"Field": "email"}
+	localize := func() string { return localization.Localize("de", "validation_required", data) }
+
+	if got := localize(); got != "email ist erforderlich" {
+		t.Fatalf("Localize() without overrides = %q", got)
+	}
+	created, err := s.Create(Translation{Language: "de", MessageID: "validation_required", Text: "{{.Field}} fehlt"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if created.DefaultText != "{{.Field}} ist erforderlich" {
+		t.Errorf("Create().DefaultText = %q, want the catalog text", created.DefaultText)
+	}
+	if got := localize(); got != "email fehlt" {
+		t.Errorf("Localize() after Create = %q, want the override", got)
+	}
+
+	if _, err := s.Update(created.ID, "{{.Field}} wird benötigt"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if got := localize(); got != "email wird benötigt" {
+		t.Errorf("Localize() after Update = %q, want the new override", got)
+	}
+
+	if err := s.Delete(created.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if got := localize(); got != "email ist erforderlich" {
+		t.Errorf("Localize() after Delete = %q, want the catalog text", got)
+	}
+}
+
+func TestImport(t *testing.T) {
+	s := newTestService(t)
+	for _, translation := range []Translation{
+		{Language: "de", MessageID: "profile_updated", Text: "Profil gespeichert"},
+		{Language: "de", MessageID: "validation_required", Text: "{{.Field}} fehlt"},
+	} {
+		if _, err := s.Create(translation); err != nil {
+			t.Fatalf("Create(%s) error = %v", translation.MessageID, err)
+		}
+	}
+
+	result, err := s.Import("de", map[string]string{
+		"profile_updated":     "Profil gespeichert",       // Unchanged
+		"validation_required": "{{.Field}} wird benötigt", // Updated
+		"validation_email":    "{{.Field}} ist keine E-Mail-Adresse",
+	})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if result != (ImportResult{Created: 1, Updated: 1}) {
+		t.Errorf("Import() = %+v, want 1 created and 1 updated", result)
+	}
+
+	exported, err := s.Export("de", true)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if len(exported) != 3 || exported["validation_required"] != "{{.Field}} wird benötigt" {
+		t.Errorf("Export() after Import = %v", exported)
+	}
+	if got := localization.Localize("de", "validation_email", map[string]interface{}{"Field": "email"}); got != "email ist keine E-Mail-Adresse" {
+		t.Errorf("Localize() after Import = %q, want the imported text", got)
+	}
+
+	if _, err := s.Import("de", map[string]string{"validation_email": "{{.Field", "no_such_message": "x"}); err == nil {
+		t.Error("Import() of invalid entries succeeded")
+	}
+	if after, _ := s.Export("de", true); after["validation_email"] != exported["validation_email"] {
+		t.Error("rejected Import() changed an override")
+	}
+}
@@ -0,0 +1,69 @@
+package translations
+
+import (
+	"sort"
+	"strings"
+	"text/template"
+
+	localization "goUniAdmin/internal/services/common"
+	"goUniAdmin/internal/services/validators"
+)
+
+// languageRule accepts the languages the API has catalogs for
+var languageRule = "required,oneof=" + strings.Join(localization.Languages, " ")
+
+// ValidateTranslation validates an override against the rules in its validate tags, and checks
+// that it replaces a message of the catalogs in a supported language with a text that renders
+func ValidateTranslation(translation Translation) error {
+	errs := validators.Var("language", translation.Language, languageRule)
+	errs = append(errs, validators.Struct(translation)...)
+	errs = append(errs, textErrors("messageId", "text", translation.MessageID, translation.Text)...)
+	return errs.Err()
+}
+
+// ValidateLanguage checks that lang is a supported language
+func ValidateLanguage(lang string) error {
+	return validators.Var("language", lang, languageRule).Err()
+}
+
+// ValidateImport checks every entry of an imported catalog, reporting failures under the
+// message ID
+func ValidateImport(messages map[string]string) error {
+	var errs validators.Errors
+	for _, messageID := range sortedIDs(messages) {
+		text := messages[messageID]
+		if text == "" {
+			errs.Add(messageID, "required", "")
+			continue
+		}
+		errs = append(errs, textErrors(messageID, messageID, messageID, text)...)
+	}
+	return errs.Err()
+}
+
+// textErrors reports a message ID missing from the English catalog, which has every message,
+// and a text that is not a valid template
+func textErrors(idField, textField, messageID, text string) validators.Errors {
+	var errs validators.Errors
+	if messageID != "" {
+		if _, ok := localization.DefaultText(localization.DefaultLanguage, messageID); !ok {
+			errs.Add(idField, "message_id", "")
+		}
+	}
+	if text != "" {
+		if _, err := template.New(messageID).Parse(text); err != nil {
+			errs.Add(textField, "template", "")
+		}
+	}
+	return errs
+}
+
+// sortedIDs returns the message IDs of messages in order
+func sortedIDs(messages map[string]string) []string {
+	ids := make([]string, 0, len(messages))
+	for messageID := range messages {
+		ids = append(ids, messageID)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,66 @@
+package translations
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"goUniAdmin/internal/services/validators"
+)
+
+// fieldErrors returns the field errors in err, or fails the test if it holds none
+func fieldErrors(t *testing.T, err error) validators.Errors {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var errs validators.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("error = %v, want validators.Errors", err)
+	}
+	return errs
+}
+
+func TestValidateImport(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages map[string]string
+		want     validators.Errors
+	}{
+		{"valid", map[string]string{"validation_required": "{{.Field}} fehlt", "profile_updated": "Gespeichert"}, nil},
+		{"unknown message ID", map[string]string{"no_such_message": "Hallo"}, validators.Errors{{Field: "no_such_message", Code: "message_id"}}},
+		{"invalid template", map[string]string{"validation_required": "{{.Field"}, validators.Errors{{Field: "validation_required", Code: "template"}}},
+		{"empty text", map[string]string{"profile_updated": ""}, validators.Errors{{Field: "profile_updated", Code: "required"}}},
+		{
+			name:     "every failure reported",
+			messages: map[string]string{"validation_required": "{{.Field", "no_such_message": "Hallo"},
+			want: validators.Errors{
+				{Field: "no_such_message", Code: "message_id"},
+				{Field: "validation_required", Code: "template"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldErrors(t, ValidateImport(tt.messages)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateImport() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateLanguage(t *testing.T) {
+	tests := []struct {
+		lang string
+		want validators.Errors
+	}{
+		{"de", nil},
+		{"", validators.Errors{{Field: "language", Code: "required"}}},
+		{"fr", validators.Errors{{Field: "language", Code: "oneof", Param: "en de es"}}},
+	}
+	for _, tt := range tests {
+		if got := fieldErrors(t, ValidateLanguage(tt.lang)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ValidateLanguage(%q) = %+v, want %+v", tt.lang, got, tt.want)
+		}
+	}
+}
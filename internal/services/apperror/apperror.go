@@ -0,0 +1,122 @@
+// Package apperror defines the errors returned to API clients: a stable code, an HTTP status
+// and a message, optionally with details and an internal cause that is logged but never sent.
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+// Code identifies a kind of error. Codes are part of the API contract; clients branch on
+// them rather than on messages, so existing codes must not change.
+type Code string
+
+// Generic codes, used when no more specific code applies
+const (
+	CodeInvalidRequest   Code = "INVALID_REQUEST"   // Malformed body, parameter or ID
+	CodeValidationFailed Code = "VALIDATION_FAILED" // Well-formed input breaking a rule
+	CodeUnauthorized     Code = "UNAUTHORIZED"      // Missing, invalid or expired credentials
+	CodeForbidden        Code = "FORBIDDEN"         // Authenticated but not allowed
+	CodeNotFound         Code = "NOT_FOUND"
+	CodeConflict         Code = "CONFLICT"
+	CodePayloadTooLarge  Code = "PAYLOAD_TOO_LARGE"
+	CodeTooManyRequests  Code = "TOO_MANY_REQUESTS"
+	CodeInternal         Code = "INTERNAL_ERROR"
+)
+
+// Error is an error with an API code and status. Its message is shown to clients, so it
+// must not include internal details; those belong in the cause.
+type Error struct {
+	Code    Code
+	Status  int
+	Message string
+	Details interface{} // Extra data for the client, e.g. field errors
+	cause   error
+}
+
+// New creates an error. Modules declare their errors with it as package variables.
+func New(status int, code Code, message string) *Error {
+	return &Error{Code: code, Status: status, Message: message}
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the internal cause
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether target is an *Error with the same code, so errors.Is matches a copy
+// made by Wrap or WithDetails against the declared error
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Wrap returns a copy of the error recording cause, which is logged but not sent to clients
+func (e *Error) Wrap(cause error) *Error {
+	clone := *e
+	clone.cause = cause
+	return &clone
+}
+
+// WithDetails returns a copy of the error carrying details for the client
+func (e *Error) WithDetails(details interface{}) *Error {
+	clone := *e
+	clone.Details = details
+	return &clone
+}
+
+// InvalidRequest reports a malformed request, e.g. a body that does not parse or a bad ID
+func InvalidRequest(message string) *Error {
+	return New(http.StatusBadRequest, CodeInvalidRequest, message)
+}
+
+// Validation reports input breaking a rule, keeping the validator's message
+func Validation(err error) *Error {
+	return New(http.StatusBadRequest, CodeValidationFailed, err.Error()).Wrap(err)
+}
+
+// Validationf reports input breaking a rule with a formatted message
+func Validationf(format string, args ...interface{}) *Error {
+	return New(http.StatusBadRequest, CodeValidationFailed, fmt.Sprintf(format, args...))
+}
+
+// Unauthorized reports missing or invalid credentials
+func Unauthorized(message string) *Error {
+	return New(http.StatusUnauthorized, CodeUnauthorized, message)
+}
+
+// Forbidden reports an authenticated request that is not allowed
+func Forbidden(message string) *Error {
+	return New(http.StatusForbidden, CodeForbidden, message)
+}
+
+// Internal reports an unexpected failure. Clients get a generic message; cause is logged.
+func Internal(cause error) *Error {
+	return New(http.StatusInternalServerError, CodeInternal, "internal server error").Wrap(cause)
+}
+
+// From converts any error into an *Error: errors already carrying a code keep it, a missing
+// record becomes NOT_FOUND, an oversized body PAYLOAD_TOO_LARGE and anything else
+// INTERNAL_ERROR, hiding raw database and library messages from clients
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return New(http.StatusNotFound, CodeNotFound, "resource not found").Wrap(err)
+	case errors.As(err, &maxBytesErr):
+		return New(http.StatusRequestEntityTooLarge, CodePayloadTooLarge, "request body is too large").Wrap(err)
+	}
+	return Internal(err)
+}
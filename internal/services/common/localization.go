@@ -2,65 +2,192 @@ package localization
 
 import (
 	"encoding/json"
-	"log"
-	"path/filepath"
+	"io/fs"
+	"log/slog"
+	"strings"
 	"sync"
+	"time"
 
+	"goUniAdmin/internal/locales"
+
+	"github.com/gin-gonic/gin"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"golang.org/x/text/language"
 )
 
+// OverrideSource loads the texts that replace the catalog defaults, by language and then
+// message ID, e.g. those edited through /api/translations
+type OverrideSource interface {
+	Overrides() (map[string]map[string]string, error)
+}
+
 // Bundle holds the i18n configuration
 var bundle *i18n.Bundle
 var once sync.Once
 
-// Init initializes the localization bundle
-func Init() {
-	once.Do(func() {
-		bundle = i18n.NewBundle(language.English) // Default language
-
-		// Register a custom unmarshal function for flat key-value JSON
-		bundle.RegisterUnmarshalFunc("json", func(data []byte, v interface{}) error {
-			// Unmarshal into a map[string]string
-			var raw map[string]string
-			if err := json.Unmarshal(data, &raw); err != nil {
-				return err
-			}
+// defaults holds the embedded catalogs by language and then message ID
+var defaults map[string]map[string]string
 
-			// Convert to the expected interface{} type (map[string]interface{})
-			messages, ok := v.(*map[string]interface{})
-			if !ok {
-				return nil // Or handle error if needed
-			}
+// The override source and the state of the bundle built with its texts. The bundle is rebuilt
+// on the next lookup once Invalidate is called or the TTL has passed, which bounds how long
+// changes made by other instances go unnoticed.
+var (
+	mu         sync.RWMutex
+	reloadMu   sync.Mutex
+	source     OverrideSource
+	sourceTTL  time.Duration
+	loadedAt   time.Time
+	generation uint64 // Incremented on invalidation so a reload racing a change is not kept
+)
 
-			// Populate the messages map
-			for id, value := range raw {
-				(*messages)[id] = value
-			}
-			return nil
-		})
+// DefaultLanguage is used when a request names no supported language
+const DefaultLanguage = "en"
 
-		// Load translation files
-		files, err := filepath.Glob("locales/*.json")
-		if err != nil {
-			log.Fatalf("Failed to find translation files: %v", err)
-		}
+// Languages lists the languages the API has catalogs for, the default first
+var Languages = []string{"en", "de", "es"}
+
+// matcher picks the closest supported language for a requested one
+var matcher = language.NewMatcher([]language.Tag{language.English, language.German, language.Spanish})
+
+// Gin context keys set by the language middleware
+const (
+	// LanguageKey holds the negotiated language, e.g. "de"
+	LanguageKey = "language"
+	// LocalizerKey holds the *i18n.Localizer for the negotiated language
+	LocalizerKey = "localizer"
+)
+
+// Init initializes the localization bundle
+func Init() {
+	once.Do(func() {
+		// Load the catalogs embedded in the binary, so they are found whatever the working
+		// directory. Each is a flat JSON object of message ID to text, named by language.
+		defaults = map[string]map[string]string{}
+		files, _ := fs.Glob(locales.FS, "*.json")
 		for _, file := range files {
-			if _, err := bundle.LoadMessageFile(file); err != nil {
-				log.Printf("Failed to load %s: %v", file, err)
-			} else {
-				log.Printf("Loaded translation file: %s", file)
+			messages := map[string]string{}
+			data, err := fs.ReadFile(locales.FS, file)
+			if err == nil {
+				err = json.Unmarshal(data, &messages)
 			}
+			if err != nil {
+				slog.Error("Failed to load translation file", "file", file, "error", err)
+				continue
+			}
+			defaults[strings.TrimSuffix(file, ".json")] = messages
+			slog.Debug("Loaded translation file", "file", file)
 		}
+		mu.Lock()
+		bundle = newBundle(nil)
+		mu.Unlock()
 	})
 }
 
+// newBundle builds a bundle of the default catalogs with overrides applied on top
+func newBundle(overrides map[string]map[string]string) *i18n.Bundle {
+	b := i18n.NewBundle(language.English) // Default language
+	for lang, messages := range defaults {
+		addMessages(b, lang, messages)
+	}
+	for lang, messages := range overrides {
+		addMessages(b, lang, messages)
+	}
+	return b
+}
+
+// addMessages adds the texts of one language to b
+func addMessages(b *i18n.Bundle, lang string, messages map[string]string) {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		slog.Error("Skipping translations of an invalid language", "language", lang, "error", err)
+		return
+	}
+	list := make([]*i18n.Message, 0, len(messages))
+	for id, text := range messages {
+		list = append(list, &i18n.Message{ID: id, Other: text})
+	}
+	if err := b.AddMessages(tag, list...); err != nil {
+		slog.Error("Failed to add translations", "language", lang, "error", err)
+	}
+}
+
+// SetOverrideSource makes src supply texts that replace the catalog defaults, reloaded at
+// least every ttl. A nil src removes the overrides.
+func SetOverrideSource(src OverrideSource, ttl time.Duration) {
+	Init()
+	mu.Lock()
+	source, sourceTTL = src, ttl
+	if src == nil {
+		bundle = newBundle(nil)
+	}
+	mu.Unlock()
+	Invalidate()
+}
+
+// Invalidate makes the next lookup rebuild the bundle with the latest overrides
+func Invalidate() {
+	mu.Lock()
+	loadedAt = time.Time{}
+	generation++
+	mu.Unlock()
+}
+
+// currentBundle returns the bundle, rebuilding it first when its overrides are stale
+func currentBundle() *i18n.Bundle {
+	Init()
+	mu.RLock()
+	b, stale := bundle, source != nil && time.Since(loadedAt) >= sourceTTL
+	mu.RUnlock()
+	if !stale {
+		return b
+	}
+
+	// One lookup rebuilds at a time; the ones waiting for it use its result
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	mu.RLock()
+	b, src, gen := bundle, source, generation
+	stale = time.Since(loadedAt) >= sourceTTL
+	mu.RUnlock()
+	if !stale {
+		return b
+	}
+
+	overrides, err := src.Overrides()
+	if err != nil {
+		// Keep serving the current texts and retry once the TTL has passed
+		slog.Error("Failed to load translation overrides", "error", err)
+	} else {
+		b = newBundle(overrides)
+	}
+	mu.Lock()
+	if generation == gen {
+		bundle, loadedAt = b, time.Now()
+	}
+	mu.Unlock()
+	return b
+}
+
+// Defaults returns a copy of the embedded catalog of lang, by message ID
+func Defaults(lang string) map[string]string {
+	Init()
+	messages := make(map[string]string, len(defaults[lang]))
+	for id, text := range defaults[lang] {
+		messages[id] = text
+	}
+	return messages
+}
+
+// DefaultText returns the text of a message in the embedded catalog of lang
+func DefaultText(lang, messageID string) (string, bool) {
+	Init()
+	text, ok := defaults[lang][messageID]
+	return text, ok
+}
+
 // GetLocalizer returns a localizer for the given language
 func GetLocalizer(lang string) *i18n.Localizer {
-	if bundle == nil {
-		Init()
-	}
-	return i18n.NewLocalizer(bundle, lang)
+	return i18n.NewLocalizer(currentBundle(), lang)
 }
 
 // Localize retrieves a localized message
@@ -71,8 +198,123 @@ func Localize(lang, messageID string, templateData map[string]interface{}) strin
 		TemplateData: templateData,
 	})
 	if err != nil {
-		log.Printf("Localization error for %s: %v", messageID, err)
+		slog.Warn("Localization failed", "message_id", messageID, "error", err)
 		return messageID // Fallback to messageID if localization fails
 	}
 	return msg
 }
+
+// Negotiate returns the supported language that best matches the first candidate naming one.
+// Candidates may be language tags or Accept-Language header values; empty ones are skipped.
+func Negotiate(candidates ...string) string {
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		tags, _, err := language.ParseAcceptLanguage(candidate)
+		if err != nil || len(tags) == 0 {
+			continue
+		}
+		_, index, confidence := matcher.Match(tags...)
+		if confidence != language.No {
+			return Languages[index]
+		}
+	}
+	return DefaultLanguage
+}
+
+// IsSupported reports whether lang is one of Languages
+func IsSupported(lang string) bool {
+	for _, supported := range Languages {
+		if lang == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// LocaleCandidates returns the lookup order for content in a locale, such as email templates,
+// static pages and master labels, e.g. "es-MX" -> es-MX, es, en
+func LocaleCandidates(locale string) []string {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	var candidates []string
+	if locale != "" {
+		candidates = append(candidates, locale)
+		if base, _, ok := strings.Cut(locale, "-"); ok {
+			candidates = append(candidates, base)
+		}
+	}
+	if locale != DefaultLanguage {
+		candidates = append(candidates, DefaultLanguage)
+	}
+	return candidates
+}
+
+// RequestLocale returns the locale of the content to serve to the request: the locale query
+// parameter if it is a valid tag, else the negotiated language of the request
+func RequestLocale(c *gin.Context) string {
+	if locale := c.Query("locale"); locale != "" {
+		if tag, err := language.Parse(locale); err == nil {
+			return tag.String()
+		}
+	}
+	return Language(c)
+}
+
+// SetLanguage stores lang and its localizer in the request context
+func SetLanguage(c *gin.Context, lang string) {
+	c.Set(LanguageKey, lang)
+	c.Set(LocalizerKey, GetLocalizer(lang))
+}
+
+// Language returns the language negotiated for the request. Outside the language middleware,
+// it is negotiated from the Accept-Language header.
+func Language(c *gin.Context) string {
+	if lang := c.GetString(LanguageKey); lang != "" {
+		return lang
+	}
+	return Negotiate(c.GetHeader("Accept-Language"))
+}
+
+// FromContext returns the localizer for the request's language
+func FromContext(c *gin.Context) *i18n.Localizer {
+	if localizer, ok := c.Value(LocalizerKey).(*i18n.Localizer); ok {
+		return localizer
+	}
+	return GetLocalizer(Language(c))
+}
+
+// T translates messageID into the request's language, falling back to English and then to
+// the ID itself
+func T(c *gin.Context, messageID string, templateData map[string]interface{}) string {
+	msg, err := FromContext(c).Localize(&i18n.LocalizeConfig{
+		MessageID:    messageID,
+		TemplateData: templateData,
+	})
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "Localization failed", "message_id", messageID, "error", err)
+		return messageID
+	}
+	return msg
+}
+
+// TDefault translates messageID into the request's language, returning defaultMessage when no
+// catalog has the ID
+func TDefault(c *gin.Context, messageID, defaultMessage string) string {
+	msg, err := FromContext(c).Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{ID: messageID, Other: defaultMessage},
+	})
+	if err != nil {
+		return defaultMessage
+	}
+	return msg
+}
+
+// MessageID derives a message ID from English text by lower-casing it and joining its words
+// with underscores, e.g. "admin not found" becomes admin_not_found
+func MessageID(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+	return strings.Join(words, "_")
+}
@@ -0,0 +1,165 @@
+package localization
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []string
+		want       string
+	}{
+		{"none", nil, DefaultLanguage},
+		{"empty", []string{""}, DefaultLanguage},
+		{"exact", []string{"de"}, "de"},
+		{"region", []string{"es-MX"}, "es"},
+		{"header by quality", []string{"fr;q=0.9, de;q=0.8, en;q=0.1"}, "de"},
+		{"unsupported falls through", []string{"ja", "es"}, "es"},
+		{"first match wins", []string{"de", "es"}, "de"},
+		{"empty skipped", []string{"", "de"}, "de"},
+		{"malformed skipped", []string{"!!", "es"}, "es"},
+		{"unsupported only", []string{"ja"}, DefaultLanguage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.candidates...); got != tt.want {
+				t.Errorf("Negotiate(%q) = %q, want %q", tt.candidates, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocaleCandidates(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   []string
+	}{
+		{"", []string{"en"}},
+		{"en", []string{"en"}},
+		{"de", []string{"de", "en"}},
+		{"es-MX", []string{"es-MX", "es", "en"}},
+		{" pt_BR ", []string{"pt-BR", "pt", "en"}},
+	}
+	for _, tt := range tests {
+		if got := LocaleCandidates(tt.locale); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("LocaleCandidates(%q) = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestRequestLocale(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name   string
+		target string
+		header string
+		want   string
+	}{
+		{"query parameter", "/?locale=es-MX", "de", "es-MX"},
+		{"invalid query parameter", "/?locale=!!", "de", "de"},
+		{"header", "/", "es;q=0.9", "es"},
+		{"neither", "/", "", DefaultLanguage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", tt.target, nil)
+			c.Request.Header.Set("Accept-Language", tt.header)
+			if got := RequestLocale(c); got != tt.want {
+				t.Errorf("RequestLocale() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	data := map[string]interface{}{"Field": "email"}
+	tests := []struct {
+		lang      string
+		messageID string
+		want      string
+	}{
+		{"en", "validation_required", "email is required"},
+		{"de", "validation_required", "email ist erforderlich"},
+		{"de-CH", "validation_required", "email ist erforderlich"},
+		{"ja", "validation_required", "email is required"},
+		{"de", "no_such_message", "no_such_message"},
+	}
+	for _, tt := range tests {
+		if got := Localize(tt.lang, tt.messageID, data); got != tt.want {
+			t.Errorf("Localize(%q, %q) = %q, want %q", tt.lang, tt.messageID, got, tt.want)
+		}
+	}
+}
+
+// fakeSource supplies fixed overrides, or fails with err
+type fakeSource struct {
+	overrides map[string]map[string]string
+	err       error
+}
+
+func (s *fakeSource) Overrides() (map[string]map[string]string, error) {
+	return s.overrides, s.err
+}
+
+// useOverrides installs src for the test and removes it afterwards
+func useOverrides(t *testing.T, src OverrideSource, ttl time.Duration) {
+	t.Helper()
+	SetOverrideSource(src, ttl)
+	t.Cleanup(func() { SetOverrideSource(nil, 0) })
+}
+
+func TestOverrideAppliedAfterInvalidate(t *testing.T) {
+	src := &fakeSource{overrides: map[string]map[string]string{"de": {"validation_required": "{{.Field}} fehlt"}}}
+	useOverrides(t, src, time.Hour)
+	data := map[string]interface{}{"Field": "email"}
+
+	if got := Localize("de", "validation_required", data); got != "email fehlt" {
+		t.Errorf("Localize() with an override = %q, want %q", got, "email fehlt")
+	}
+	if got := Localize("en", "validation_required", data); got != "email is required" {
+		t.Errorf("Localize() in another language = %q, want the catalog text", got)
+	}
+
+	src.overrides = map[string]map[string]string{"de": {"validation_required": "{{.Field}} wird benötigt"}}
+	if got := Localize("de", "validation_required", data); got != "email fehlt" {
+		t.Errorf("Localize() before Invalidate = %q, want the cached override", got)
+	}
+	Invalidate()
+	if got := Localize("de", "validation_required", data); got != "email wird benötigt" {
+		t.Errorf("Localize() after Invalidate = %q, want the new override", got)
+	}
+
+	src.overrides = nil
+	Invalidate()
+	if got := Localize("de", "validation_required", data); got != "email ist erforderlich" {
+		t.Errorf("Localize() after removing the override = %q, want the catalog text", got)
+	}
+}
+
+func TestOverrideReloadFailureKeepsBundle(t *testing.T) {
+	src := &fakeSource{overrides: map[string]map[string]string{"en": {"validation_required": "{{.Field}} is missing"}}}
+	// A zero TTL reloads on every lookup
+	useOverrides(t, src, 0)
+	data := map[string]interface{}{"Field": "email"}
+
+	if got := Localize("en", "validation_required", data); got != "email is missing" {
+		t.Fatalf("Localize() = %q, want the override", got)
+	}
+	src.err = errors.New("database unavailable")
+	if got := Localize("en", "validation_required", data); got != "email is missing" {
+		t.Errorf("Localize() after a failed reload = %q, want the override still served", got)
+	}
+	src.err = nil
+	src.overrides = nil
+	if got := Localize("en", "validation_required", data); got != "email is required" {
+		t.Errorf("Localize() after a successful reload = %q, want the catalog text", got)
+	}
+}
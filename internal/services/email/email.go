@@ -0,0 +1,34 @@
+package email
+
+import (
+	"os"
+	"strings"
+
+	"goUniAdmin/internal/config"
+)
+
+// Message is an email ready to be delivered
+type Message struct {
+	To       []string
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// Sender delivers email messages
+type Sender interface {
+	Send(msg Message) error
+}
+
+// NewSender returns the sender selected by the EMAIL_DRIVER setting: "smtp" (default),
+// "log" to print messages to stdout, or "memory" to keep them in memory
+func NewSender(cfg *config.Config) Sender {
+	switch strings.ToLower(cfg.EmailDriver) {
+	case "log":
+		return NewLogSender(os.Stdout)
+	case "memory":
+		return NewMemorySender()
+	default:
+		return NewSMTPSender(cfg)
+	}
+}
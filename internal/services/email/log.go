@@ -0,0 +1,33 @@
+package email
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// LogSender writes messages to an output stream instead of delivering them, for local development
+type LogSender struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewLogSender creates a sender that prints messages to out
+func NewLogSender(out io.Writer) *LogSender {
+	return &LogSender{out: out}
+}
+
+// Send prints the message headers and text body
+func (s *LogSender) Send(msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	body := msg.TextBody
+	if body == "" {
+		body = msg.HTMLBody
+	}
+	_, err := fmt.Fprintf(s.out, "---- email ----\nTo: %s\nSubject: %s\n\n%s\n---------------\n",
+		strings.Join(msg.To, ", "), msg.Subject, body)
+	return err
+}
@@ -0,0 +1,60 @@
+package email
+
+import "goUniAdmin/internal/config"
+
+// SiteSettings supplies live site settings such as site.name
+type SiteSettings interface {
+	GetString(key, defaultValue string) string
+}
+
+// SiteSettingsInstance is set by the settings module so templates see edits without a restart
+var SiteSettingsInstance SiteSettings
+
+// SiteValues returns the placeholders available to every template: AppName from the
+// site.name setting, falling back to appName, and SupportEmail from site.support_email
+func SiteValues(appName string) map[string]interface{} {
+	values := map[string]interface{}{"AppName": appName, "SupportEmail": ""}
+	if SiteSettingsInstance != nil {
+		values["AppName"] = SiteSettingsInstance.GetString("site.name", appName)
+		values["SupportEmail"] = SiteSettingsInstance.GetString("site.support_email", "")
+	}
+	return values
+}
+
+// Mailer renders templates and hands the resulting messages to a Sender
+type Mailer struct {
+	sender   Sender
+	renderer *Renderer
+	appName  string
+}
+
+// NewMailer creates a mailer from a sender and a renderer
+func NewMailer(sender Sender, renderer *Renderer, appName string) *Mailer {
+	return &Mailer{sender: sender, renderer: renderer, appName: appName}
+}
+
+// NewMailerFromConfig creates a mailer using the configured sender and the default renderer
+func NewMailerFromConfig(cfg *config.Config) *Mailer {
+	return NewMailer(NewSender(cfg), DefaultRenderer, cfg.AppName)
+}
+
+// SetSender replaces the sender, e.g. with an in-memory sender in tests
+func (m *Mailer) SetSender(sender Sender) {
+	m.sender = sender
+}
+
+// SendTemplate renders the named template in the given locale and sends it to the recipient.
+// The SiteValues are always available to templates.
+func (m *Mailer) SendTemplate(to, name, locale string, data map[string]interface{}) error {
+	values := SiteValues(m.appName)
+	for k, v := range data {
+		values[k] = v
+	}
+
+	msg, err := m.renderer.Render(name, locale, values)
+	if err != nil {
+		return err
+	}
+	msg.To = []string{to}
+	return m.sender.Send(msg)
+}
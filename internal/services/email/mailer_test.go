@@ -0,0 +1,34 @@
+package email
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMemorySenderCapturesMessages(t *testing.T) {
+	sender := NewMemorySender()
+	mailer := NewMailer(sender, NewRenderer(testSource("en", "de")), "goUniAdmin")
+
+	for _, to := range []string{"ann@example.com", "bo@example.com"} {
+		if err := mailer.SendTemplate(to, "welcome", "de", map[string]interface{}{"FirstName": "Ann"}); err != nil {
+			t.Fatalf("SendTemplate() error = %v", err)
+		}
+	}
+	if err := mailer.SendTemplate("cy@example.com", "goodbye", "en", nil); err == nil {
+		t.Error("SendTemplate() of an unknown template succeeded")
+	}
+
+	messages := sender.Messages()
+	want := []Message{
+		{To: []string{"ann@example.com"}, Subject: "Welcome de", HTMLBody: "<p>Hi Ann</p>", TextBody: "Hi Ann"},
+		{To: []string{"bo@example.com"}, Subject: "Welcome de", HTMLBody: "<p>Hi Ann</p>", TextBody: "Hi Ann"},
+	}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("Messages() = %+v, want %+v", messages, want)
+	}
+
+	messages[0].Subject = "changed"
+	if sender.Messages()[0].Subject != "Welcome de" {
+		t.Error("Messages() returned the sender's own slice")
+	}
+}
@@ -0,0 +1,77 @@
+package email
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"goUniAdmin/internal/config"
+)
+
+// SMTPSender delivers messages through an SMTP server
+type SMTPSender struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPSender creates an SMTP sender from the email settings in config
+func NewSMTPSender(cfg *config.Config) *SMTPSender {
+	return &SMTPSender{
+		host:     cfg.EmailHost,
+		port:     cfg.EmailPort,
+		username: cfg.EmailUsername,
+		password: cfg.EmailPassword,
+		from:     cfg.EmailFrom,
+	}
+}
+
+// Send delivers the message as multipart/alternative with text and HTML parts
+func (s *SMTPSender) Send(msg Message) error {
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+
+	body, err := buildMIME(s.from, msg)
+	if err != nil {
+		return err
+	}
+	if err := smtp.SendMail(s.host+":"+s.port, auth, s.from, msg.To, body); err != nil {
+		return fmt.Errorf("failed to send email: %v", err)
+	}
+	return nil
+}
+
+// buildMIME renders the raw message bytes including headers
+func buildMIME(from string, msg Message) ([]byte, error) {
+	boundaryBytes := make([]byte, 12)
+	if _, err := rand.Read(boundaryBytes); err != nil {
+		return nil, err
+	}
+	boundary := hex.EncodeToString(boundaryBytes)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", boundary)
+
+	if msg.TextBody != "" {
+		fmt.Fprintf(&buf, "--%s\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n%s\r\n", boundary, msg.TextBody)
+	}
+	if msg.HTMLBody != "" {
+		fmt.Fprintf(&buf, "--%s\r\nContent-Type: text/html; charset=utf-8\r\n\r\n%s\r\n", boundary, msg.HTMLBody)
+	}
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+	return buf.Bytes(), nil
+}
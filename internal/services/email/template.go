@@ -0,0 +1,173 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+
+	localization "goUniAdmin/internal/services/common"
+)
+
+// ErrTemplateNotFound is returned when no source provides a template in any candidate locale
+var ErrTemplateNotFound = errors.New("email template not found")
+
+// Template is a transactional email template in a single locale. Subject and TextBody are
+// rendered with text/template, HTMLBody with html/template so data is escaped.
+type Template struct {
+	Name     string
+	Locale   string
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// TemplateSource looks up templates by name and exact locale
+type TemplateSource interface {
+	FindTemplate(name, locale string) (Template, bool, error)
+}
+
+//go:embed templates
+var embeddedTemplates embed.FS
+
+// FSSource loads templates from a file tree laid out as <locale>/<name>.subject,
+// <locale>/<name>.html and <locale>/<name>.txt
+type FSSource struct {
+	fsys fs.FS
+}
+
+// NewFSSource creates a template source reading from fsys
+func NewFSSource(fsys fs.FS) *FSSource {
+	return &FSSource{fsys: fsys}
+}
+
+// FindTemplate reads the template files for name in locale
+func (s *FSSource) FindTemplate(name, locale string) (Template, bool, error) {
+	subject, err := fs.ReadFile(s.fsys, locale+"/"+name+".subject")
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Template{}, false, nil
+		}
+		return Template{}, false, err
+	}
+
+	tmpl := Template{Name: name, Locale: locale, Subject: strings.TrimSpace(string(subject))}
+	if html, err := fs.ReadFile(s.fsys, locale+"/"+name+".html"); err == nil {
+		tmpl.HTMLBody = string(html)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return Template{}, false, err
+	}
+	if text, err := fs.ReadFile(s.fsys, locale+"/"+name+".txt"); err == nil {
+		tmpl.TextBody = string(text)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return Template{}, false, err
+	}
+	return tmpl, true, nil
+}
+
+// Renderer resolves templates from its sources, in order, and renders them into messages
+type Renderer struct {
+	mu      sync.RWMutex
+	sources []TemplateSource
+}
+
+// NewRenderer creates a renderer consulting the given sources in order
+func NewRenderer(sources ...TemplateSource) *Renderer {
+	return &Renderer{sources: sources}
+}
+
+// DefaultRenderer renders the templates shipped with the application. Modules may
+// prepend sources to it, e.g. to let admins override the built-in copy.
+var DefaultRenderer = NewRenderer(NewFSSource(mustSub(embeddedTemplates, "templates")))
+
+// PrependSource adds a source that takes precedence over the existing ones
+func (r *Renderer) PrependSource(source TemplateSource) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources = append([]TemplateSource{source}, r.sources...)
+}
+
+// Find returns the template for name, trying the locale, its base language and then the default language
+func (r *Renderer) Find(name, locale string) (Template, error) {
+	r.mu.RLock()
+	sources := r.sources
+	r.mu.RUnlock()
+
+	for _, candidate := range localization.LocaleCandidates(locale) {
+		for _, source := range sources {
+			tmpl, ok, err := source.FindTemplate(name, candidate)
+			if err != nil {
+				return Template{}, err
+			}
+			if ok {
+				return tmpl, nil
+			}
+		}
+	}
+	return Template{}, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+}
+
+// Render finds the template for name and locale and renders it with data
+func (r *Renderer) Render(name, locale string, data map[string]interface{}) (Message, error) {
+	tmpl, err := r.Find(name, locale)
+	if err != nil {
+		return Message{}, err
+	}
+	return RenderTemplate(tmpl, data)
+}
+
+// RenderTemplate renders a template with data. Missing keys are treated as errors so that
+// broken placeholders are not silently sent to recipients.
+func RenderTemplate(tmpl Template, data map[string]interface{}) (Message, error) {
+	var msg Message
+	var err error
+
+	if msg.Subject, err = renderText(tmpl.Name+".subject", tmpl.Subject, data); err != nil {
+		return Message{}, err
+	}
+	if msg.TextBody, err = renderText(tmpl.Name+".txt", tmpl.TextBody, data); err != nil {
+		return Message{}, err
+	}
+	if tmpl.HTMLBody != "" {
+		t, err := htmltemplate.New(tmpl.Name + ".html").Option("missingkey=error").Parse(tmpl.HTMLBody)
+		if err != nil {
+			return Message{}, err
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, data); err != nil {
+			return Message{}, err
+		}
+		msg.HTMLBody = buf.String()
+	}
+	return msg, nil
+}
+
+// renderText renders a text/template string
+func renderText(name, text string, data map[string]interface{}) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	t, err := texttemplate.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// mustSub returns the subtree of fsys rooted at dir
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
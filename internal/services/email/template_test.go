@@ -0,0 +1,83 @@
+package email
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// testSource returns a source with a "welcome" template whose subject names its locale
+func testSource(locales ...string) *FSSource {
+	fsys := fstest.MapFS{}
+	for _, locale := range locales {
+		fsys[locale+"/welcome.subject"] = &fstest.MapFile{Data: []byte("Welcome " + locale + "\n")}
+		fsys[locale+"/welcome.txt"] = &fstest.MapFile{Data: []byte("Hi {{.FirstName}}")}
+		fsys[locale+"/welcome.html"] = &fstest.MapFile{Data: []byte("<p>Hi {{.FirstName}}</p>")}
+	}
+	return NewFSSource(fsys)
+}
+
+func TestRendererLocaleFallback(t *testing.T) {
+	renderer := NewRenderer(testSource("en", "es", "es-MX"))
+	renderer.PrependSource(testSource("de"))
+
+	tests := []struct {
+		locale      string
+		wantSubject string
+	}{
+		{"es-MX", "Welcome es-MX"},
+		{"es-AR", "Welcome es"},
+		{"es", "Welcome es"},
+		{"de-AT", "Welcome de"},
+		{"fr", "Welcome en"},
+		{"", "Welcome en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			msg, err := renderer.Render("welcome", tt.locale, map[string]interface{}{"FirstName": "Ann"})
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if msg.Subject != tt.wantSubject || msg.TextBody != "Hi Ann" || msg.HTMLBody != "<p>Hi Ann</p>" {
+				t.Errorf("Render(%q) = %+v, want subject %q", tt.locale, msg, tt.wantSubject)
+			}
+		})
+	}
+}
+
+func TestRendererPrependedSourceWins(t *testing.T) {
+	override := NewFSSource(fstest.MapFS{"en/welcome.subject": &fstest.MapFile{Data: []byte("Custom welcome")}})
+	renderer := NewRenderer(testSource("en"))
+	renderer.PrependSource(override)
+
+	msg, err := renderer.Render("welcome", "en", nil)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if msg.Subject != "Custom welcome" || msg.TextBody != "" {
+		t.Errorf("Render() = %+v, want the prepended template", msg)
+	}
+}
+
+func TestRendererErrors(t *testing.T) {
+	renderer := NewRenderer(testSource("en"))
+
+	if _, err := renderer.Render("goodbye", "en", nil); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("Render() of an unknown template error = %v, want ErrTemplateNotFound", err)
+	}
+	if _, err := renderer.Render("welcome", "en", map[string]interface{}{}); err == nil || !strings.Contains(err.Error(), "FirstName") {
+		t.Errorf("Render() without FirstName error = %v, want a missing key error", err)
+	}
+}
+
+func TestRenderTemplateEscapesHTML(t *testing.T) {
+	tmpl := Template{Name: "note", Subject: "{{.Name}}", HTMLBody: "<p>{{.Name}}</p>", TextBody: "{{.Name}}"}
+	msg, err := RenderTemplate(tmpl, map[string]interface{}{"Name": "<b>Ann</b>"})
+	if err != nil {
+		t.Fatalf("RenderTemplate() error = %v", err)
+	}
+	if msg.HTMLBody != "<p>&lt;b&gt;Ann&lt;/b&gt;</p>" || msg.TextBody != "<b>Ann</b>" {
+		t.Errorf("RenderTemplate() = %+v, want only the HTML body escaped", msg)
+	}
+}
@@ -0,0 +1,24 @@
+package logger
+
+import "context"
+
+// requestIDKey is the context key holding the request ID
+type requestIDKey struct{}
+
+// RequestIDAttr is the attribute name of the request ID in log records
+const RequestIDAttr = "request_id"
+
+// WithRequestID returns a copy of ctx carrying the request ID. Records logged with the
+// context, including those of GORM queries run with it, are tagged with the ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx, or "" when there is none
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
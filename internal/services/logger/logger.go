@@ -0,0 +1,56 @@
+// Package logger configures structured, leveled logging on log/slog. Every record is scrubbed
+// of secrets and tagged with the request ID carried by its context.
+package logger
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"goUniAdmin/internal/config"
+)
+
+// Init builds the logger described by cfg and installs it as the slog default. The standard
+// log package writes through it too, so older log.Printf calls are redacted as well.
+func Init(cfg *config.Config) *slog.Logger {
+	logger := New(os.Stdout, cfg)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// New returns a logger writing to w at the LOG_LEVEL level: JSON lines in production, text
+// otherwise
+func New(w io.Writer, cfg *config.Config) *slog.Logger {
+	options := &slog.HandlerOptions{Level: ParseLevel(cfg.LogLevel)}
+	var base slog.Handler
+	if isProduction(cfg.Environment) {
+		base = slog.NewJSONHandler(w, options)
+	} else {
+		base = slog.NewTextHandler(w, options)
+	}
+	return slog.New(&handler{next: base}).With("app", cfg.AppName)
+}
+
+// ParseLevel converts a LOG_LEVEL value (debug, info, warn or error) to a level, defaulting to info
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// isProduction reports whether ENVIRONMENT names a production deployment
+func isProduction(environment string) bool {
+	switch strings.ToLower(environment) {
+	case "production", "prod":
+		return true
+	}
+	return false
+}
@@ -1,30 +1,69 @@
 package middleware
 
 import (
+	"math"
 	"net/http"
 	"strings"
+	"time"
 
 	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/response"
+	"goUniAdmin/internal/services/revocation"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
+const (
+	// TokenTypeAccess marks tokens that grant access to protected routes
+	TokenTypeAccess = "access"
+	// TokenTypeChallenge marks the short-lived token returned by login when 2FA is enabled
+	TokenTypeChallenge = "2fa_challenge"
+	// TokenTypeInvitation marks the signed token in an admin invitation link
+	TokenTypeInvitation = "invitation"
+)
+
+var (
+	// ErrAuthRequired is returned for a protected route called without credentials
+	ErrAuthRequired = apperror.New(http.StatusUnauthorized, "AUTH_REQUIRED", "authorization header required")
+	// ErrInvalidToken is returned for a malformed, expired or wrongly typed access token
+	ErrInvalidToken = apperror.New(http.StatusUnauthorized, "INVALID_TOKEN", "invalid or expired token")
+	// ErrTokenRevoked is returned for an access token revoked by logout or account changes
+	ErrTokenRevoked = apperror.New(http.StatusUnauthorized, "TOKEN_REVOKED", "token has been revoked")
+)
+
+// AdminAccount is the state of the signed-in admin that AuthMiddleware checks on every request
+type AdminAccount struct {
+	Active   bool   // False once the admin is deleted or deactivated
+	Language string // Language chosen for API messages, or "" if none
+}
+
+// AdminAccounts looks up the signed-in admin's account. AuthMiddleware calls it once per
+// authenticated request, so it costs one primary key lookup each time. The same lookup serves
+// the admin's language, and it keeps tokens of deleted or deactivated admins from working when
+// revoking them failed or has not reached this instance's revocation cache yet.
+type AdminAccounts interface {
+	AdminAccount(adminID uuid.UUID) (AdminAccount, error)
+}
+
+// AdminAccountsInstance is the lookup used by AuthMiddleware, provided by the admin module
+var AdminAccountsInstance AdminAccounts
+
 // AuthMiddleware verifies JWT tokens
 func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "Authorization header required"})
-			c.Abort()
+			response.Error(c, ErrAuthRequired)
 			return
 		}
 
 		// Expect "Bearer <token>"
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "Invalid Authorization header format"})
-			c.Abort()
+			response.Error(c, apperror.Unauthorized("invalid authorization header format"))
 			return
 		}
 
@@ -37,26 +76,79 @@ func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 		})
 
 		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "Invalid or expired token"})
-			c.Abort()
+			response.Error(c, ErrInvalidToken)
 			return
 		}
 
 		// Extract admin ID from token claims and set it in context
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			adminID, ok := claims["id"].(string)
-			if !ok {
-				c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "Invalid token claims"})
-				c.Abort()
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok {
+			response.Error(c, ErrInvalidToken)
+			return
+		}
+		// Only access tokens are accepted; e.g. a 2FA challenge token must not grant access
+		if typ, _ := claims["typ"].(string); typ != "" && typ != TokenTypeAccess {
+			response.Error(c, ErrInvalidToken)
+			return
+		}
+		adminIDStr, ok := claims["id"].(string)
+		if !ok {
+			response.Error(c, ErrInvalidToken)
+			return
+		}
+		adminID, err := uuid.Parse(adminIDStr)
+		if err != nil {
+			response.Error(c, ErrInvalidToken)
+			return
+		}
+
+		// Reject tokens revoked by logout, or by deleting or deactivating the admin
+		jti, _ := claims["jti"].(string)
+		issuedAt := IssuedAt(claims)
+		var expiresAt time.Time
+		if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+			expiresAt = exp.Time
+		}
+		if revocation.StoreInstance != nil && revocation.StoreInstance.IsRevoked(jti, adminID, issuedAt) {
+			response.Error(c, ErrTokenRevoked)
+			return
+		}
+
+		// Tokens of deleted or deactivated admins stop working even if revoking them failed
+		var account AdminAccount
+		if AdminAccountsInstance != nil {
+			account, err = AdminAccountsInstance.AdminAccount(adminID)
+			if err != nil {
+				response.Error(c, err)
+				return
+			}
+			if !account.Active {
+				response.Error(c, ErrTokenRevoked)
 				return
 			}
-			c.Set("adminID", adminID)
-		} else {
-			c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "Invalid token claims"})
-			c.Abort()
-			return
 		}
 
+		c.Set("adminID", adminIDStr)
+		c.Set("tokenID", jti)
+		c.Set("tokenExpiresAt", expiresAt)
+		applyAdminLanguage(c, account.Language)
+
 		c.Next()
 	}
 }
+
+// IssuedAtClaim returns the iat claim for a token issued at t. It keeps microseconds, so a
+// token issued right after revocation.Store.RevokeAdmin, within the same second, stays valid.
+func IssuedAtClaim(t time.Time) float64 {
+	return float64(t.UnixMicro()) / 1e6
+}
+
+// IssuedAt reads the iat claim set by IssuedAtClaim. It is read directly, since the jwt
+// package truncates it to whole seconds; the zero time is returned when it is missing.
+func IssuedAt(claims jwt.MapClaims) time.Time {
+	iat, ok := claims["iat"].(float64)
+	if !ok {
+		return time.Time{}
+	}
+	return time.UnixMicro(int64(math.Round(iat * 1e6)))
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestIssuedAt(t *testing.T) {
+	now := time.Date(2024, 3, 1, 10, 0, 0, 123456789, time.UTC)
+
+	// Round trip through a signed token, as the claims are decoded from JSON
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"iat": IssuedAtClaim(now)}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(signed, claims, func(*jwt.Token) (interface{}, error) { return []byte("secret"), nil }); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := IssuedAt(claims), now.Truncate(time.Microsecond); !got.Equal(want) {
+		t.Errorf("IssuedAt() = %v, want %v", got, want)
+	}
+
+	if got := IssuedAt(jwt.MapClaims{}); !got.IsZero() {
+		t.Errorf("IssuedAt() without iat = %v, want the zero time", got)
+	}
+}
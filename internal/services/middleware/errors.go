@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"goUniAdmin/internal/services/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorHandler renders the last error recorded with c.Error as the standard error envelope.
+// Handlers report a failure with c.Error(err) and return; middleware that aborts a request
+// writes its response directly with response.Error.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		response.Error(c, c.Errors.Last().Err)
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	localization "goUniAdmin/internal/services/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Language negotiates the language of the request from the lang query parameter, else the
+// Accept-Language header, and stores it with its localizer in the context. AuthMiddleware
+// later applies the signed-in admin's stored preference when no lang parameter was given.
+func Language() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		localization.SetLanguage(c, localization.Negotiate(c.Query("lang"), c.GetHeader("Accept-Language")))
+		c.Next()
+	}
+}
+
+// applyAdminLanguage switches the request to the admin's preferred language, which outranks
+// Accept-Language but not an explicit lang parameter
+func applyAdminLanguage(c *gin.Context, lang string) {
+	if c.Query("lang") == "" && localization.IsSupported(lang) {
+		localization.SetLanguage(c, lang)
+	}
+}
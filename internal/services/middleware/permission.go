@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+
+	"goUniAdmin/internal/services/apperror"
+	"goUniAdmin/internal/services/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PermissionChecker resolves whether an admin has been granted a permission
+type PermissionChecker interface {
+	HasPermission(adminID uuid.UUID, permission string) (bool, error)
+}
+
+// ErrPermissionDenied is returned when the admin lacks the permission a route requires
+var ErrPermissionDenied = apperror.New(http.StatusForbidden, "PERMISSION_DENIED", "you do not have permission to perform this action")
+
+// PermissionCheckerInstance is the checker used by RequirePermission, provided by the roles module
+var PermissionCheckerInstance PermissionChecker
+
+// permissions holds every permission string guarded by RequirePermission
+var (
+	permissionsMu sync.Mutex
+	permissions   = map[string]struct{}{}
+)
+
+// RequirePermission allows the request only if the authenticated admin holds the permission.
+// It must be used after AuthMiddleware, which sets the adminID context key.
+func RequirePermission(permission string) gin.HandlerFunc {
+	permissionsMu.Lock()
+	permissions[permission] = struct{}{}
+	permissionsMu.Unlock()
+
+	return func(c *gin.Context) {
+		adminID, err := uuid.Parse(c.GetString("adminID"))
+		if err != nil {
+			response.Error(c, ErrInvalidToken)
+			return
+		}
+
+		if PermissionCheckerInstance == nil {
+			slog.ErrorContext(c.Request.Context(), "Permission requested but no permission checker is configured", "permission", permission)
+			response.Error(c, ErrPermissionDenied)
+			return
+		}
+
+		allowed, err := PermissionCheckerInstance.HasPermission(adminID, permission)
+		if err != nil {
+			response.Error(c, apperror.Internal(err))
+			return
+		}
+		if !allowed {
+			response.Error(c, ErrPermissionDenied)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RegisteredPermissions returns the sorted list of permissions guarded by RequirePermission
+func RegisteredPermissions() []string {
+	permissionsMu.Lock()
+	defer permissionsMu.Unlock()
+
+	list := make([]string, 0, len(permissions))
+	for p := range permissions {
+		list = append(list, p)
+	}
+	sort.Strings(list)
+	return list
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// checkerFunc adapts a function to PermissionChecker
+type checkerFunc func(adminID uuid.UUID, permission string) (bool, error)
+
+func (f checkerFunc) HasPermission(adminID uuid.UUID, permission string) (bool, error) {
+	return f(adminID, permission)
+}
+
+func TestRequirePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	adminID := uuid.New()
+	holds := checkerFunc(func(id uuid.UUID, permission string) (bool, error) {
+		return id == adminID && permission == "admins:delete", nil
+	})
+
+	tests := []struct {
+		name    string
+		checker PermissionChecker
+		adminID string
+		want    int
+	}{
+		{"granted", holds, adminID.String(), http.StatusOK},
+		{"not granted", checkerFunc(func(uuid.UUID, string) (bool, error) { return false, nil }), adminID.String(), http.StatusForbidden},
+		{"missing checker", nil, adminID.String(), http.StatusForbidden},
+		{"checker error", checkerFunc(func(uuid.UUID, string) (bool, error) { return false, errors.New("db down") }), adminID.String(), http.StatusInternalServerError},
+		{"no authenticated admin", holds, "", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := PermissionCheckerInstance
+			PermissionCheckerInstance = tt.checker
+			defer func() { PermissionCheckerInstance = previous }()
+
+			router := gin.New()
+			router.DELETE("/admins/:id", func(c *gin.Context) {
+				c.Set("adminID", tt.adminID)
+			}, RequirePermission("admins:delete"), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/admins/1", nil))
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisteredPermissions(t *testing.T) {
+	RequirePermission("zz:read")
+	RequirePermission("zz:read")
+	count := 0
+	for _, p := range RegisteredPermissions() {
+		if p == "zz:read" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("RegisteredPermissions() lists zz:read %d times, want once", count)
+	}
+}
@@ -0,0 +1,169 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"goUniAdmin/internal/services/apperror"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Keyset pagination orders rows by (created_at, id) and continues from the last row seen
+// instead of skipping OFFSET rows, so pages stay fast on large tables and rows inserted
+// while paging do not shift later pages.
+const (
+	// KeysetTimeColumn is the first column of the keyset order
+	KeysetTimeColumn = "created_at"
+	// KeysetIDColumn breaks ties between rows created at the same time
+	KeysetIDColumn = "id"
+)
+
+// ErrInvalidCursor is returned for a cursor that cannot be decoded
+var ErrInvalidCursor = apperror.New(http.StatusBadRequest, "INVALID_CURSOR", "invalid cursor")
+
+// Cursor is the position of a row in keyset order. It is sent to clients as an opaque string.
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`          // UUID primary key
+	Backward  bool      `json:"b,omitempty"` // Page towards the start of the list (prev_cursor)
+}
+
+// Cursors are the opaque positions of the pages before and after a keyset page. A cursor is
+// empty when there is no such page.
+type Cursors struct {
+	Next string `json:"next_cursor"`
+	Prev string `json:"prev_cursor"`
+}
+
+// CursorPage is a request for one page in keyset order
+type CursorPage struct {
+	Cursor *Cursor // Position to continue from; nil for the first page
+	Limit  int
+	Desc   bool // Newest first
+}
+
+// EncodeCursor returns the opaque string form of a cursor
+func EncodeCursor(c Cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor produced by EncodeCursor. Cursors are client input, so the ID
+// is checked to be a UUID here rather than failing later as a database error.
+func DecodeCursor(s string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.CreatedAt.IsZero() {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if _, err := uuid.Parse(c.ID); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// ParseCursorPage reports whether the request asks for keyset pagination, i.e. has a cursor
+// parameter (empty for the first page), and builds the page. The sort in params must be
+// createdAt in either direction, since that is the only order a cursor can continue.
+func ParseCursorPage(values url.Values, params Params, limit int) (CursorPage, bool, error) {
+	if _, ok := values["cursor"]; !ok {
+		return CursorPage{}, false, nil
+	}
+
+	if len(params.Sort) != 2 || params.Sort[0].Column != KeysetTimeColumn || params.Sort[1].Column != KeysetIDColumn ||
+		params.Sort[0].Desc != params.Sort[1].Desc {
+		return CursorPage{}, true, apperror.Validationf("cursor pagination only supports sorting by createdAt")
+	}
+
+	page := CursorPage{Limit: limit, Desc: params.Sort[0].Desc}
+	if raw := values.Get("cursor"); raw != "" {
+		c, err := DecodeCursor(raw)
+		if err != nil {
+			return CursorPage{}, true, err
+		}
+		page.Cursor = &c
+	}
+	return page, true, nil
+}
+
+// IncludeTotal reads the include_total flag, falling back to the given default
+func IncludeTotal(values url.Values, defaultValue bool) (bool, error) {
+	raw := values.Get("include_total")
+	if raw == "" {
+		return defaultValue, nil
+	}
+	b, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, apperror.Validationf("include_total must be true or false")
+	}
+	return b, nil
+}
+
+// Apply adds the keyset condition, order and limit. One extra row is fetched so CursorResult
+// can tell whether another page exists.
+func (p CursorPage) Apply(db *gorm.DB) *gorm.DB {
+	// Walking backward reverses the order; CursorResult restores it
+	desc := p.Desc
+	if p.Cursor != nil && p.Cursor.Backward {
+		desc = !desc
+	}
+
+	if p.Cursor != nil {
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		db = db.Where("("+KeysetTimeColumn+", "+KeysetIDColumn+") "+op+" (?, ?)", p.Cursor.CreatedAt, p.Cursor.ID)
+	}
+
+	direction := " ASC"
+	if desc {
+		direction = " DESC"
+	}
+	return db.Order(KeysetTimeColumn + direction).Order(KeysetIDColumn + direction).Limit(p.Limit + 1)
+}
+
+// CursorResult trims the extra row fetched by Apply, restores the order of backward pages and
+// returns the cursors for the neighbouring pages.
+func CursorResult[T any](p CursorPage, rows []T, key func(T) (time.Time, string)) ([]T, Cursors) {
+	hasMore := len(rows) > p.Limit
+	if hasMore {
+		rows = rows[:p.Limit]
+	}
+	backward := p.Cursor != nil && p.Cursor.Backward
+	if backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+	if len(rows) == 0 {
+		return rows, Cursors{}
+	}
+
+	// Walking forward, there is an earlier page unless this is the first one; walking
+	// backward, there is always a later page (the one the cursor came from)
+	hasNext, hasPrev := hasMore, p.Cursor != nil
+	if backward {
+		hasNext, hasPrev = true, hasMore
+	}
+
+	var cursors Cursors
+	if hasNext {
+		t, id := key(rows[len(rows)-1])
+		cursors.Next = EncodeCursor(Cursor{CreatedAt: t, ID: id})
+	}
+	if hasPrev {
+		t, id := key(rows[0])
+		cursors.Prev = EncodeCursor(Cursor{CreatedAt: t, ID: id, Backward: true})
+	}
+	return rows, cursors
+}
@@ -0,0 +1,141 @@
+package query
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type row struct {
+	createdAt time.Time
+	name      string
+	id        string
+}
+
+func rowKey(r row) (time.Time, string) {
+	return r.createdAt, r.id
+}
+
+// rows returns rows created a minute apart, named for readable assertions
+func rows(names ...string) []row {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	out := make([]row, len(names))
+	for i, name := range names {
+		out[i] = row{createdAt: base.Add(time.Duration(i) * time.Minute), name: name, id: uuid.NewString()}
+	}
+	return out
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	c := Cursor{CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), ID: uuid.NewString(), Backward: true}
+	got, err := DecodeCursor(EncodeCursor(c))
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if !got.CreatedAt.Equal(c.CreatedAt) || got.ID != c.ID || !got.Backward {
+		t.Errorf("DecodeCursor() = %+v, want %+v", got, c)
+	}
+
+	malformed := []string{
+		"not base64!",
+		"bnVsbA",
+		EncodeCursor(Cursor{ID: c.ID}),
+		EncodeCursor(Cursor{CreatedAt: c.CreatedAt}),
+		EncodeCursor(Cursor{CreatedAt: c.CreatedAt, ID: "abc"}),
+		EncodeCursor(Cursor{CreatedAt: c.CreatedAt, ID: "' OR 1=1 --"}),
+	}
+	for _, bad := range malformed {
+		if _, err := DecodeCursor(bad); err != ErrInvalidCursor {
+			t.Errorf("DecodeCursor(%q) error = %v, want ErrInvalidCursor", bad, err)
+		}
+	}
+}
+
+func TestCursorResult(t *testing.T) {
+	all := rows("a", "b", "c")
+	cursor := &Cursor{CreatedAt: time.Now(), ID: uuid.NewString()}
+	backward := &Cursor{CreatedAt: time.Now(), ID: uuid.NewString(), Backward: true}
+
+	tests := []struct {
+		name     string
+		page     CursorPage
+		rows     []row
+		wantRows []string
+		wantNext bool
+		wantPrev bool
+	}{
+		{"first page with more", CursorPage{Limit: 2}, all, []string{"a", "b"}, true, false},
+		{"first and only page", CursorPage{Limit: 3}, all, []string{"a", "b", "c"}, false, false},
+		{"middle page", CursorPage{Limit: 2, Cursor: cursor}, all, []string{"a", "b"}, true, true},
+		{"last page", CursorPage{Limit: 5, Cursor: cursor}, all, []string{"a", "b", "c"}, false, true},
+		{"backward page with more", CursorPage{Limit: 2, Cursor: backward}, all, []string{"b", "a"}, true, true},
+		{"backward to the start", CursorPage{Limit: 5, Cursor: backward}, all, []string{"c", "b", "a"}, true, false},
+		{"empty", CursorPage{Limit: 2, Cursor: cursor}, nil, []string{}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := append([]row(nil), tt.rows...)
+			got, cursors := CursorResult(tt.page, input, rowKey)
+
+			names := []string{}
+			for _, r := range got {
+				names = append(names, r.name)
+			}
+			if !reflect.DeepEqual(names, tt.wantRows) {
+				t.Errorf("rows = %v, want %v", names, tt.wantRows)
+			}
+			if (cursors.Next != "") != tt.wantNext || (cursors.Prev != "") != tt.wantPrev {
+				t.Fatalf("cursors = %+v, want next %v prev %v", cursors, tt.wantNext, tt.wantPrev)
+			}
+			if cursors.Next != "" {
+				last := got[len(got)-1]
+				next, err := DecodeCursor(cursors.Next)
+				if err != nil || next.ID != last.id || next.Backward {
+					t.Errorf("next cursor = %+v, %v, want forward from %s", next, err, last.name)
+				}
+			}
+			if cursors.Prev != "" {
+				prev, err := DecodeCursor(cursors.Prev)
+				if err != nil || prev.ID != got[0].id || !prev.Backward {
+					t.Errorf("prev cursor = %+v, %v, want backward from %s", prev, err, got[0].name)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCursorPage(t *testing.T) {
+	byCreated, _ := Parse(url.Values{}, testSpec)
+	byName, _ := Parse(url.Values{"sort": {"name"}}, testSpec)
+	valid := EncodeCursor(Cursor{CreatedAt: time.Now(), ID: uuid.NewString()})
+	badID := EncodeCursor(Cursor{CreatedAt: time.Now(), ID: "abc"})
+
+	tests := []struct {
+		name      string
+		query     url.Values
+		params    Params
+		wantKeyed bool
+		wantErr   bool
+	}{
+		{"offset pagination", url.Values{}, byCreated, false, false},
+		{"first keyset page", url.Values{"cursor": {""}}, byCreated, true, false},
+		{"next keyset page", url.Values{"cursor": {valid}}, byCreated, true, false},
+		{"unsupported sort", url.Values{"cursor": {""}}, byName, true, true},
+		{"malformed cursor", url.Values{"cursor": {"garbage"}}, byCreated, true, true},
+		{"cursor with a malformed id", url.Values{"cursor": {badID}}, byCreated, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, keyed, err := ParseCursorPage(tt.query, tt.params, 20)
+			if keyed != tt.wantKeyed || (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCursorPage() = %v, %v, want keyed %v, error %v", keyed, err, tt.wantKeyed, tt.wantErr)
+			}
+			if keyed && err == nil && (page.Limit != 20 || !page.Desc) {
+				t.Errorf("page = %+v, want limit 20 newest first", page)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// Package query turns list endpoint query parameters into GORM conditions. A module declares
+// a Spec with the columns it allows to be searched, filtered and sorted, parses the request's
+// query string with Parse and applies the result to its base query. Only whitelisted columns
+// ever reach SQL, so user input cannot inject column names.
+package query
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"goUniAdmin/internal/services/apperror"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FilterType controls how a filter parameter is parsed and compared
+type FilterType int
+
+const (
+	// FilterIn matches one or more comma-separated values exactly
+	FilterIn FilterType = iota
+	// FilterBool matches true or false
+	FilterBool
+	// FilterFrom matches values at or after a date or RFC 3339 timestamp
+	FilterFrom
+	// FilterTo matches values at or before a timestamp, or anywhere on a given date
+	FilterTo
+	// FilterUUID matches one or more comma-separated UUIDs exactly, e.g. on a uuid column
+	FilterUUID
+)
+
+// dateLayout is the date-only format accepted by FilterFrom and FilterTo
+const dateLayout = "2006-01-02"
+
+// Filter maps a query parameter to a column
+type Filter struct {
+	Column string
+	Type   FilterType
+}
+
+// Spec declares the parameters a list endpoint accepts
+type Spec struct {
+	SearchColumns []string          // Columns matched by ?search=
+	Filters       map[string]Filter // Query parameter name -> filter
+	Sorts         map[string]string // Sort key used in ?sort= -> column
+	DefaultSort   string            // Used when ?sort= is absent, e.g. "-createdAt"
+	TieBreaker    string            // Unique column appended to every sort so pages are stable, e.g. "id"
+}
+
+// SortField is a single column to order by
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+// condition is a parsed filter ready to be applied
+type condition struct {
+	sql   string
+	value interface{}
+}
+
+// Params is a parsed list request
+type Params struct {
+	Search     string
+	Sort       []SortField
+	conditions []condition
+	searchCols []string
+}
+
+// Parse reads search, filter and sort parameters from a query string. Unknown sort keys and
+// malformed filter values are reported as errors so clients learn about typos.
+//
+// Sorting uses ?sort=key1,-key2 where a leading "-" sorts descending.
+func Parse(values url.Values, spec Spec) (Params, error) {
+	params := Params{
+		Search:     strings.TrimSpace(values.Get("search")),
+		searchCols: spec.SearchColumns,
+	}
+
+	names := make([]string, 0, len(spec.Filters))
+	for name := range spec.Filters {
+		names = append(names, name)
+	}
+	sort.Strings(names) // Deterministic SQL for the same request
+
+	for _, name := range names {
+		filter := spec.Filters[name]
+		raw := strings.TrimSpace(values.Get(name))
+		if raw == "" {
+			continue
+		}
+		cond, err := parseFilter(name, raw, filter)
+		if err != nil {
+			return Params{}, err
+		}
+		params.conditions = append(params.conditions, cond)
+	}
+
+	sortParam := strings.TrimSpace(values.Get("sort"))
+	if sortParam == "" {
+		sortParam = spec.DefaultSort
+	}
+	seen := map[string]bool{}
+	for _, key := range strings.Split(sortParam, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		desc := strings.HasPrefix(key, "-")
+		key = strings.TrimPrefix(key, "-")
+		column, ok := spec.Sorts[key]
+		if !ok {
+			return Params{}, apperror.Validationf("cannot sort by %q", key)
+		}
+		if seen[column] {
+			continue
+		}
+		seen[column] = true
+		params.Sort = append(params.Sort, SortField{Column: column, Desc: desc})
+	}
+	if spec.TieBreaker != "" && !seen[spec.TieBreaker] {
+		// Follow the direction of the last field so the tie-breaker fits a composite index
+		desc := len(params.Sort) > 0 && params.Sort[len(params.Sort)-1].Desc
+		params.Sort = append(params.Sort, SortField{Column: spec.TieBreaker, Desc: desc})
+	}
+	return params, nil
+}
+
+// Where applies the search and filter conditions. Use it for both the count and the page query.
+func (p Params) Where(db *gorm.DB) *gorm.DB {
+	for _, cond := range p.conditions {
+		db = db.Where(cond.sql, cond.value)
+	}
+
+	// Every search term must match at least one of the search columns
+	if p.Search != "" && len(p.searchCols) > 0 {
+		for _, term := range strings.Fields(p.Search) {
+			pattern := "%" + escapeLike(strings.ToLower(term)) + "%"
+			clauses := make([]string, len(p.searchCols))
+			args := make([]interface{}, len(p.searchCols))
+			for i, column := range p.searchCols {
+				clauses[i] = "LOWER(" + column + ") LIKE ? ESCAPE '\\'"
+				args[i] = pattern
+			}
+			db = db.Where("("+strings.Join(clauses, " OR ")+")", args...)
+		}
+	}
+	return db
+}
+
+// Order applies the sort fields
+func (p Params) Order(db *gorm.DB) *gorm.DB {
+	for _, field := range p.Sort {
+		direction := "ASC"
+		if field.Desc {
+			direction = "DESC"
+		}
+		db = db.Order(field.Column + " " + direction)
+	}
+	return db
+}
+
+// parseFilter converts a raw filter value into a condition
+func parseFilter(name, raw string, filter Filter) (condition, error) {
+	switch filter.Type {
+	case FilterBool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return condition{}, apperror.Validationf("%s must be true or false", name)
+		}
+		return condition{sql: filter.Column + " = ?", value: b}, nil
+
+	case FilterFrom:
+		t, _, err := parseTime(raw)
+		if err != nil {
+			return condition{}, apperror.Validationf("%s must be a date (YYYY-MM-DD) or RFC 3339 timestamp", name)
+		}
+		return condition{sql: filter.Column + " >= ?", value: t}, nil
+
+	case FilterTo:
+		t, dateOnly, err := parseTime(raw)
+		if err != nil {
+			return condition{}, apperror.Validationf("%s must be a date (YYYY-MM-DD) or RFC 3339 timestamp", name)
+		}
+		if dateOnly {
+			// A date includes the whole day
+			return condition{sql: filter.Column + " < ?", value: t.AddDate(0, 0, 1)}, nil
+		}
+		return condition{sql: filter.Column + " <= ?", value: t}, nil
+
+	case FilterUUID:
+		var ids []uuid.UUID
+		for _, v := range splitList(raw) {
+			id, err := uuid.Parse(v)
+			if err != nil {
+				return condition{}, apperror.Validationf("%s must be a comma-separated list of UUIDs", name)
+			}
+			ids = append(ids, id)
+		}
+		return condition{sql: filter.Column + " IN ?", value: ids}, nil
+
+	default:
+		return condition{sql: filter.Column + " IN ?", value: splitList(raw)}, nil
+	}
+}
+
+// splitList splits a comma-separated filter value, dropping blank entries
+func splitList(raw string) []string {
+	var list []string
+	for _, v := range strings.Split(raw, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// parseTime accepts a date or an RFC 3339 timestamp and reports whether it was a date
+func parseTime(raw string) (time.Time, bool, error) {
+	if t, err := time.Parse(dateLayout, raw); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	return t, false, err
+}
+
+// escapeLike escapes the LIKE wildcards so search terms match literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,128 @@
+package query
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"goUniAdmin/internal/services/apperror"
+
+	"github.com/google/uuid"
+)
+
+var testSpec = Spec{
+	SearchColumns: []string{"first_name", "email_id"},
+	Filters: map[string]Filter{
+		"status":        {Column: "status", Type: FilterIn},
+		"isActive":      {Column: "is_active", Type: FilterBool},
+		"createdAfter":  {Column: "created_at", Type: FilterFrom},
+		"createdBefore": {Column: "created_at", Type: FilterTo},
+		"ownerId":       {Column: "owner_id", Type: FilterUUID},
+	},
+	Sorts:       map[string]string{"createdAt": "created_at", "name": "first_name"},
+	DefaultSort: "-createdAt",
+	TieBreaker:  "id",
+}
+
+func TestParse(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	ownerA, ownerB := uuid.New(), uuid.New()
+	tests := []struct {
+		name       string
+		query      string
+		search     string
+		sort       []SortField
+		conditions []condition
+		wantErr    bool
+	}{
+		{
+			name: "defaults",
+			sort: []SortField{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}},
+		},
+		{
+			name:   "search is trimmed",
+			query:  "search=+ann+",
+			search: "ann",
+			sort:   []SortField{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}},
+		},
+		{
+			name:  "sort keys with duplicates",
+			query: "sort=name,-createdAt,name",
+			sort:  []SortField{{Column: "first_name"}, {Column: "created_at", Desc: true}, {Column: "id", Desc: true}},
+		},
+		{
+			name:  "ascending sort keeps the tie-breaker ascending",
+			query: "sort=createdAt",
+			sort:  []SortField{{Column: "created_at"}, {Column: "id"}},
+		},
+		{
+			name:  "filters in name order",
+			query: "status=a,+b,,&isActive=false&createdAfter=2024-03-01&createdBefore=2024-03-01",
+			sort:  []SortField{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}},
+			conditions: []condition{
+				{sql: "created_at >= ?", value: day},
+				{sql: "created_at < ?", value: day.AddDate(0, 0, 1)},
+				{sql: "is_active = ?", value: false},
+				{sql: "status IN ?", value: []string{"a", "b"}},
+			},
+		},
+		{
+			name:  "timestamp upper bound is inclusive",
+			query: "createdBefore=2024-03-01T10:00:00Z",
+			sort:  []SortField{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}},
+			conditions: []condition{
+				{sql: "created_at <= ?", value: day.Add(10 * time.Hour)},
+			},
+		},
+		{
+			name:  "uuid filter",
+			query: "ownerId=" + ownerA.String() + ",+" + strings.ToUpper(ownerB.String()),
+			sort:  []SortField{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}},
+			conditions: []condition{
+				{sql: "owner_id IN ?", value: []uuid.UUID{ownerA, ownerB}},
+			},
+		},
+		{name: "unknown sort key", query: "sort=password", wantErr: true},
+		{name: "malformed bool", query: "isActive=maybe", wantErr: true},
+		{name: "malformed date", query: "createdAfter=yesterday", wantErr: true},
+		{name: "malformed uuid", query: "ownerId=" + ownerA.String() + ",abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			params, err := Parse(values, testSpec)
+			if tt.wantErr {
+				var appErr *apperror.Error
+				if !errors.As(err, &appErr) || appErr.Status != http.StatusBadRequest {
+					t.Fatalf("Parse() error = %v, want a 400 error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if params.Search != tt.search {
+				t.Errorf("Search = %q, want %q", params.Search, tt.search)
+			}
+			if !reflect.DeepEqual(params.Sort, tt.sort) {
+				t.Errorf("Sort = %v, want %v", params.Sort, tt.sort)
+			}
+			if !reflect.DeepEqual(params.conditions, tt.conditions) {
+				t.Errorf("conditions = %v, want %v", params.conditions, tt.conditions)
+			}
+		})
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	if got, want := escapeLike(`50%_off\`), `50\%\_off\\`; got != want {
+		t.Errorf("escapeLike() = %q, want %q", got, want)
+	}
+}
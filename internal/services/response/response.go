@@ -0,0 +1,83 @@
+// Package response writes the envelope shared by every API response:
+//
+//	{"success": true, "message": "...", "data": ...}
+//	{"success": false, "error": {"code": "ADMIN_NOT_FOUND", "message": "...", "requestId": "..."}}
+package response
+
+import (
+	"errors"
+	"log/slog"
+
+	"goUniAdmin/internal/services/apperror"
+	localization "goUniAdmin/internal/services/common"
+	"goUniAdmin/internal/services/logger"
+	"goUniAdmin/internal/services/validators"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Envelope is the body of a successful response
+type Envelope struct {
+	Success bool        `json:"success" example:"true"`
+	Message string      `json:"message,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// ErrorEnvelope is the body of a failed response
+type ErrorEnvelope struct {
+	Success bool      `json:"success" example:"false"`
+	Error   ErrorBody `json:"error"`
+}
+
+// ErrorBody describes what went wrong. Clients branch on Code; Message is for people.
+type ErrorBody struct {
+	Code      apperror.Code `json:"code" swaggertype:"string" example:"VALIDATION_FAILED"`
+	Message   string        `json:"message" example:"firstName must not be empty"`
+	Details   interface{}   `json:"details,omitempty"`   // For VALIDATION_FAILED, a list of validators.FieldError
+	RequestID string        `json:"requestId,omitempty"` // Matches X-Request-ID and the request's log lines
+}
+
+// Page is the data of a paginated list. Offset pages set Page; cursor pages set the cursors.
+type Page struct {
+	List       interface{} `json:"list"`
+	Page       int         `json:"page,omitempty"`
+	PageSize   int         `json:"page_size"`
+	TotalCount *int64      `json:"total_count,omitempty"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	PrevCursor string      `json:"prev_cursor,omitempty"`
+}
+
+// NewPage builds an offset page with its total count
+func NewPage(list interface{}, page, pageSize int, totalCount int64) Page {
+	return Page{List: list, Page: page, PageSize: pageSize, TotalCount: &totalCount}
+}
+
+// Success writes a successful response with the message for messageID in the request's language
+func Success(c *gin.Context, status int, messageID string, data interface{}) {
+	c.JSON(status, Envelope{Success: true, Message: localization.T(c, messageID, nil), Data: data})
+}
+
+// Error writes a failed response for err and aborts the request. Server errors are logged
+// with their cause, which the client never sees. Field validation errors are listed in the
+// details. Messages are in the request's language: other errors are translated when the
+// catalogs have the message's ID (see localization.MessageID) and are left in English if not,
+// e.g. when they carry runtime values.
+func Error(c *gin.Context, err error) {
+	appErr := apperror.From(err)
+	if cause := errors.Unwrap(appErr); appErr.Status >= 500 && cause != nil {
+		slog.ErrorContext(c.Request.Context(), "Request failed", "code", appErr.Code, "error", cause)
+	}
+	body := ErrorBody{
+		Code:      appErr.Code,
+		Message:   localization.TDefault(c, localization.MessageID(appErr.Message), appErr.Message),
+		Details:   appErr.Details,
+		RequestID: logger.RequestID(c.Request.Context()),
+	}
+	var fieldErrs validators.Errors
+	if errors.As(appErr, &fieldErrs) {
+		lang := localization.Language(c)
+		body.Message = fieldErrs.Message(lang)
+		body.Details = fieldErrs.Localize(lang)
+	}
+	c.AbortWithStatusJSON(appErr.Status, ErrorEnvelope{Error: body})
+}
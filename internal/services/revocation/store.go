@@ -0,0 +1,185 @@
+package revocation
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"goUniAdmin/internal/db"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// RevokedToken records a single access token (by its jti claim) that must no longer be accepted
+type RevokedToken struct {
+	JTI       string    `gorm:"primaryKey" json:"jti"`
+	AdminID   uuid.UUID `gorm:"type:uuid;index" json:"adminId"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expiresAt"` // Entry can be discarded once the token has expired
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt,omitempty"`
+}
+
+// AdminRevocation invalidates every token issued to an admin at or before RevokedAt
+type AdminRevocation struct {
+	AdminID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"adminId"`
+	RevokedAt time.Time `gorm:"not null" json:"revokedAt"`
+}
+
+const (
+	// syncInterval controls how often the in-memory cache is reloaded from Postgres,
+	// so revocations made by other instances are picked up
+	syncInterval = 30 * time.Second
+	// syncRetryInterval is how long a failed reload waits before it is tried again
+	syncRetryInterval = 5 * time.Second
+	// cleanupInterval controls how often revocations of expired tokens are deleted
+	cleanupInterval = time.Hour
+)
+
+// Store is a Postgres backed revocation list with an in-memory cache
+type Store struct {
+	db       *db.DB
+	mu       sync.RWMutex
+	tokens   map[string]time.Time    // jti -> token expiry
+	admins   map[uuid.UUID]time.Time // admin ID -> revocation cutoff
+	nextSync time.Time               // When the cache is next reloaded
+	loadMu   sync.Mutex              // Held by the one request reloading the cache
+}
+
+// StoreInstance is a global instance of the revocation store
+var StoreInstance *Store
+
+// NewStore creates the revocation store, loads the current revocations into memory and starts
+// deleting the revocations of expired tokens in the background
+func NewStore(db *db.DB) (*Store, error) {
+	store := &Store{db: db}
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+	go store.cleanupLoop()
+
+	StoreInstance = store // Assign the instance to the global variable
+	return StoreInstance, nil
+}
+
+// RevokeToken revokes a single access token until it expires
+func (s *Store) RevokeToken(jti string, adminID uuid.UUID, expiresAt time.Time) error {
+	entry := RevokedToken{JTI: jti, AdminID: adminID, ExpiresAt: expiresAt}
+	if err := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entry).Error; err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.tokens[jti] = expiresAt
+	s.mu.Unlock()
+	return nil
+}
+
+// RevokeAdmin revokes every token issued to the admin up to now
+func (s *Store) RevokeAdmin(adminID uuid.UUID) error {
+	// Access tokens carry iat in microseconds, the precision Postgres stores, so a token issued
+	// right after the revocation, e.g. by logging in again, is still accepted
+	entry := AdminRevocation{AdminID: adminID, RevokedAt: time.Now().Truncate(time.Microsecond)}
+	err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "admin_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"revoked_at"}),
+	}).Create(&entry).Error
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.admins[adminID] = entry.RevokedAt
+	s.mu.Unlock()
+	return nil
+}
+
+// IsRevoked reports whether a token has been revoked, either individually or through its admin
+func (s *Store) IsRevoked(jti string, adminID uuid.UUID, issuedAt time.Time) bool {
+	s.refresh()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if jti != "" {
+		if _, ok := s.tokens[jti]; ok {
+			return true
+		}
+	}
+	if cutoff, ok := s.admins[adminID]; ok && !issuedAt.After(cutoff) {
+		return true
+	}
+	return false
+}
+
+// refresh reloads the cache once it is due. Only one request reloads at a time; the others keep
+// using the current cache, and a failed reload is retried after syncRetryInterval.
+func (s *Store) refresh() {
+	s.mu.RLock()
+	due := time.Now().After(s.nextSync)
+	s.mu.RUnlock()
+	if !due || !s.loadMu.TryLock() {
+		return
+	}
+	defer s.loadMu.Unlock()
+
+	// Another request may have reloaded between the check above and taking the lock
+	s.mu.RLock()
+	due = time.Now().After(s.nextSync)
+	s.mu.RUnlock()
+	if !due {
+		return
+	}
+
+	if err := s.load(); err != nil {
+		slog.Error("Failed to refresh token revocation cache", "error", err)
+		s.mu.Lock()
+		s.nextSync = time.Now().Add(syncRetryInterval)
+		s.mu.Unlock()
+	}
+}
+
+// cleanupLoop deletes the revocations of expired tokens every cleanupInterval. Expired tokens
+// are rejected by signature validation anyway, so their entries are no longer needed.
+func (s *Store) cleanupLoop() {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := s.deleteExpired(); err != nil {
+			slog.Error("Failed to delete expired token revocations", "error", err)
+		}
+	}
+}
+
+// deleteExpired deletes the revocations of tokens that have expired
+func (s *Store) deleteExpired() error {
+	return s.db.Where("expires_at < ?", time.Now()).Delete(&RevokedToken{}).Error
+}
+
+// load replaces the cache with the non-expired revocations stored in the database
+func (s *Store) load() error {
+	now := time.Now()
+
+	var revokedTokens []RevokedToken
+	if err := s.db.Where("expires_at >= ?", now).Find(&revokedTokens).Error; err != nil {
+		return err
+	}
+	var adminRevocations []AdminRevocation
+	if err := s.db.Find(&adminRevocations).Error; err != nil {
+		return err
+	}
+
+	tokens := make(map[string]time.Time, len(revokedTokens))
+	for _, t := range revokedTokens {
+		tokens[t.JTI] = t.ExpiresAt
+	}
+	admins := make(map[uuid.UUID]time.Time, len(adminRevocations))
+	for _, a := range adminRevocations {
+		admins[a.AdminID] = a.RevokedAt
+	}
+
+	s.mu.Lock()
+	s.tokens = tokens
+	s.admins = admins
+	s.nextSync = now.Add(syncInterval)
+	s.mu.Unlock()
+	return nil
+}
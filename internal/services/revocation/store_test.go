@@ -0,0 +1,156 @@
+package revocation
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"goUniAdmin/internal/db"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB returns a fresh SQLite database with the revocation tables
+func newTestDB(t *testing.T) *db.DB {
+	t.Helper()
+	conn, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "revocation.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := conn.AutoMigrate(&RevokedToken{}, &AdminRevocation{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return &db.DB{DB: conn}
+}
+
+// newTestStore returns a loaded store on the database without the global instance or cleanup loop
+func newTestStore(t *testing.T, database *db.DB) *Store {
+	t.Helper()
+	store := &Store{db: database}
+	if err := store.load(); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+	return store
+}
+
+func TestRevokeToken(t *testing.T) {
+	store := newTestStore(t, newTestDB(t))
+	adminID := uuid.New()
+
+	if err := store.RevokeToken("t1", adminID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("RevokeToken() error = %v", err)
+	}
+	if err := store.RevokeToken("t1", adminID, time.Now().Add(time.Hour)); err != nil {
+		t.Errorf("RevokeToken() of a revoked token error = %v", err)
+	}
+	if !store.IsRevoked("t1", adminID, time.Now()) {
+		t.Error("IsRevoked() of the revoked token = false")
+	}
+	if store.IsRevoked("t2", adminID, time.Now()) {
+		t.Error("IsRevoked() of another token = true")
+	}
+}
+
+func TestRevokeAdmin(t *testing.T) {
+	store := newTestStore(t, newTestDB(t))
+	adminID := uuid.New()
+	before := time.Now().Add(-time.Second)
+
+	if err := store.RevokeAdmin(adminID); err != nil {
+		t.Fatalf("RevokeAdmin() error = %v", err)
+	}
+	// A token issued in the same second, right after the revocation, e.g. by logging in again
+	after := time.Now().Truncate(time.Microsecond).Add(time.Microsecond)
+
+	tests := []struct {
+		name     string
+		adminID  uuid.UUID
+		issuedAt time.Time
+		want     bool
+	}{
+		{"issued before", adminID, before, true},
+		{"issued right after", adminID, after, false},
+		{"other admin", uuid.New(), before, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := store.IsRevoked("", tt.adminID, tt.issuedAt); got != tt.want {
+				t.Errorf("IsRevoked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpiredRevocations(t *testing.T) {
+	database := newTestDB(t)
+	store := newTestStore(t, database)
+	adminID := uuid.New()
+	if err := store.RevokeToken("expired", adminID, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RevokeToken("active", adminID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reloading skips expired entries and deleteExpired removes them from the database
+	if reloaded := newTestStore(t, database); reloaded.IsRevoked("expired", adminID, time.Now()) || !reloaded.IsRevoked("active", adminID, time.Now()) {
+		t.Error("load() kept the expired revocation or lost the active one")
+	}
+	if err := store.deleteExpired(); err != nil {
+		t.Fatalf("deleteExpired() error = %v", err)
+	}
+	var jtis []string
+	database.Model(&RevokedToken{}).Pluck("jti", &jtis)
+	if len(jtis) != 1 || jtis[0] != "active" {
+		t.Errorf("stored revocations = %v, want [active]", jtis)
+	}
+}
+
+func TestRefreshPicksUpOtherInstances(t *testing.T) {
+	database := newTestDB(t)
+	store := newTestStore(t, database)
+	other := newTestStore(t, database)
+	adminID := uuid.New()
+
+	if err := other.RevokeToken("t1", adminID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if store.IsRevoked("t1", adminID, time.Now()) {
+		t.Fatal("IsRevoked() reloaded the cache before it was due")
+	}
+
+	store.mu.Lock()
+	store.nextSync = time.Now().Add(-time.Second)
+	store.mu.Unlock()
+	if !store.IsRevoked("t1", adminID, time.Now()) {
+		t.Error("IsRevoked() after the sync interval did not see the other instance's revocation")
+	}
+}
+
+func TestRefreshFailureRetriesLater(t *testing.T) {
+	database := newTestDB(t)
+	store := newTestStore(t, database)
+	if err := store.RevokeToken("t1", uuid.New(), time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := database.Migrator().DropTable(&RevokedToken{}); err != nil {
+		t.Fatal(err)
+	}
+
+	store.mu.Lock()
+	store.nextSync = time.Now().Add(-time.Second)
+	store.mu.Unlock()
+	if !store.IsRevoked("t1", uuid.Nil, time.Now()) {
+		t.Error("IsRevoked() dropped the cache after a failed reload")
+	}
+
+	store.mu.RLock()
+	nextSync := store.nextSync
+	store.mu.RUnlock()
+	if wait := time.Until(nextSync); wait <= 0 || wait > syncRetryInterval {
+		t.Errorf("next reload in %v, want within %v", wait, syncRetryInterval)
+	}
+}
@@ -0,0 +1,381 @@
+// Package seed bootstraps a database with a super admin, the default roles and optional
+// fixture data. Every step is idempotent, so seeding can be re-run against the same database.
+package seed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"goUniAdmin/internal/config"
+	"goUniAdmin/internal/db"
+	"goUniAdmin/internal/modules/admin"
+	"goUniAdmin/internal/modules/mastermanagement"
+	"goUniAdmin/internal/modules/roles"
+	"goUniAdmin/internal/modules/settings"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+// RoleFixture describes a role to create
+type RoleFixture struct {
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description" yaml:"description"`
+	Permissions []string `json:"permissions" yaml:"permissions"`
+}
+
+// AdminFixture describes an admin to create. Roles are referenced by name.
+type AdminFixture struct {
+	FirstName string   `json:"firstName" yaml:"firstName"`
+	LastName  string   `json:"lastName" yaml:"lastName"`
+	EmailID   string   `json:"emailId" yaml:"emailId"`
+	Password  string   `json:"password" yaml:"password"`
+	Roles     []string `json:"roles" yaml:"roles"`
+}
+
+// SettingFixture describes a setting to create. Value is any value of the declared type.
+type SettingFixture struct {
+	Key         string      `json:"key" yaml:"key"`
+	Category    string      `json:"category" yaml:"category"`
+	Type        string      `json:"type" yaml:"type"`
+	Value       interface{} `json:"value" yaml:"value"`
+	Description string      `json:"description" yaml:"description"`
+}
+
+// MasterTypeFixture describes a master type and the values to add to it
+type MasterTypeFixture struct {
+	Code        string               `json:"code" yaml:"code"`
+	Name        string               `json:"name" yaml:"name"`
+	Description string               `json:"description" yaml:"description"`
+	Values      []MasterValueFixture `json:"values" yaml:"values"`
+}
+
+// MasterValueFixture describes a master value. The parent is referenced by the codes of its
+// type and itself, and must be listed earlier.
+type MasterValueFixture struct {
+	Code       string            `json:"code" yaml:"code"`
+	Label      string            `json:"label" yaml:"label"`
+	Labels     map[string]string `json:"labels" yaml:"labels"`
+	SortOrder  int               `json:"sortOrder" yaml:"sortOrder"`
+	ParentType string            `json:"parentType" yaml:"parentType"`
+	ParentCode string            `json:"parentCode" yaml:"parentCode"`
+}
+
+// Fixtures is the content of a fixture file
+type Fixtures struct {
+	Roles    []RoleFixture       `json:"roles" yaml:"roles"`
+	Admins   []AdminFixture      `json:"admins" yaml:"admins"`
+	Settings []SettingFixture    `json:"settings" yaml:"settings"`
+	Masters  []MasterTypeFixture `json:"masters" yaml:"masters"`
+}
+
+// DefaultRoles are created alongside the super_admin system role
+var DefaultRoles = []RoleFixture{
+	{
+		Name:        "admin",
+		Description: "Manages admins and content",
+		Permissions: []string{"admins:*", "roles:read", "email-templates:*", "audit-logs:read", "settings:read", "static-pages:*", "masters:*", "files:*", "translations:*"},
+	},
+	{
+		Name:        "viewer",
+		Description: "Read-only access",
+		Permissions: []string{"admins:read", "roles:read", "email-templates:read", "settings:read", "static-pages:read", "masters:read", "files:read", "translations:read"},
+	},
+}
+
+// DefaultSettings are created by SeedSettings
+var DefaultSettings = []SettingFixture{
+	{Key: "site.name", Category: "general", Type: "string", Value: "Go Uni Admin", Description: "Name shown in the admin panel"},
+	{Key: "site.support_email", Category: "general", Type: "string", Value: "", Description: "Contact address shown to admins"},
+}
+
+// DefaultMasterTypes are created by SeedMasters. They start empty; admin country, currency
+// and time zone values are validated once their lists are filled in.
+var DefaultMasterTypes = []MasterTypeFixture{
+	{Code: mastermanagement.TypeCountry, Name: "Countries", Description: "ISO 3166-1 alpha-2 country codes"},
+	{Code: mastermanagement.TypeCurrency, Name: "Currencies", Description: "ISO 4217 currency codes"},
+	{Code: mastermanagement.TypeTimeZone, Name: "Time zones", Description: "IANA time zone names"},
+}
+
+// Seeder writes seed data to the database
+type Seeder struct {
+	db       *db.DB
+	cfg      *config.Config
+	roles    *roles.RoleService
+	settings *settings.SettingService
+	masters  *mastermanagement.MasterService
+}
+
+// NewSeeder creates a seeder for the given database
+func NewSeeder(db *db.DB, cfg *config.Config) *Seeder {
+	return &Seeder{
+		db:       db,
+		cfg:      cfg,
+		roles:    roles.NewRoleService(db, cfg),
+		settings: settings.NewSettingService(db, cfg),
+		masters:  mastermanagement.NewMasterService(db, cfg),
+	}
+}
+
+// SeedRoles creates the system roles and DefaultRoles. Existing roles are left untouched,
+// so permissions changed through the API survive a re-run.
+func (s *Seeder) SeedRoles() error {
+	if err := s.roles.EnsureSystemRoles(); err != nil {
+		return err
+	}
+	for _, role := range DefaultRoles {
+		if err := s.seedRole(role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SeedSettings creates DefaultSettings. Existing settings keep their current values.
+func (s *Seeder) SeedSettings() error {
+	for _, setting := range DefaultSettings {
+		if err := s.seedSetting(setting); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SeedMasters creates DefaultMasterTypes. Existing types and values are left untouched.
+func (s *Seeder) SeedMasters() error {
+	for _, masterType := range DefaultMasterTypes {
+		if err := s.seedMasterType(masterType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SeedSuperAdmin creates a verified, active admin holding the super_admin role. If the
+// email already exists the role is granted and the password is left unchanged.
+func (s *Seeder) SeedSuperAdmin(fixture AdminFixture) error {
+	fixture.Roles = []string{roles.SuperAdminRole}
+	return s.seedAdmin(fixture)
+}
+
+// SeedFixtures creates the roles, admins, settings and master data described by a fixture file
+func (s *Seeder) SeedFixtures(fixtures Fixtures) error {
+	for _, role := range fixtures.Roles {
+		if err := s.seedRole(role); err != nil {
+			return err
+		}
+	}
+	for _, a := range fixtures.Admins {
+		if err := s.seedAdmin(a); err != nil {
+			return err
+		}
+	}
+	for _, setting := range fixtures.Settings {
+		if err := s.seedSetting(setting); err != nil {
+			return err
+		}
+	}
+	for _, masterType := range fixtures.Masters {
+		if err := s.seedMasterType(masterType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadFixtures reads a fixture file. The format is chosen by extension: .json, .yaml or .yml.
+func LoadFixtures(path string) (Fixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixtures{}, err
+	}
+
+	var fixtures Fixtures
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &fixtures)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &fixtures)
+	default:
+		return Fixtures{}, fmt.Errorf("unsupported fixture format %q, use .json, .yaml or .yml", filepath.Ext(path))
+	}
+	if err != nil {
+		return Fixtures{}, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return fixtures, nil
+}
+
+// seedRole creates a role unless one with the same name exists
+func (s *Seeder) seedRole(fixture RoleFixture) error {
+	if _, err := s.roles.ReadByName(fixture.Name); err == nil {
+		slog.Info("Role already exists, skipping", "role", fixture.Name)
+		return nil
+	}
+
+	_, err := s.roles.Create(roles.Role{
+		Name:        fixture.Name,
+		Description: fixture.Description,
+		Permissions: fixture.Permissions,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create role %q: %v", fixture.Name, err)
+	}
+	slog.Info("Created role", "role", fixture.Name)
+	return nil
+}
+
+// seedSetting creates a setting unless one with the same key exists
+func (s *Seeder) seedSetting(fixture SettingFixture) error {
+	if _, err := s.settings.Read(fixture.Key); err == nil {
+		slog.Info("Setting already exists, skipping", "setting", fixture.Key)
+		return nil
+	} else if !errors.Is(err, settings.ErrSettingNotFound) {
+		return err
+	}
+
+	value, err := json.Marshal(fixture.Value)
+	if err != nil {
+		return fmt.Errorf("setting %q: %v", fixture.Key, err)
+	}
+	category := fixture.Category
+	if category == "" {
+		category = "general"
+	}
+	_, err = s.settings.Create(settings.Setting{
+		Key:         fixture.Key,
+		Category:    category,
+		Type:        settings.SettingType(fixture.Type),
+		Value:       value,
+		Description: fixture.Description,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create setting %q: %v", fixture.Key, err)
+	}
+	slog.Info("Created setting", "setting", fixture.Key)
+	return nil
+}
+
+// seedMasterType creates a master type unless one with the same code exists, then adds the
+// fixture's values that are missing from it
+func (s *Seeder) seedMasterType(fixture MasterTypeFixture) error {
+	masterType, err := s.masters.ReadTypeByCode(fixture.Code)
+	switch {
+	case err == nil:
+		slog.Info("Master type already exists, skipping creation", "master_type", fixture.Code)
+	case errors.Is(err, mastermanagement.ErrTypeNotFound):
+		masterType, err = s.masters.CreateType(mastermanagement.MasterType{
+			Code:        fixture.Code,
+			Name:        fixture.Name,
+			Description: fixture.Description,
+			IsActive:    true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create master type %q: %v", fixture.Code, err)
+		}
+		slog.Info("Created master type", "master_type", fixture.Code)
+	default:
+		return err
+	}
+
+	created := 0
+	for _, value := range fixture.Values {
+		if _, err := s.masters.ReadValueByCode(fixture.Code, value.Code); err == nil {
+			continue
+		} else if !errors.Is(err, mastermanagement.ErrValueNotFound) {
+			return err
+		}
+
+		record := mastermanagement.MasterValue{
+			TypeID:    masterType.ID,
+			Code:      value.Code,
+			Label:     value.Label,
+			Labels:    value.Labels,
+			SortOrder: value.SortOrder,
+			IsActive:  true,
+		}
+		if value.ParentCode != "" {
+			parent, err := s.masters.ReadValueByCode(value.ParentType, value.ParentCode)
+			if err != nil {
+				return fmt.Errorf("master value %s/%s: parent %s/%s: %v", fixture.Code, value.Code, value.ParentType, value.ParentCode, err)
+			}
+			record.ParentID = &parent.ID
+		}
+		if _, err := s.masters.CreateValue(record); err != nil {
+			return fmt.Errorf("failed to create master value %s/%s: %v", fixture.Code, value.Code, err)
+		}
+		created++
+	}
+	if created > 0 {
+		slog.Info("Created master values", "master_type", fixture.Code, "count", created)
+	}
+	return nil
+}
+
+// seedAdmin creates an admin unless the email exists, then grants the fixture's roles
+func (s *Seeder) seedAdmin(fixture AdminFixture) error {
+	if fixture.EmailID == "" {
+		return errors.New("admin email is required")
+	}
+
+	var existing admin.Admin
+	err := s.db.Where("email_id = ? AND is_deleted = ?", fixture.EmailID, false).First(&existing).Error
+	switch {
+	case err == nil:
+		slog.Info("Admin already exists, skipping creation", "email", fixture.EmailID)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		existing, err = s.createAdmin(fixture)
+		if err != nil {
+			return err
+		}
+		slog.Info("Created admin", "email", fixture.EmailID)
+	default:
+		return err
+	}
+
+	for _, name := range fixture.Roles {
+		role, err := s.roles.ReadByName(name)
+		if err != nil {
+			return fmt.Errorf("admin %s: role %q not found", fixture.EmailID, name)
+		}
+		if err := s.roles.GrantRole(existing.ID, role.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createAdmin inserts a verified, active admin. AdminService.Create is not used because
+// it would send a verification email.
+func (s *Seeder) createAdmin(fixture AdminFixture) (admin.Admin, error) {
+	if fixture.FirstName == "" || fixture.LastName == "" {
+		return admin.Admin{}, fmt.Errorf("admin %s: first and last name are required", fixture.EmailID)
+	}
+	if err := admin.ValidatePassword(fixture.Password); err != nil {
+		return admin.Admin{}, fmt.Errorf("admin %s: %v", fixture.EmailID, err)
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(fixture.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return admin.Admin{}, err
+	}
+
+	record := admin.Admin{
+		ID:                      uuid.New(),
+		FirstName:               fixture.FirstName,
+		LastName:                fixture.LastName,
+		EmailID:                 fixture.EmailID,
+		Password:                string(hashedPassword),
+		EmailVerificationStatus: true,
+		Status:                  true,
+	}
+	if err := s.db.Create(&record).Error; err != nil {
+		return admin.Admin{}, err
+	}
+	return record, nil
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"goUniAdmin/internal/config"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Storage keeps files in a bucket of S3 or an S3-compatible service such as MinIO. Files
+// are read through presigned bucket URLs.
+type S3Storage struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3Storage connects to the bucket configured by the STORAGE_S3_* settings
+func NewS3Storage(cfg *config.Config) (*S3Storage, error) {
+	if cfg.StorageS3Bucket == "" {
+		return nil, fmt.Errorf("STORAGE_S3_BUCKET is required for the s3 storage driver")
+	}
+	lookup := minio.BucketLookupAuto
+	if cfg.StorageS3PathStyle {
+		lookup = minio.BucketLookupPath
+	}
+	client, err := minio.New(cfg.StorageS3Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(cfg.StorageS3AccessKey, cfg.StorageS3SecretKey, ""),
+		Secure:       cfg.StorageS3UseSSL,
+		Region:       cfg.StorageS3Region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %v", err)
+	}
+	return &S3Storage{client: client, bucket: cfg.StorageS3Bucket}, nil
+}
+
+// Put uploads body to key
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if !validKey(key) {
+		return fmt.Errorf("invalid storage key %q", key)
+	}
+	_, err := s.client.PutObject(ctx, s.bucket, key, body, size, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+// Open returns the content of key
+func (s *S3Storage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// GetObject is lazy; Stat surfaces a missing key before the caller starts reading
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return object, nil
+}
+
+// Delete removes key. Deleting a missing key is not an error.
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+// SignedURL returns a presigned GET URL to key that expires after expiry
+func (s *S3Storage) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	u, err := s.client.PresignedGetObject(ctx, s.bucket, key, expiry, nil)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"goUniAdmin/internal/config"
+)
+
+// ServePath is the route under which files of the local and memory storages are served
+const ServePath = "/api/files/raw/"
+
+// ErrInvalidSignature is returned for a file URL whose signature is wrong or expired
+var ErrInvalidSignature = errors.New("invalid or expired file URL")
+
+// Signer creates and checks the HMAC-signed URLs used by storages that serve files
+// through the API rather than from a bucket
+type Signer struct {
+	baseURL string
+	secret  []byte
+}
+
+// NewSigner creates a signer for URLs under STORAGE_PUBLIC_URL, keyed with
+// STORAGE_SIGNING_SECRET or, when unset, the JWT secret
+func NewSigner(cfg *config.Config) *Signer {
+	secret := cfg.StorageSigningSecret
+	if secret == "" {
+		secret = cfg.JWTSecret
+	}
+	return &Signer{
+		baseURL: strings.TrimRight(cfg.StoragePublicURL, "/"),
+		secret:  []byte(secret),
+	}
+}
+
+// URL returns a URL to key that is valid until now plus expiry
+func (s *Signer) URL(key string, expiry time.Duration) string {
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+	query := url.Values{"expires": {expires}, "signature": {s.signature(key, expires)}}
+	return s.baseURL + ServePath + (&url.URL{Path: key}).EscapedPath() + "?" + query.Encode()
+}
+
+// Verify checks the expiry and signature of a URL created by URL
+func (s *Signer) Verify(key, expires, signature string) error {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > unix {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.signature(key, expires))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// signature is the HMAC-SHA256 of the key and expiry
+func (s *Signer) signature(key, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
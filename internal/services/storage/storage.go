@@ -0,0 +1,52 @@
+// Package storage stores uploaded files behind a Storage interface with local-disk,
+// S3-compatible and in-memory implementations.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"goUniAdmin/internal/config"
+)
+
+// ErrNotFound is returned when no object is stored under a key
+var ErrNotFound = errors.New("file not found")
+
+// Storage puts, reads and deletes objects by key and hands out time-limited URLs to them.
+// Keys are slash-separated relative paths such as "avatars/2025/01/<id>.jpg".
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
+
+// New returns the storage selected by the STORAGE_DRIVER setting: "local" (default) to keep
+// files under STORAGE_LOCAL_ROOT, "s3" for an S3-compatible bucket, or "memory" to keep
+// them in memory
+func New(cfg *config.Config) (Storage, error) {
+	switch strings.ToLower(cfg.StorageDriver) {
+	case "s3":
+		return NewS3Storage(cfg)
+	case "memory":
+		return NewMemoryStorage(NewSigner(cfg)), nil
+	default:
+		return NewLocalStorage(cfg.StorageLocalRoot, NewSigner(cfg))
+	}
+}
+
+// validKey rejects keys that are empty, absolute or escape the storage root
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
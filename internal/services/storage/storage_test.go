@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"goUniAdmin/internal/config"
+)
+
+func TestValidKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"avatars/2024/photo.png", true},
+		{"file.txt", true},
+		{"", false},
+		{"/etc/passwd", false},
+		{"avatars/../secret", false},
+		{"../secret", false},
+		{"avatars/./photo.png", false},
+		{"avatars//photo.png", false},
+		{"avatars/", false},
+		{"avatars\\photo.png", false},
+	}
+	for _, tt := range tests {
+		if got := validKey(tt.key); got != tt.want {
+			t.Errorf("validKey(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestSignerVerify(t *testing.T) {
+	signer := NewSigner(&config.Config{StorageSigningSecret: "secret", StoragePublicURL: "http://localhost:8080/"})
+	key := "attachments/report.pdf"
+
+	signed, err := url.Parse(signer.URL(key, time.Minute))
+	if err != nil {
+		t.Fatalf("parse signed URL: %v", err)
+	}
+	if want := ServePath + key; signed.Path != want {
+		t.Fatalf("signed URL path = %q, want %q", signed.Path, want)
+	}
+	expires := signed.Query().Get("expires")
+	signature := signed.Query().Get("signature")
+
+	past := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+	other := NewSigner(&config.Config{StorageSigningSecret: "other"})
+
+	tests := []struct {
+		name      string
+		signer    *Signer
+		key       string
+		expires   string
+		signature string
+		wantErr   bool
+	}{
+		{"valid", signer, key, expires, signature, false},
+		{"other key", signer, "attachments/other.pdf", expires, signature, true},
+		{"changed expiry", signer, key, expires + "0", signature, true},
+		{"expired", signer, key, past, signer.signature(key, past), true},
+		{"non-numeric expiry", signer, key, "tomorrow", signature, true},
+		{"tampered signature", signer, key, expires, strings.ToUpper(signature), true},
+		{"empty signature", signer, key, expires, "", true},
+		{"other secret", other, key, expires, signature, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.signer.Verify(tt.key, tt.expires, tt.signature)
+			if tt.wantErr && !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Verify() = %v, want ErrInvalidSignature", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Verify() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestSignerFallsBackToJWTSecret(t *testing.T) {
+	jwtSigner := NewSigner(&config.Config{JWTSecret: "jwt"})
+	explicit := NewSigner(&config.Config{StorageSigningSecret: "jwt"})
+	if jwtSigner.signature("k", "1") != explicit.signature("k", "1") {
+		t.Error("signer without STORAGE_SIGNING_SECRET does not use the JWT secret")
+	}
+}
+
+func TestMemoryStorage(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStorage(NewSigner(&config.Config{StorageSigningSecret: "secret"}))
+
+	if err := store.Put(ctx, "../escape", strings.NewReader("x"), 1, "text/plain"); err == nil {
+		t.Fatal("Put() accepted a key outside the storage")
+	}
+	if err := store.Put(ctx, "notes/a.txt", strings.NewReader("hello"), 5, "text/plain"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	body, err := store.Open(ctx, "notes/a.txt")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "hello" {
+		t.Errorf("Open() content = %q, want %q", data, "hello")
+	}
+
+	if err := store.Delete(ctx, "notes/a.txt"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Open(ctx, "notes/a.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open() after Delete() error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,100 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the RFC 6238 SHA-1 test key "12345678901234567890" in base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	// RFC 6238 appendix B, truncated to six digits
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatalf("Code(%d) error = %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("Code(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+
+	if _, err := Code("not base32!", time.Now()); err == nil {
+		t.Error("Code() accepted an invalid secret")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code := func(offset time.Duration) string {
+		c, err := Code(rfcSecret, now.Add(offset))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{"current", rfcSecret, code(0), Step(now), true},
+		{"lower-case secret and padded code", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", " " + code(0) + " ", Step(now), true},
+		{"previous period", rfcSecret, code(-Period * time.Second), Step(now) - 1, true},
+		{"next period", rfcSecret, code(Period * time.Second), Step(now) + 1, true},
+		{"outside skew", rfcSecret, code(-2 * Period * time.Second), 0, false},
+		{"wrong code", rfcSecret, "000000", 0, false},
+		{"too short", rfcSecret, code(0)[:5], 0, false},
+		{"invalid secret", "not base32!", code(0), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := Validate(tt.secret, tt.code, now)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("Validate() = %d, %v, want %d, %v", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+	if key, err := encoding.DecodeString(secret); err != nil || len(key) != 20 {
+		t.Errorf("GenerateSecret() = %q, want 20 base32 encoded bytes", secret)
+	}
+	if other, _ := GenerateSecret(); other == secret {
+		t.Error("GenerateSecret() returned the same secret twice")
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri, err := url.Parse(URI("Uni Admin", "ann@example.com", rfcSecret))
+	if err != nil {
+		t.Fatalf("URI() is not a URL: %v", err)
+	}
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/Uni Admin:ann@example.com" {
+		t.Errorf("URI() = %s", uri)
+	}
+	query := uri.Query()
+	if query.Get("secret") != rfcSecret || query.Get("issuer") != "Uni Admin" || query.Get("digits") != "6" || query.Get("period") != "30" {
+		t.Errorf("URI() query = %v", query)
+	}
+}
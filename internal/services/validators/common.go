@@ -0,0 +1,190 @@
+// Package validators checks request data against the rules declared in `validate` struct tags
+// and collects every failure as a field error, whose message is rendered in the language of
+// the request.
+//
+// Besides the rules built into go-playground/validator, these are registered:
+//
+//	phone     E.164 phone number, e.g. +14155550123
+//	country   ISO 3166-1 alpha-2 country code, e.g. DE
+//	website   http or https URL
+//	timezone  IANA time zone name, e.g. Europe/Berlin (built in)
+//	past      time before now
+//	notblank  string with more than whitespace
+//	slug      lowercase letters and digits joined by '-' or '_', e.g. privacy-policy
+//	locale    BCP 47 language tag, e.g. es-MX
+package validators
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	localization "goUniAdmin/internal/services/common"
+
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/text/language"
+)
+
+// FieldError is a failed rule on one field
+type FieldError struct {
+	Field   string `json:"field" example:"mobile"`
+	Code    string `json:"code" example:"phone"` // The rule that failed
+	Message string `json:"message" example:"mobile must be a phone number in international format, e.g. +14155550123"`
+	Param   string `json:"-"` // The rule's parameter, e.g. the options of oneof
+}
+
+// Errors is the list of field errors found in a value. It is an error so it can be returned
+// from validation functions; use Err to return nil when it is empty.
+type Errors []FieldError
+
+// Error implements error with the English messages
+func (e Errors) Error() string {
+	return e.Message("en")
+}
+
+// Message joins the messages of all errors in lang
+func (e Errors) Message(lang string) string {
+	localized := e.Localize(lang)
+	messages := make([]string, len(localized))
+	for i, fieldErr := range localized {
+		messages[i] = fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Err returns the errors, or nil if there are none
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Add records a failed rule on a field, for checks that cannot be expressed as tags
+func (e *Errors) Add(field, code, param string) {
+	*e = append(*e, FieldError{Field: field, Code: code, Param: param})
+}
+
+// Localize returns a copy of the errors with their messages in lang, which may be an
+// Accept-Language header value. Rules without a message of their own get a generic one.
+func (e Errors) Localize(lang string) Errors {
+	localized := make(Errors, len(e))
+	for i, fieldErr := range e {
+		data := map[string]interface{}{"Field": fieldErr.Field, "Param": fieldErr.Param}
+		messageID := "validation_" + fieldErr.Code
+		message := localization.Localize(lang, messageID, data)
+		if message == messageID {
+			message = localization.Localize(lang, "validation_invalid", data)
+		}
+		fieldErr.Message = message
+		localized[i] = fieldErr
+	}
+	return localized
+}
+
+var (
+	engine     *validator.Validate
+	engineOnce sync.Once
+)
+
+// getEngine returns the shared validator with the custom rules registered
+func getEngine() *validator.Validate {
+	engineOnce.Do(func() {
+		engine = validator.New(validator.WithRequiredStructEnabled())
+
+		// Report fields by their JSON names, which is what clients send
+		engine.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return field.Name
+			}
+			return name
+		})
+
+		engine.RegisterAlias("phone", "e164")
+		engine.RegisterAlias("country", "iso3166_1_alpha2")
+		engine.RegisterAlias("website", "http_url")
+		_ = engine.RegisterValidation("past", isPast)
+		_ = engine.RegisterValidation("notblank", isNotBlank)
+		_ = engine.RegisterValidation("slug", isSlug)
+		_ = engine.RegisterValidation("locale", isLocale)
+	})
+	return engine
+}
+
+// isPast accepts times before now
+func isPast(fl validator.FieldLevel) bool {
+	t, ok := fl.Field().Interface().(time.Time)
+	return ok && t.Before(time.Now())
+}
+
+// isNotBlank accepts strings with a non-whitespace character
+func isNotBlank(fl validator.FieldLevel) bool {
+	return strings.TrimSpace(fl.Field().String()) != ""
+}
+
+// slugPattern matches slugs such as "verify_email" or "privacy-policy"
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:[_-][a-z0-9]+)*$`)
+
+// isSlug accepts lowercase letters and digits joined by single '-' or '_'
+func isSlug(fl validator.FieldLevel) bool {
+	return slugPattern.MatchString(fl.Field().String())
+}
+
+// isLocale accepts well-formed BCP 47 language tags
+func isLocale(fl validator.FieldLevel) bool {
+	_, err := language.Parse(fl.Field().String())
+	return err == nil
+}
+
+// Struct checks s against its `validate` tags and returns every failure
+func Struct(s interface{}) Errors {
+	err := getEngine().Struct(s)
+	if err == nil {
+		return nil
+	}
+	validationErrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		// Only returned for a value that is not a struct, which is a programming error
+		panic(err)
+	}
+
+	errs := make(Errors, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		errs.Add(fieldName(fieldErr), fieldErr.Tag(), fieldErr.Param())
+	}
+	return errs
+}
+
+// Var checks a single value against tag, reporting failures under field. It suits optional
+// pointer fields, whose omitempty does not skip an empty string that is set.
+func Var(field string, value interface{}, tag string) Errors {
+	err := getEngine().Var(value, tag)
+	if err == nil {
+		return nil
+	}
+	validationErrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		panic(err)
+	}
+
+	errs := make(Errors, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		errs.Add(field, fieldErr.Tag(), fieldErr.Param())
+	}
+	return errs
+}
+
+// fieldName returns the JSON path of the field without the top-level struct name
+func fieldName(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fieldErr.Field()
+}
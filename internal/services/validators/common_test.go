@@ -0,0 +1,113 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type address struct {
+	Country string `json:"country" validate:"required,country"`
+}
+
+type profile struct {
+	Name     string    `json:"name" validate:"notblank"`
+	Mobile   string    `json:"mobile" validate:"omitempty,phone"`
+	Website  string    `json:"website" validate:"omitempty,website"`
+	Timezone string    `json:"timezone" validate:"omitempty,timezone"`
+	Born     time.Time `json:"born" validate:"omitempty,past"`
+	Role     string    `json:"role" validate:"oneof=admin editor"`
+	Slug     string    `json:"slug" validate:"omitempty,slug"`
+	Locale   string    `json:"locale" validate:"omitempty,locale"`
+	Address  address   `json:"address"`
+	Internal string    `json:"-" validate:"omitempty,email"`
+}
+
+func validProfile() profile {
+	return profile{
+		Name:     "Ann",
+		Mobile:   "+14155550123",
+		Website:  "https://example.com",
+		Timezone: "Europe/Berlin",
+		Born:     time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		Role:     "admin",
+		Slug:     "privacy-policy",
+		Locale:   "es-MX",
+		Address:  address{Country: "DE"},
+	}
+}
+
+func TestStruct(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*profile)
+		want   Errors
+	}{
+		{"valid", func(*profile) {}, nil},
+		{"blank name", func(p *profile) { p.Name = "  " }, Errors{{Field: "name", Code: "notblank"}}},
+		{"local phone", func(p *profile) { p.Mobile = "0415555" }, Errors{{Field: "mobile", Code: "phone"}}},
+		{"ftp website", func(p *profile) { p.Website = "ftp://example.com" }, Errors{{Field: "website", Code: "website"}}},
+		{"unknown timezone", func(p *profile) { p.Timezone = "Mars/Olympus" }, Errors{{Field: "timezone", Code: "timezone"}}},
+		{"future birth date", func(p *profile) { p.Born = time.Now().Add(time.Hour) }, Errors{{Field: "born", Code: "past"}}},
+		{"role not in options", func(p *profile) { p.Role = "owner" }, Errors{{Field: "role", Code: "oneof", Param: "admin editor"}}},
+		{"uppercase slug", func(p *profile) { p.Slug = "Privacy" }, Errors{{Field: "slug", Code: "slug"}}},
+		{"doubled slug separator", func(p *profile) { p.Slug = "privacy--policy" }, Errors{{Field: "slug", Code: "slug"}}},
+		{"malformed locale", func(p *profile) { p.Locale = "es_MX!" }, Errors{{Field: "locale", Code: "locale"}}},
+		{"nested field", func(p *profile) { p.Address.Country = "Germany" }, Errors{{Field: "address.country", Code: "country"}}},
+		{
+			name:   "every failure reported",
+			modify: func(p *profile) { p.Name = ""; p.Address.Country = "" },
+			want:   Errors{{Field: "name", Code: "notblank"}, {Field: "address.country", Code: "required"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := validProfile()
+			tt.modify(&p)
+			if got := Struct(p); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Struct() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVar(t *testing.T) {
+	if errs := Var("email", "ann@example.com", "email"); errs != nil {
+		t.Errorf("Var() = %+v, want nil", errs)
+	}
+	want := Errors{{Field: "email", Code: "email"}}
+	if got := Var("email", "", "email"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Var() = %+v, want %+v", got, want)
+	}
+}
+
+func TestErrorsLocalize(t *testing.T) {
+	var errs Errors
+	if errs.Err() != nil {
+		t.Error("Err() of no errors is not nil")
+	}
+	errs.Add("role", "oneof", "admin editor")
+	errs.Add("code", "no_such_rule", "")
+	if errs.Err() == nil {
+		t.Error("Err() of errors is nil")
+	}
+
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"en", "role must be one of: admin editor; code is invalid"},
+		{"de-DE,de;q=0.9", "role muss einer der folgenden Werte sein: admin editor; code ist ungültig"},
+	}
+	for _, tt := range tests {
+		if got := errs.Message(tt.lang); got != tt.want {
+			t.Errorf("Message(%q) = %q, want %q", tt.lang, got, tt.want)
+		}
+	}
+	if errs.Error() != errs.Message("en") {
+		t.Errorf("Error() = %q, want the English message", errs.Error())
+	}
+	if errs[0].Message != "" {
+		t.Error("Localize() modified its receiver")
+	}
+}
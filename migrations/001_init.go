@@ -0,0 +1,53 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 1,
+		Name:    "init",
+		Up: `
+CREATE TABLE IF NOT EXISTS admins (
+	id uuid PRIMARY KEY,
+	first_name text NOT NULL,
+	last_name text NOT NULL,
+	user_name text,
+	mobile text,
+	email_id text NOT NULL,
+	password text NOT NULL,
+	photo text,
+	email_verification_status boolean DEFAULT false,
+	verification_token text,
+	verification_token_creation_time timestamptz,
+	date_of_birth timestamptz,
+	gender text,
+	website text,
+	address text,
+	fb_id text,
+	twitter_id text,
+	instagram_id text,
+	github_id text,
+	codepen text,
+	slack text,
+	send_otp_token text,
+	forgot_token text,
+	forgot_token_creation_time timestamptz,
+	device_token text,
+	device text,
+	is_theme_dark boolean,
+	added_by uuid,
+	country_code text,
+	time_zone text,
+	date_format text,
+	currency text,
+	table_column_settings jsonb,
+	status boolean DEFAULT true,
+	is_deleted boolean DEFAULT false,
+	created_at timestamptz,
+	updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_admins_email_id ON admins (email_id);
+`,
+		Down: `
+DROP TABLE IF EXISTS admins;
+`,
+	})
+}
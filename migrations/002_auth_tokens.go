@@ -0,0 +1,42 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 2,
+		Name:    "auth_tokens",
+		Up: `
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+	id uuid PRIMARY KEY,
+	admin_id uuid NOT NULL,
+	family_id uuid NOT NULL,
+	token_hash text NOT NULL,
+	expires_at timestamptz NOT NULL,
+	revoked_at timestamptz,
+	replaced_by uuid,
+	created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_admin_id ON refresh_tokens (admin_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+	jti text PRIMARY KEY,
+	admin_id uuid,
+	expires_at timestamptz NOT NULL,
+	created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_admin_id ON revoked_tokens (admin_id);
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens (expires_at);
+
+CREATE TABLE IF NOT EXISTS admin_revocations (
+	admin_id uuid PRIMARY KEY,
+	revoked_at timestamptz NOT NULL
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS admin_revocations;
+DROP TABLE IF EXISTS revoked_tokens;
+DROP TABLE IF EXISTS refresh_tokens;
+`,
+	})
+}
@@ -0,0 +1,38 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 3,
+		Name:    "roles",
+		Up: `
+CREATE TABLE IF NOT EXISTS roles (
+	id uuid PRIMARY KEY,
+	name text NOT NULL,
+	description text,
+	is_system boolean DEFAULT false,
+	created_at timestamptz,
+	updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_roles_name ON roles (name);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+	role_id uuid NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+	permission text NOT NULL,
+	PRIMARY KEY (role_id, permission)
+);
+
+CREATE TABLE IF NOT EXISTS admin_roles (
+	admin_id uuid NOT NULL,
+	role_id uuid NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+	created_at timestamptz,
+	PRIMARY KEY (admin_id, role_id)
+);
+CREATE INDEX IF NOT EXISTS idx_admin_roles_role_id ON admin_roles (role_id);
+`,
+		Down: `
+DROP TABLE IF EXISTS admin_roles;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS roles;
+`,
+	})
+}
@@ -0,0 +1,26 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 4,
+		Name:    "email_templates",
+		Up: `
+CREATE TABLE IF NOT EXISTS email_templates (
+	id uuid PRIMARY KEY,
+	slug text NOT NULL,
+	locale text NOT NULL DEFAULT 'en',
+	subject text NOT NULL,
+	html_body text,
+	text_body text,
+	placeholders jsonb,
+	status boolean DEFAULT true,
+	created_at timestamptz,
+	updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email_templates_slug_locale ON email_templates (slug, locale);
+`,
+		Down: `
+DROP TABLE IF EXISTS email_templates;
+`,
+	})
+}
@@ -0,0 +1,28 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 5,
+		Name:    "two_factor",
+		Up: `
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS two_factor_enabled boolean DEFAULT false;
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS two_factor_secret text;
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS two_factor_last_step bigint DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS recovery_codes (
+	id uuid PRIMARY KEY,
+	admin_id uuid NOT NULL,
+	code_hash text NOT NULL,
+	used_at timestamptz,
+	created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_recovery_codes_admin_id ON recovery_codes (admin_id);
+`,
+		Down: `
+DROP TABLE IF EXISTS recovery_codes;
+ALTER TABLE admins DROP COLUMN IF EXISTS two_factor_last_step;
+ALTER TABLE admins DROP COLUMN IF EXISTS two_factor_secret;
+ALTER TABLE admins DROP COLUMN IF EXISTS two_factor_enabled;
+`,
+	})
+}
@@ -0,0 +1,20 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 6,
+		Name:    "login_attempts",
+		Up: `
+CREATE TABLE IF NOT EXISTS login_attempts (
+	key text PRIMARY KEY,
+	failures integer NOT NULL DEFAULT 0,
+	last_failed_at timestamptz,
+	locked_until timestamptz,
+	updated_at timestamptz
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS login_attempts;
+`,
+	})
+}
@@ -0,0 +1,25 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 7,
+		Name:    "admin_invitations",
+		Up: `
+CREATE TABLE IF NOT EXISTS admin_invitations (
+	id uuid PRIMARY KEY,
+	email_id text NOT NULL,
+	first_name text NOT NULL,
+	last_name text NOT NULL,
+	invited_by uuid NOT NULL,
+	expires_at timestamptz NOT NULL,
+	accepted_at timestamptz,
+	revoked_at timestamptz,
+	created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_admin_invitations_email_id ON admin_invitations (email_id);
+`,
+		Down: `
+DROP TABLE IF EXISTS admin_invitations;
+`,
+	})
+}
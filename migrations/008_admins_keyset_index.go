@@ -0,0 +1,14 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 8,
+		Name:    "admins_keyset_index",
+		Up: `
+CREATE INDEX IF NOT EXISTS idx_admins_created_at_id ON admins (created_at, id);
+`,
+		Down: `
+DROP INDEX IF EXISTS idx_admins_created_at_id;
+`,
+	})
+}
@@ -0,0 +1,28 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 9,
+		Name:    "audit_logs",
+		Up: `
+CREATE TABLE IF NOT EXISTS audit_logs (
+	id uuid PRIMARY KEY,
+	actor_id uuid,
+	action text NOT NULL,
+	entity text NOT NULL,
+	entity_id text,
+	before jsonb,
+	after jsonb,
+	ip_address text,
+	user_agent text,
+	created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id ON audit_logs (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs (entity, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at_id ON audit_logs (created_at, id);
+`,
+		Down: `
+DROP TABLE IF EXISTS audit_logs;
+`,
+	})
+}
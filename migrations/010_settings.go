@@ -0,0 +1,24 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 10,
+		Name:    "settings",
+		Up: `
+CREATE TABLE IF NOT EXISTS settings (
+	id uuid PRIMARY KEY,
+	key text NOT NULL UNIQUE,
+	category text NOT NULL DEFAULT 'general',
+	type text NOT NULL,
+	value jsonb NOT NULL,
+	description text,
+	created_at timestamptz,
+	updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_settings_category ON settings (category);
+`,
+		Down: `
+DROP TABLE IF EXISTS settings;
+`,
+	})
+}
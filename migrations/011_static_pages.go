@@ -0,0 +1,28 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 11,
+		Name:    "static_pages",
+		Up: `
+CREATE TABLE IF NOT EXISTS static_pages (
+	id uuid PRIMARY KEY,
+	slug text NOT NULL,
+	locale text NOT NULL DEFAULT 'en',
+	title text NOT NULL,
+	body text,
+	meta_title text,
+	meta_description text,
+	meta_keywords text,
+	status text NOT NULL DEFAULT 'draft',
+	publish_at timestamptz,
+	created_at timestamptz,
+	updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_static_pages_slug_locale ON static_pages (slug, locale);
+`,
+		Down: `
+DROP TABLE IF EXISTS static_pages;
+`,
+	})
+}
@@ -0,0 +1,37 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 12,
+		Name:    "master_data",
+		Up: `
+CREATE TABLE IF NOT EXISTS master_types (
+	id uuid PRIMARY KEY,
+	code text NOT NULL UNIQUE,
+	name text NOT NULL,
+	description text,
+	is_active boolean NOT NULL DEFAULT true,
+	created_at timestamptz,
+	updated_at timestamptz
+);
+CREATE TABLE IF NOT EXISTS master_values (
+	id uuid PRIMARY KEY,
+	type_id uuid NOT NULL REFERENCES master_types (id),
+	code text NOT NULL,
+	label text NOT NULL,
+	labels jsonb,
+	parent_id uuid REFERENCES master_values (id),
+	sort_order bigint NOT NULL DEFAULT 0,
+	is_active boolean NOT NULL DEFAULT true,
+	created_at timestamptz,
+	updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_master_values_type_code ON master_values (type_id, code);
+CREATE INDEX IF NOT EXISTS idx_master_values_parent_id ON master_values (parent_id);
+`,
+		Down: `
+DROP TABLE IF EXISTS master_values;
+DROP TABLE IF EXISTS master_types;
+`,
+	})
+}
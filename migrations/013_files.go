@@ -0,0 +1,28 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 13,
+		Name:    "files",
+		Up: `
+CREATE TABLE IF NOT EXISTS files (
+	id uuid PRIMARY KEY,
+	owner_id uuid NOT NULL,
+	purpose text NOT NULL DEFAULT 'attachment',
+	original_name text,
+	content_type text NOT NULL,
+	size bigint NOT NULL,
+	storage_key text NOT NULL UNIQUE,
+	thumbnail_key text,
+	width bigint,
+	height bigint,
+	created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_files_owner_id ON files (owner_id);
+CREATE INDEX IF NOT EXISTS idx_files_created_at_id ON files (created_at, id);
+`,
+		Down: `
+DROP TABLE IF EXISTS files;
+`,
+	})
+}
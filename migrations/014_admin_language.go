@@ -0,0 +1,14 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 14,
+		Name:    "admin_language",
+		Up: `
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS language text;
+`,
+		Down: `
+ALTER TABLE admins DROP COLUMN IF EXISTS language;
+`,
+	})
+}
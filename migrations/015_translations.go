@@ -0,0 +1,22 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 15,
+		Name:    "translations",
+		Up: `
+CREATE TABLE IF NOT EXISTS translations (
+	id uuid PRIMARY KEY,
+	language text NOT NULL,
+	message_id text NOT NULL,
+	text text NOT NULL,
+	created_at timestamptz,
+	updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_translations_language_message_id ON translations (language, message_id);
+`,
+		Down: `
+DROP TABLE IF EXISTS translations;
+`,
+	})
+}
@@ -0,0 +1,31 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 16,
+		Name:    "super_admin_backfill",
+		// Admins created before roles existed had full access. While no admin has a role yet,
+		// each of them is granted super_admin so upgrading does not lock them out.
+		Up: `
+INSERT INTO roles (id, name, description, is_system, created_at, updated_at)
+VALUES (gen_random_uuid(), 'super_admin', 'Full access to every feature', true, now(), now())
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT id, '*' FROM roles WHERE name = 'super_admin'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO admin_roles (admin_id, role_id, created_at)
+SELECT admins.id, roles.id, now()
+FROM admins CROSS JOIN roles
+WHERE roles.name = 'super_admin'
+	AND admins.is_deleted = false
+	AND NOT EXISTS (SELECT 1 FROM admin_roles)
+ON CONFLICT DO NOTHING;
+`,
+		// The grants cannot be told apart from ones made later, so they are kept
+		Down: `
+SELECT 1;
+`,
+	})
+}
@@ -0,0 +1,85 @@
+package migrations
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	gosqlite "github.com/glebarez/go-sqlite"
+	"github.com/google/uuid"
+)
+
+var registerPostgresFuncs sync.Once
+
+// backfillSchema is the part of the schema migration 016 reads and writes, in SQLite
+const backfillSchema = `
+CREATE TABLE admins (id TEXT PRIMARY KEY, is_deleted BOOLEAN NOT NULL DEFAULT false);
+CREATE TABLE roles (id TEXT PRIMARY KEY, name TEXT NOT NULL UNIQUE, description TEXT, is_system BOOLEAN, created_at DATETIME, updated_at DATETIME);
+CREATE TABLE role_permissions (role_id TEXT NOT NULL, permission TEXT NOT NULL, PRIMARY KEY (role_id, permission));
+CREATE TABLE admin_roles (admin_id TEXT NOT NULL, role_id TEXT NOT NULL, created_at DATETIME, PRIMARY KEY (admin_id, role_id));
+`
+
+func TestSuperAdminBackfill(t *testing.T) {
+	// The migration calls these Postgres functions
+	registerPostgresFuncs.Do(func() {
+		gosqlite.MustRegisterScalarFunction("now", 0, func(*gosqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+			return time.Now().UTC().Format(time.RFC3339Nano), nil
+		})
+		gosqlite.MustRegisterScalarFunction("gen_random_uuid", 0, func(*gosqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+			return uuid.NewString(), nil
+		})
+	})
+
+	tests := []struct {
+		name     string
+		existing map[string]string // Role name by admin ID granted before the migration
+		want     map[string]string
+	}{
+		{
+			name:     "no roles granted yet",
+			existing: nil,
+			want:     map[string]string{"ann": "super_admin", "bo": "super_admin"},
+		},
+		{
+			name:     "roles already granted",
+			existing: map[string]string{"ann": "viewer"},
+			want:     map[string]string{"ann": "viewer"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := openTestDB(t)
+			if err := conn.Exec(backfillSchema).Error; err != nil {
+				t.Fatalf("create schema: %v", err)
+			}
+			conn.Exec("INSERT INTO admins (id, is_deleted) VALUES ('ann', false), ('bo', false), ('cy', true)")
+			for adminID, role := range tt.existing {
+				conn.Exec("INSERT INTO roles (id, name) VALUES (?, ?)", role+"-id", role)
+				conn.Exec("INSERT INTO admin_roles (admin_id, role_id) VALUES (?, ?)", adminID, role+"-id")
+			}
+
+			m := &Migrator{db: conn, migrations: []Migration{registry[16]}}
+			if _, err := m.Up(); err != nil {
+				t.Fatalf("Up() error = %v", err)
+			}
+
+			var grants []struct{ AdminID, Name string }
+			conn.Raw("SELECT admin_roles.admin_id, roles.name FROM admin_roles JOIN roles ON roles.id = admin_roles.role_id").Scan(&grants)
+			got := map[string]string{}
+			for _, grant := range grants {
+				got[grant.AdminID] = grant.Name
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("roles after migration = %v, want %v", got, tt.want)
+			}
+
+			var permissions []string
+			conn.Raw("SELECT permission FROM role_permissions JOIN roles ON roles.id = role_permissions.role_id WHERE roles.name = 'super_admin'").Scan(&permissions)
+			if !reflect.DeepEqual(permissions, []string{"*"}) {
+				t.Errorf("super_admin permissions = %v, want [*]", permissions)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package migrations
+
+func init() {
+	register(Migration{
+		Version: 17,
+		Name:    "invitation_roles",
+		Up: `
+ALTER TABLE admin_invitations ADD COLUMN IF NOT EXISTS role_ids jsonb;
+`,
+		Down: `
+ALTER TABLE admin_invitations DROP COLUMN IF EXISTS role_ids;
+`,
+	})
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// fileNamePattern matches migration files such as 001_init.go
+	fileNamePattern = regexp.MustCompile(`^(\d+)_[a-z0-9_]+\.go$`)
+	// nonNameChars matches characters not allowed in a migration name
+	nonNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// migrationFileTemplate is the skeleton written by Create
+const migrationFileTemplate = "package migrations\n" +
+	"\n" +
+	"func init() {\n" +
+	"\tregister(Migration{\n" +
+	"\t\tVersion: %d,\n" +
+	"\t\tName:    %q,\n" +
+	"\t\tUp: `\n" +
+	"`,\n" +
+	"\t\tDown: `\n" +
+	"`,\n" +
+	"\t})\n" +
+	"}\n"
+
+// Create writes a new, empty migration file to dir and returns its path. The version is
+// one higher than the highest existing file or registered migration.
+func Create(dir, name string) (string, error) {
+	name = strings.Trim(nonNameChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", fmt.Errorf("migration name must contain letters or digits")
+	}
+
+	var next int64 = 1
+	for version := range registry {
+		if version >= next {
+			next = version + 1
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		if version, err := strconv.ParseInt(match[1], 10, 64); err == nil && version >= next {
+			next = version + 1
+		}
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%03d_%s.go", next, name))
+	content := fmt.Sprintf(migrationFileTemplate, next, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCreate(t *testing.T) {
+	latest := All()[len(All())-1].Version
+
+	tests := []struct {
+		name     string
+		existing []string
+		input    string
+		want     string
+	}{
+		{"after the registered migrations", nil, "add_notes", fmt.Sprintf("%03d_add_notes.go", latest+1)},
+		{"after a newer file", []string{"040_later.go", "README.md"}, "add_notes", "041_add_notes.go"},
+		{"name normalised", nil, " Add Notes-Table! ", fmt.Sprintf("%03d_add_notes_table.go", latest+1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, name := range tt.existing {
+				if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			path, err := Create(dir, tt.input)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if filepath.Base(path) != tt.want {
+				t.Errorf("Create() = %s, want %s", filepath.Base(path), tt.want)
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(content), "register(Migration{") {
+				t.Errorf("created file = %q, want a registered migration", content)
+			}
+		})
+	}
+
+	if _, err := Create(t.TempDir(), "--"); err == nil {
+		t.Error("Create() with an empty name succeeded")
+	}
+}
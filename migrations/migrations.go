@@ -0,0 +1,41 @@
+// Package migrations holds the versioned SQL migrations for the database schema.
+//
+// Each migration lives in its own file named <version>_<name>.go and registers itself
+// from init. Applied versions are tracked in the schema_migrations table; use
+// cmd/migrate to apply, roll back or create migrations.
+package migrations
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Migration is a single schema change with the SQL to apply and revert it
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string // SQL run by "migrate up"
+	Down    string // SQL run by "migrate down"
+}
+
+// registry holds every migration registered by the files in this package
+var registry = map[int64]Migration{}
+
+// register adds a migration to the registry. It panics on a duplicate version,
+// since two files claiming the same version is a programming error.
+func register(m Migration) {
+	if existing, ok := registry[m.Version]; ok {
+		panic(fmt.Sprintf("migrations: version %d registered twice (%s and %s)", m.Version, existing.Name, m.Name))
+	}
+	registry[m.Version] = m
+}
+
+// All returns the registered migrations ordered by version
+func All() []Migration {
+	list := make([]Migration, 0, len(registry))
+	for _, m := range registry {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list
+}
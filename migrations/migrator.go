@@ -0,0 +1,208 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// advisoryLockKey identifies the Postgres advisory lock held while migrating, so that
+// concurrent runners (e.g. several instances deploying at once) apply migrations one at a time
+const advisoryLockKey int64 = 7300424715232169
+
+// SchemaMigration records an applied migration
+type SchemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// TableName overrides the default table name
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrationStatus describes a migration and whether it has been applied
+type MigrationStatus struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"appliedAt,omitempty"`
+	Missing   bool       `json:"missing,omitempty"` // Applied but no longer present in the code
+}
+
+// Migrator applies and reverts migrations against a database
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator creates a migrator for the registered migrations
+func NewMigrator(db *gorm.DB) *Migrator {
+	return &Migrator{db: db, migrations: All()}
+}
+
+// Up applies every pending migration in version order and returns the ones applied
+func (m *Migrator) Up() ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(func() error {
+		done, err := m.appliedVersions()
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(migration); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the last n applied migrations, newest first, and returns the ones reverted
+func (m *Migrator) Down(n int) ([]Migration, error) {
+	if n < 1 {
+		return nil, errors.New("number of migrations to revert must be at least 1")
+	}
+
+	var reverted []Migration
+	err := m.withLock(func() error {
+		done, err := m.appliedVersions()
+		if err != nil {
+			return err
+		}
+		versions := make([]int64, 0, len(done))
+		for version := range done {
+			versions = append(versions, version)
+		}
+		sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+		known := make(map[int64]Migration, len(m.migrations))
+		for _, migration := range m.migrations {
+			known[migration.Version] = migration
+		}
+
+		for i := 0; i < n && i < len(versions); i++ {
+			migration, ok := known[versions[i]]
+			if !ok {
+				return fmt.Errorf("migration %d is applied but missing from the code, cannot revert it", versions[i])
+			}
+			if err := m.revert(migration); err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lists every known migration together with those applied but no longer in the code
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+	done, err := m.appliedVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := done[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+			delete(done, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range done {
+		appliedAt := record.AppliedAt
+		statuses = append(statuses, MigrationStatus{Version: record.Version, Name: record.Name, AppliedAt: &appliedAt, Missing: true})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// apply runs a migration and records it in the same transaction
+func (m *Migrator) apply(migration Migration) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Up).Error; err != nil {
+			return fmt.Errorf("migration %d_%s failed: %v", migration.Version, migration.Name, err)
+		}
+		return tx.Create(&SchemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+	})
+}
+
+// revert runs a migration's down SQL and removes its record in the same transaction
+func (m *Migrator) revert(migration Migration) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return fmt.Errorf("reverting migration %d_%s failed: %v", migration.Version, migration.Name, err)
+		}
+		return tx.Delete(&SchemaMigration{}, "version = ?", migration.Version).Error
+	})
+}
+
+// appliedVersions returns the applied migrations keyed by version
+func (m *Migrator) appliedVersions() (map[int64]SchemaMigration, error) {
+	var records []SchemaMigration
+	if err := m.db.Order("version").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	done := make(map[int64]SchemaMigration, len(records))
+	for _, record := range records {
+		done[record.Version] = record
+	}
+	return done, nil
+}
+
+// ensureTable creates the schema_migrations table if it does not exist
+func (m *Migrator) ensureTable() error {
+	if m.db.Migrator().HasTable(&SchemaMigration{}) {
+		return nil
+	}
+	return m.db.Migrator().CreateTable(&SchemaMigration{})
+}
+
+// withLock runs fn while holding the migration advisory lock. The lock is session scoped,
+// so it is taken on a dedicated connection that is kept until fn returns. Other databases
+// (e.g. SQLite in local tooling) have no advisory locks and run fn directly.
+func (m *Migrator) withLock(fn func() error) error {
+	if m.db.Dialector.Name() != "postgres" {
+		if err := m.ensureTable(); err != nil {
+			return err
+		}
+		return fn()
+	}
+
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %v", err)
+	}
+	defer conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockKey)
+
+	// Created under the lock so two first-time runners do not race on it
+	if err := m.ensureTable(); err != nil {
+		return err
+	}
+	return fn()
+}
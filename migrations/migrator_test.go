@@ -0,0 +1,247 @@
+package migrations
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testMigrations create and fill a table in SQL that SQLite understands
+var testMigrations = []Migration{
+	{Version: 1, Name: "create_notes", Up: "CREATE TABLE notes (id INTEGER PRIMARY KEY, body TEXT)", Down: "DROP TABLE notes"},
+	{Version: 2, Name: "add_note_title", Up: "ALTER TABLE notes ADD COLUMN title TEXT", Down: "ALTER TABLE notes DROP COLUMN title"},
+	{Version: 3, Name: "seed_notes", Up: "INSERT INTO notes (body, title) VALUES ('hello', 'first')", Down: "DELETE FROM notes"},
+}
+
+// openTestDB returns a fresh SQLite database
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	conn, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "migrations.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	return conn
+}
+
+// versions returns the versions of migrations in order
+func versions(migrations []Migration) []int64 {
+	list := make([]int64, 0, len(migrations))
+	for _, migration := range migrations {
+		list = append(list, migration.Version)
+	}
+	return list
+}
+
+// recordedVersions returns the versions recorded in schema_migrations in order
+func recordedVersions(t *testing.T, m *Migrator) []int64 {
+	t.Helper()
+	var list []int64
+	if err := m.db.Model(&SchemaMigration{}).Order("version").Pluck("version", &list).Error; err != nil {
+		t.Fatalf("read schema_migrations: %v", err)
+	}
+	return list
+}
+
+func TestUpAndDown(t *testing.T) {
+	m := &Migrator{db: openTestDB(t), migrations: testMigrations}
+
+	applied, err := m.Up()
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if got := versions(applied); !reflect.DeepEqual(got, []int64{1, 2, 3}) {
+		t.Errorf("Up() applied %v, want [1 2 3]", got)
+	}
+	if applied, err := m.Up(); err != nil || len(applied) != 0 {
+		t.Errorf("second Up() = %v, %v, want nothing applied", versions(applied), err)
+	}
+
+	reverted, err := m.Down(2)
+	if err != nil {
+		t.Fatalf("Down(2) error = %v", err)
+	}
+	if got := versions(reverted); !reflect.DeepEqual(got, []int64{3, 2}) {
+		t.Errorf("Down(2) reverted %v, want newest first [3 2]", got)
+	}
+	if got := recordedVersions(t, m); !reflect.DeepEqual(got, []int64{1}) {
+		t.Errorf("recorded versions after Down(2) = %v, want [1]", got)
+	}
+	if m.db.Migrator().HasColumn("notes", "title") {
+		t.Error("Down(2) kept the column added by migration 2")
+	}
+
+	if _, err := m.Down(0); err == nil {
+		t.Error("Down(0) succeeded, want an error")
+	}
+	reverted, err = m.Down(5)
+	if err != nil || !reflect.DeepEqual(versions(reverted), []int64{1}) {
+		t.Errorf("Down(5) = %v, %v, want only [1] reverted", versions(reverted), err)
+	}
+	if m.db.Migrator().HasTable("notes") {
+		t.Error("reverting every migration kept their table")
+	}
+}
+
+func TestUpSkipsAppliedVersions(t *testing.T) {
+	m := &Migrator{db: openTestDB(t), migrations: testMigrations[:1]}
+	if _, err := m.Up(); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	// Version 1 would fail if it ran again, since its table exists
+	m.migrations = testMigrations
+	applied, err := m.Up()
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if got := versions(applied); !reflect.DeepEqual(got, []int64{2, 3}) {
+		t.Errorf("Up() applied %v, want only the pending [2 3]", got)
+	}
+}
+
+func TestUpStopsAtFailedMigration(t *testing.T) {
+	broken := Migration{Version: 2, Name: "broken", Up: "CREATE TABLE notes (id INTEGER)", Down: "SELECT 1"}
+	m := &Migrator{db: openTestDB(t), migrations: []Migration{testMigrations[0], broken, testMigrations[2]}}
+
+	applied, err := m.Up()
+	if err == nil || !strings.Contains(err.Error(), "2_broken") {
+		t.Fatalf("Up() error = %v, want migration 2 to fail", err)
+	}
+	if got := versions(applied); !reflect.DeepEqual(got, []int64{1}) {
+		t.Errorf("Up() applied %v, want [1]", got)
+	}
+	if got := recordedVersions(t, m); !reflect.DeepEqual(got, []int64{1}) {
+		t.Errorf("recorded versions = %v, want the failed and later migrations unrecorded", got)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	m := &Migrator{db: openTestDB(t), migrations: testMigrations[:2]}
+	if _, err := m.Up(); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	// A version applied by a newer build, and one dropped from the code since
+	orphans := []SchemaMigration{
+		{Version: 9, Name: "from_newer_build", AppliedAt: time.Now()},
+		{Version: 0, Name: "removed", AppliedAt: time.Now()},
+	}
+	if err := m.db.Create(&orphans).Error; err != nil {
+		t.Fatalf("record orphans: %v", err)
+	}
+	m.migrations = testMigrations
+
+	statuses, err := m.Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	type row struct {
+		Version int64
+		Applied bool
+		Missing bool
+	}
+	var got []row
+	for _, status := range statuses {
+		got = append(got, row{status.Version, status.AppliedAt != nil, status.Missing})
+	}
+	want := []row{{0, true, true}, {1, true, false}, {2, true, false}, {3, false, false}, {9, true, true}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Status() = %+v, want %+v", got, want)
+	}
+
+	if _, err := m.Down(1); err == nil || !strings.Contains(err.Error(), "missing from the code") {
+		t.Errorf("Down(1) of a version missing from the code error = %v", err)
+	}
+}
+
+func TestStatusOnEmptyDatabase(t *testing.T) {
+	m := &Migrator{db: openTestDB(t), migrations: testMigrations}
+	statuses, err := m.Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if len(statuses) != 3 || statuses[0].AppliedAt != nil {
+		t.Errorf("Status() = %+v, want every migration pending", statuses)
+	}
+}
+
+func TestRegisteredMigrationsAreOrdered(t *testing.T) {
+	all := All()
+	for i, migration := range all {
+		if migration.Version != int64(i+1) {
+			t.Fatalf("migration %d_%s at position %d, want versions numbered from 1 without gaps", migration.Version, migration.Name, i)
+		}
+		if strings.TrimSpace(migration.Up) == "" || strings.TrimSpace(migration.Down) == "" {
+			t.Errorf("migration %d_%s lacks Up or Down SQL", migration.Version, migration.Name)
+		}
+	}
+}
+
+// TestUpWaitsForAdvisoryLock needs a Postgres database, named by TEST_DATABASE_URL. It only
+// creates schema_migrations, which is left in place.
+func TestUpWaitsForAdvisoryLock(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	conn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	sqlDB, err := conn.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	// Hold the lock as a concurrent runner would
+	ctx := context.Background()
+	holder, err := sqlDB.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer holder.Close()
+	if _, err := holder.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockKey); err != nil {
+		t.Fatalf("take lock: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := (&Migrator{db: conn}).Up()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("Up() returned %v while another runner held the lock", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if _, err := holder.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockKey); err != nil {
+		t.Fatalf("release lock: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Up() did not finish after the lock was released")
+	}
+
+	var acquired bool
+	if err := holder.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", advisoryLockKey).Scan(&acquired); err != nil {
+		t.Fatal(err)
+	}
+	if !acquired {
+		t.Fatal("Up() kept the lock after returning")
+	}
+	holder.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockKey)
+}
@@ -0,0 +1,8 @@
+#!/bin/sh
+# Applies pending migrations and seeds the database.
+# Usage: SEED_ADMIN_EMAIL=admin@example.com SEED_ADMIN_PASSWORD=... scripts/seed.sh [-fixtures scripts/fixtures.sample.yaml]
+set -e
+
+cd "$(dirname "$0")/.."
+go run ./cmd/migrate up
+go run ./cmd/seed "$@"